@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// adaptiveThreshold is the greatest number of elements an AdaptiveSet keeps
+// in a slice, beyond which scanning the slice is slower than hashing.
+const adaptiveThreshold = 16
+
+// AdaptiveSet is a set of comparable elements which chooses its
+// representation by size. A small AdaptiveSet stores its elements in a slice,
+// which for a handful of elements is smaller and faster than a map; once it
+// grows beyond a threshold it is upgraded to a Set, and remains one.
+//
+// An AdaptiveSet suits code creating many sets of unknown and widely varying
+// size, most of which stay small.
+//
+// Not thread safe, and not safe for concurrent modification.
+type AdaptiveSet[T comparable] struct {
+	items []T     // the elements, while small
+	set   *Set[T] // the elements, once upgraded
+}
+
+// NewAdaptiveSet creates a new empty AdaptiveSet with initial underlying
+// capacity of size. A size beyond the threshold creates an upgraded set.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func NewAdaptiveSet[T comparable](size int) *AdaptiveSet[T] {
+	if size > adaptiveThreshold {
+		return &AdaptiveSet[T]{set: New[T](size)}
+	}
+	return &AdaptiveSet[T]{items: make([]T, 0, max(0, size))}
+}
+
+// AdaptiveSetFrom creates a new AdaptiveSet containing each item in items.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func AdaptiveSetFrom[T comparable](items []T) *AdaptiveSet[T] {
+	s := NewAdaptiveSet[T](len(items))
+	s.InsertSlice(items)
+	return s
+}
+
+// Upgraded returns whether s has been upgraded from a slice to a Set.
+func (s *AdaptiveSet[T]) Upgraded() bool {
+	return s.set != nil
+}
+
+// Insert item into s.
+//
+// Return true if s was modified (item was not already in s), false otherwise.
+func (s *AdaptiveSet[T]) Insert(item T) bool {
+	if s.set != nil {
+		return s.set.Insert(item)
+	}
+	if slices.Contains(s.items, item) {
+		return false
+	}
+	if len(s.items) == adaptiveThreshold {
+		s.set = From(s.items)
+		s.items = nil
+		return s.set.Insert(item)
+	}
+	s.items = append(s.items, item)
+	return true
+}
+
+// InsertSlice will insert each item in items into s.
+//
+// Return true if s was modified (at least one item was not already in s), false otherwise.
+func (s *AdaptiveSet[T]) InsertSlice(items []T) bool {
+	modified := false
+	for _, item := range items {
+		if s.Insert(item) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// Remove item from s.
+//
+// Return true if s was modified (item was in s), false otherwise.
+func (s *AdaptiveSet[T]) Remove(item T) bool {
+	if s.set != nil {
+		return s.set.Remove(item)
+	}
+	i := slices.Index(s.items, item)
+	if i < 0 {
+		return false
+	}
+	last := len(s.items) - 1
+	s.items[i] = s.items[last]
+	var zero T
+	s.items[last] = zero
+	s.items = s.items[:last]
+	return true
+}
+
+// RemoveSlice will remove each item in items from s.
+//
+// Return true if s was modified (any item was in s), false otherwise.
+func (s *AdaptiveSet[T]) RemoveSlice(items []T) bool {
+	modified := false
+	for _, item := range items {
+		if s.Remove(item) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// Contains returns whether item is present in s.
+func (s *AdaptiveSet[T]) Contains(item T) bool {
+	if s.set != nil {
+		return s.set.Contains(item)
+	}
+	return slices.Contains(s.items, item)
+}
+
+// Size returns the cardinality of s.
+func (s *AdaptiveSet[T]) Size() int {
+	if s.set != nil {
+		return s.set.Size()
+	}
+	return len(s.items)
+}
+
+// Empty returns true if s contains no elements, false otherwise.
+func (s *AdaptiveSet[T]) Empty() bool {
+	return s.Size() == 0
+}
+
+// Slice creates a copy of s as a slice. Elements are in no particular order.
+func (s *AdaptiveSet[T]) Slice() []T {
+	if s.set != nil {
+		return s.set.Slice()
+	}
+	return append(make([]T, 0, len(s.items)), s.items...)
+}
+
+// ForEach calls visit for each element of s, in no particular order.
+// Iteration stops early if visit returns false.
+func (s *AdaptiveSet[T]) ForEach(visit func(item T) bool) {
+	if s.set != nil {
+		s.set.ForEach(visit)
+		return
+	}
+	for _, item := range s.items {
+		if !visit(item) {
+			return
+		}
+	}
+}
+
+// Set creates a Set containing the elements of s.
+func (s *AdaptiveSet[T]) Set() *Set[T] {
+	if s.set != nil {
+		return s.set.Copy()
+	}
+	return From(s.items)
+}
+
+// String creates a string representation of s, using "%v" printf formatting to transform
+// each element into a string. The result contains elements sorted by their lexical
+// string order.
+func (s *AdaptiveSet[T]) String() string {
+	if s.set != nil {
+		return s.set.String()
+	}
+	l := make([]string, 0, len(s.items))
+	for _, item := range s.items {
+		l = append(l, fmt.Sprintf("%v", item))
+	}
+	sort.Strings(l)
+	return fmt.Sprintf("%s", l)
+}
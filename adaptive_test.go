@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestAdaptiveSet_Insert(t *testing.T) {
+	s := NewAdaptiveSet[int](0)
+	must.True(t, s.Empty())
+	must.False(t, s.Upgraded())
+
+	for i := 1; i <= adaptiveThreshold; i++ {
+		must.True(t, s.Insert(i))
+		must.False(t, s.Insert(i))
+	}
+	must.False(t, s.Upgraded())
+	must.Eq(t, adaptiveThreshold, s.Size())
+
+	must.True(t, s.Insert(adaptiveThreshold+1))
+	must.True(t, s.Upgraded())
+	must.False(t, s.Insert(1))
+	must.Eq(t, adaptiveThreshold+1, s.Size())
+	must.Eq(t, From(ints(adaptiveThreshold+1)), s.Set())
+}
+
+func TestAdaptiveSet_Remove(t *testing.T) {
+	t.Run("small", func(t *testing.T) {
+		s := AdaptiveSetFrom([]int{1, 2, 3, 4})
+		must.True(t, s.Remove(2))
+		must.False(t, s.Remove(2))
+		must.False(t, s.Contains(2))
+		must.Eq(t, "[1 3 4]", s.String())
+
+		must.True(t, s.RemoveSlice([]int{1, 4, 9}))
+		must.Eq(t, []int{3}, s.Slice())
+	})
+
+	t.Run("upgraded", func(t *testing.T) {
+		s := AdaptiveSetFrom(ints(100))
+		must.True(t, s.Upgraded())
+		must.True(t, s.RemoveSlice(ints(99)))
+		must.Eq(t, "[100]", s.String())
+
+		// an upgraded set stays upgraded
+		must.True(t, s.Upgraded())
+		must.True(t, s.Insert(1))
+		must.True(t, s.Contains(1))
+	})
+}
+
+func TestAdaptiveSet_NewAdaptiveSet(t *testing.T) {
+	must.False(t, NewAdaptiveSet[int](adaptiveThreshold).Upgraded())
+	must.True(t, NewAdaptiveSet[int](adaptiveThreshold+1).Upgraded())
+	must.False(t, NewAdaptiveSet[int](-1).Upgraded())
+}
+
+func TestAdaptiveSet_ForEach(t *testing.T) {
+	for _, n := range []int{5, 50} {
+		s := AdaptiveSetFrom(ints(n))
+		visited := New[int](n)
+		s.ForEach(func(i int) bool {
+			visited.Insert(i)
+			return true
+		})
+		must.Eq(t, From(ints(n)), visited)
+		must.Eq(t, From(ints(n)), From(s.Slice()))
+
+		count := 0
+		s.ForEach(func(int) bool {
+			count++
+			return count < 3
+		})
+		must.Eq(t, 3, count)
+	}
+}
+
+func BenchmarkAdaptiveSet_Contains(b *testing.B) {
+	for _, n := range []int{4, 16, 1000} {
+		s := AdaptiveSetFrom(ints(n))
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s.Contains(i % (2 * n))
+			}
+		})
+	}
+}
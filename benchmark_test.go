@@ -109,6 +109,17 @@ func BenchmarkSet_Minimum(b *testing.B) {
 	}
 }
 
+func BenchmarkSet_Min(b *testing.B) {
+	for _, tc := range cases {
+		s := From(random[int](tc.size))
+		b.Run(tc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = s.Min(Cmp[int])
+			}
+		})
+	}
+}
+
 func BenchmarkHashSet_Minimum(b *testing.B) {
 	for _, tc := range cases {
 		hs := HashSetFrom[hashint, int](random[hashint](tc.size))
@@ -123,6 +134,30 @@ func BenchmarkHashSet_Minimum(b *testing.B) {
 	}
 }
 
+func BenchmarkHashSet_Min(b *testing.B) {
+	for _, tc := range cases {
+		hs := HashSetFrom[hashint, int](random[hashint](tc.size))
+		b.Run(tc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = hs.Min(Cmp[hashint])
+			}
+		})
+	}
+}
+
+func BenchmarkPersistentSet_Insert(b *testing.B) {
+	for _, tc := range cases {
+		ps := PersistentSetFrom(random[int](tc.size))
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				// each insert creates a new version, sharing the old one
+				_ = ps.Insert(i)
+			}
+		})
+	}
+}
+
 func BenchmarkTreeSet_Minimum(b *testing.B) {
 	for _, tc := range cases {
 		ts := TreeSetFrom[int, Compare[int]](random[int](tc.size), Cmp[int])
@@ -186,3 +221,63 @@ func BenchmarkTreeSet_Contains(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkSet_Union(b *testing.B) {
+	for _, tc := range cases {
+		s, o := From(random[int](tc.size)), From(random[int](tc.size))
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = s.Union(o)
+			}
+		})
+	}
+}
+
+func BenchmarkSet_Intersect(b *testing.B) {
+	for _, tc := range cases {
+		s := From(random[int](tc.size))
+		o := From(append(s.Slice()[:tc.size/2], random[int](tc.size/2)...))
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = s.Intersect(o)
+			}
+		})
+	}
+}
+
+func BenchmarkTreeSet_InsertSlice(b *testing.B) {
+	for _, tc := range cases {
+		items := random[int](tc.size)
+		b.Run(tc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ts := NewTreeSet[int, Compare[int]](Cmp[int])
+				ts.InsertSlice(items)
+			}
+		})
+	}
+}
+
+func BenchmarkForEach(b *testing.B) {
+	sets := readOnlys(ints(1_000))
+	names := make([]string, 0, len(sets))
+	for name := range sets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		s := sets[name]
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			count := 0
+			visit := func(int) bool {
+				count++
+				return true
+			}
+			for i := 0; i < b.N; i++ {
+				s.ForEach(visit)
+			}
+		})
+	}
+}
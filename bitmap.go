@@ -0,0 +1,678 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+	"strconv"
+)
+
+// arrayMax is the largest number of elements stored in an array container;
+// beyond this a bitmap container (8 KiB) is smaller than an array of uint16.
+const arrayMax = 4096
+
+// containerWords is the number of 64 bit words in a bitmap container.
+const containerWords = 1 << 16 / 64
+
+// Bitmap is a compressed set of uint64 integers, in the style of a Roaring
+// bitmap. It is much smaller than a Set[uint64] for integers that are sparse
+// but clustered, such as identifiers allocated sequentially in blocks.
+//
+// Elements are partitioned by their upper 48 bits into containers, each of
+// which stores the lower 16 bits of up to 65536 elements. A container with
+// few elements is a sorted array of uint16, while a container with many
+// elements is a fixed size bitmap. Set operations between bitmaps are
+// performed container by container, and between two bitmap containers are
+// performed 64 elements at a time.
+//
+// Elements are always iterated in ascending order.
+//
+// Not thread safe, and not safe for concurrent modification.
+type Bitmap struct {
+	keys       []uint64
+	containers []*container
+}
+
+// container holds the lower 16 bits of the elements of a Bitmap that share
+// the same upper 48 bits. Exactly one of array or words is in use, depending
+// on whether n exceeds arrayMax.
+type container struct {
+	array []uint16
+	words []uint64
+	n     int
+}
+
+// NewBitmap creates a new empty Bitmap.
+func NewBitmap() *Bitmap {
+	return new(Bitmap)
+}
+
+// BitmapFrom creates a new Bitmap containing each item in items.
+func BitmapFrom(items []uint64) *Bitmap {
+	b := NewBitmap()
+	b.InsertSlice(items)
+	return b
+}
+
+// BitmapOf creates a new Bitmap containing each item passed as an argument.
+func BitmapOf(items ...uint64) *Bitmap {
+	return BitmapFrom(items)
+}
+
+// splitKey returns the container key and the position within that container
+// of item.
+func splitKey(item uint64) (uint64, uint16) {
+	return item >> 16, uint16(item)
+}
+
+// find returns the index of the container for key, and whether it exists.
+func (b *Bitmap) find(key uint64) (int, bool) {
+	return slices.BinarySearch(b.keys, key)
+}
+
+// Insert item into b.
+//
+// Return true if b was modified (item was not already in b), false otherwise.
+func (b *Bitmap) Insert(item uint64) bool {
+	key, low := splitKey(item)
+	i, exists := b.find(key)
+	if !exists {
+		b.keys = slices.Insert(b.keys, i, key)
+		b.containers = slices.Insert(b.containers, i, &container{})
+	}
+	return b.containers[i].insert(low)
+}
+
+// InsertSlice will insert each item in items into b.
+//
+// Return true if b was modified (at least one item was not already in b), false otherwise.
+func (b *Bitmap) InsertSlice(items []uint64) bool {
+	modified := false
+	for _, item := range items {
+		if b.Insert(item) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// InsertRange will insert each integer from lo up to but not including hi
+// into b. Whole containers covered by the range are filled 64 elements at a
+// time.
+//
+// Return true if b was modified (at least one integer was not already in b), false otherwise.
+func (b *Bitmap) InsertRange(lo, hi uint64) bool {
+	if lo >= hi {
+		return false
+	}
+	r := NewBitmap()
+	for start := lo; start < hi; {
+		key, low := splitKey(start)
+		end := min(hi, (key+1)<<16) // exclusive, within this container
+		if end == 0 {
+			end = hi // the final container of the uint64 space
+		}
+		c := &container{words: make([]uint64, containerWords)}
+		fillWords(c.words, uint64(low), uint64(low)+(end-start))
+		r.keys = append(r.keys, key)
+		r.containers = append(r.containers, c.normalize())
+		start = end
+	}
+	before := b.Size()
+	b.InsertSet(r)
+	return b.Size() != before
+}
+
+// InsertSet will insert each element of o into b.
+//
+// Return true if b was modified (at least one item of o was not already in b), false otherwise.
+func (b *Bitmap) InsertSet(o *Bitmap) bool {
+	before := b.Size()
+	for j, key := range o.keys {
+		i, exists := b.find(key)
+		if exists {
+			b.containers[i] = b.containers[i].union(o.containers[j])
+			continue
+		}
+		b.keys = slices.Insert(b.keys, i, key)
+		b.containers = slices.Insert(b.containers, i, o.containers[j].clone())
+	}
+	return b.Size() != before
+}
+
+// Remove will remove item from b.
+//
+// Return true if b was modified (item was present), false otherwise.
+func (b *Bitmap) Remove(item uint64) bool {
+	key, low := splitKey(item)
+	i, exists := b.find(key)
+	if !exists || !b.containers[i].remove(low) {
+		return false
+	}
+	if b.containers[i].n == 0 {
+		b.keys = slices.Delete(b.keys, i, i+1)
+		b.containers = slices.Delete(b.containers, i, i+1)
+	}
+	return true
+}
+
+// RemoveSlice will remove each item in items from b.
+//
+// Return true if b was modified (any item was present), false otherwise.
+func (b *Bitmap) RemoveSlice(items []uint64) bool {
+	modified := false
+	for _, item := range items {
+		if b.Remove(item) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// RemoveSet will remove each element of o from b.
+//
+// Return true if b was modified (any item of o was present in b), false otherwise.
+func (b *Bitmap) RemoveSet(o *Bitmap) bool {
+	before := b.Size()
+	for j, key := range o.keys {
+		i, exists := b.find(key)
+		if !exists {
+			continue
+		}
+		b.containers[i] = b.containers[i].difference(o.containers[j])
+		if b.containers[i].n == 0 {
+			b.keys = slices.Delete(b.keys, i, i+1)
+			b.containers = slices.Delete(b.containers, i, i+1)
+		}
+	}
+	return b.Size() != before
+}
+
+// Contains returns whether item is present in b.
+func (b *Bitmap) Contains(item uint64) bool {
+	key, low := splitKey(item)
+	i, exists := b.find(key)
+	return exists && b.containers[i].contains(low)
+}
+
+// Size returns the cardinality of b.
+func (b *Bitmap) Size() int {
+	size := 0
+	for _, c := range b.containers {
+		size += c.n
+	}
+	return size
+}
+
+// Empty returns true if b contains no elements, false otherwise.
+func (b *Bitmap) Empty() bool {
+	return len(b.containers) == 0
+}
+
+// Min returns the smallest element of b, and true. If b is empty, zero and
+// false are returned.
+func (b *Bitmap) Min() (uint64, bool) {
+	if b.Empty() {
+		return 0, false
+	}
+	var result uint64
+	b.ForEach(func(item uint64) bool {
+		result = item
+		return false
+	})
+	return result, true
+}
+
+// Max returns the largest element of b, and true. If b is empty, zero and
+// false are returned.
+func (b *Bitmap) Max() (uint64, bool) {
+	if b.Empty() {
+		return 0, false
+	}
+	last := len(b.containers) - 1
+	return b.keys[last]<<16 | uint64(b.containers[last].max()), true
+}
+
+// Union returns a set that contains all elements of b and o combined.
+func (b *Bitmap) Union(o *Bitmap) *Bitmap {
+	result := &Bitmap{
+		keys:       make([]uint64, 0, max(len(b.keys), len(o.keys))),
+		containers: make([]*container, 0, max(len(b.keys), len(o.keys))),
+	}
+	i, j := 0, 0
+	for i < len(b.keys) || j < len(o.keys) {
+		switch {
+		case j == len(o.keys) || (i < len(b.keys) && b.keys[i] < o.keys[j]):
+			result.append(b.keys[i], b.containers[i].clone())
+			i++
+		case i == len(b.keys) || o.keys[j] < b.keys[i]:
+			result.append(o.keys[j], o.containers[j].clone())
+			j++
+		default:
+			result.append(b.keys[i], b.containers[i].union(o.containers[j]))
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// Intersect returns a set that contains elements that are present in both b and o.
+func (b *Bitmap) Intersect(o *Bitmap) *Bitmap {
+	result := NewBitmap()
+	i, j := 0, 0
+	for i < len(b.keys) && j < len(o.keys) {
+		switch {
+		case b.keys[i] < o.keys[j]:
+			i++
+		case o.keys[j] < b.keys[i]:
+			j++
+		default:
+			result.append(b.keys[i], b.containers[i].intersect(o.containers[j]))
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// Difference returns a set that contains elements of b that are not in o.
+func (b *Bitmap) Difference(o *Bitmap) *Bitmap {
+	result := NewBitmap()
+	j := 0
+	for i, key := range b.keys {
+		for j < len(o.keys) && o.keys[j] < key {
+			j++
+		}
+		if j < len(o.keys) && o.keys[j] == key {
+			result.append(key, b.containers[i].difference(o.containers[j]))
+		} else {
+			result.append(key, b.containers[i].clone())
+		}
+	}
+	return result
+}
+
+// append adds c to the end of b, unless c is empty. key must be greater than
+// every key already in b.
+func (b *Bitmap) append(key uint64, c *container) {
+	if c.n == 0 {
+		return
+	}
+	b.keys = append(b.keys, key)
+	b.containers = append(b.containers, c)
+}
+
+// Intersects returns whether b and o have at least one element in common.
+//
+// Returns as soon as a common element is found.
+func (b *Bitmap) Intersects(o *Bitmap) bool {
+	i, j := 0, 0
+	for i < len(b.keys) && j < len(o.keys) {
+		switch {
+		case b.keys[i] < o.keys[j]:
+			i++
+		case o.keys[j] < b.keys[i]:
+			j++
+		default:
+			if b.containers[i].intersects(o.containers[j]) {
+				return true
+			}
+			i++
+			j++
+		}
+	}
+	return false
+}
+
+// Subset returns whether o is a subset of b.
+//
+// Returns as soon as an element of o is found that is not in b.
+func (b *Bitmap) Subset(o *Bitmap) bool {
+	i := 0
+	for j, key := range o.keys {
+		for i < len(b.keys) && b.keys[i] < key {
+			i++
+		}
+		if i == len(b.keys) || b.keys[i] != key || !b.containers[i].subset(o.containers[j]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns whether b and o contain the same elements.
+func (b *Bitmap) Equal(o *Bitmap) bool {
+	if !slices.Equal(b.keys, o.keys) {
+		return false
+	}
+	for i, c := range b.containers {
+		if !c.equal(o.containers[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Copy creates a copy of b.
+func (b *Bitmap) Copy() *Bitmap {
+	result := &Bitmap{
+		keys:       slices.Clone(b.keys),
+		containers: make([]*container, len(b.containers)),
+	}
+	for i, c := range b.containers {
+		result.containers[i] = c.clone()
+	}
+	return result
+}
+
+// Slice creates a copy of b as a slice. Elements are in ascending order.
+func (b *Bitmap) Slice() []uint64 {
+	result := make([]uint64, 0, b.Size())
+	b.ForEach(func(item uint64) bool {
+		result = append(result, item)
+		return true
+	})
+	return result
+}
+
+// ForEach calls visit for each element of b, in ascending order. Iteration
+// stops early if visit returns false.
+func (b *Bitmap) ForEach(visit func(item uint64) bool) {
+	for i, c := range b.containers {
+		if !c.each(b.keys[i]<<16, visit) {
+			return
+		}
+	}
+}
+
+// String creates a string representation of b. The result contains elements
+// in ascending order.
+func (b *Bitmap) String() string {
+	l := make([]string, 0, b.Size())
+	b.ForEach(func(item uint64) bool {
+		l = append(l, strconv.FormatUint(item, 10))
+		return true
+	})
+	return fmt.Sprintf("%s", l)
+}
+
+// fillWords sets the bits of words from position lo up to but not including hi.
+func fillWords(words []uint64, lo, hi uint64) {
+	for lo < hi {
+		if lo%64 == 0 && hi-lo >= 64 {
+			words[lo/64] = ^uint64(0)
+			lo += 64
+			continue
+		}
+		words[lo/64] |= 1 << (lo % 64)
+		lo++
+	}
+}
+
+func (c *container) contains(low uint16) bool {
+	if c.words != nil {
+		return c.words[low/64]&(1<<(low%64)) != 0
+	}
+	_, exists := slices.BinarySearch(c.array, low)
+	return exists
+}
+
+func (c *container) insert(low uint16) bool {
+	if c.words != nil {
+		bit := uint64(1) << (low % 64)
+		if c.words[low/64]&bit != 0 {
+			return false
+		}
+		c.words[low/64] |= bit
+		c.n++
+		return true
+	}
+	i, exists := slices.BinarySearch(c.array, low)
+	if exists {
+		return false
+	}
+	c.array = slices.Insert(c.array, i, low)
+	c.n++
+	if c.n > arrayMax {
+		c.words, c.array = c.bitmap(), nil
+	}
+	return true
+}
+
+func (c *container) remove(low uint16) bool {
+	if c.words != nil {
+		bit := uint64(1) << (low % 64)
+		if c.words[low/64]&bit == 0 {
+			return false
+		}
+		c.words[low/64] &^= bit
+		c.n--
+		if c.n <= arrayMax {
+			c.array, c.words = c.sorted(), nil
+		}
+		return true
+	}
+	i, exists := slices.BinarySearch(c.array, low)
+	if !exists {
+		return false
+	}
+	c.array = slices.Delete(c.array, i, i+1)
+	c.n--
+	return true
+}
+
+func (c *container) max() uint16 {
+	if c.words == nil {
+		return c.array[len(c.array)-1]
+	}
+	for i := len(c.words) - 1; ; i-- {
+		if w := c.words[i]; w != 0 {
+			return uint16(i*64 + 63 - bits.LeadingZeros64(w))
+		}
+	}
+}
+
+// each calls visit with base plus each element of c, in ascending order.
+func (c *container) each(base uint64, visit func(uint64) bool) bool {
+	if c.words == nil {
+		for _, low := range c.array {
+			if !visit(base | uint64(low)) {
+				return false
+			}
+		}
+		return true
+	}
+	for i, w := range c.words {
+		for w != 0 {
+			low := uint64(i*64 + bits.TrailingZeros64(w))
+			if !visit(base | low) {
+				return false
+			}
+			w &= w - 1
+		}
+	}
+	return true
+}
+
+// bitmap returns the elements of c as a newly allocated bitmap.
+func (c *container) bitmap() []uint64 {
+	if c.words != nil {
+		return slices.Clone(c.words)
+	}
+	words := make([]uint64, containerWords)
+	for _, low := range c.array {
+		words[low/64] |= 1 << (low % 64)
+	}
+	return words
+}
+
+// sorted returns the elements of c as a newly allocated sorted array.
+func (c *container) sorted() []uint16 {
+	if c.words == nil {
+		return slices.Clone(c.array)
+	}
+	array := make([]uint16, 0, c.n)
+	c.each(0, func(low uint64) bool {
+		array = append(array, uint16(low))
+		return true
+	})
+	return array
+}
+
+// normalize recounts the elements of a bitmap container, and converts it to
+// an array container if it has become small enough.
+func (c *container) normalize() *container {
+	c.n = 0
+	for _, w := range c.words {
+		c.n += bits.OnesCount64(w)
+	}
+	if c.n <= arrayMax {
+		c.array, c.words = c.sorted(), nil
+	}
+	return c
+}
+
+func (c *container) clone() *container {
+	return &container{
+		array: slices.Clone(c.array),
+		words: slices.Clone(c.words),
+		n:     c.n,
+	}
+}
+
+// filter returns an array container of the elements of c for which keep
+// returns true. c must be an array container.
+func (c *container) filter(keep func(uint16) bool) *container {
+	result := &container{}
+	for _, low := range c.array {
+		if keep(low) {
+			result.array = append(result.array, low)
+		}
+	}
+	result.n = len(result.array)
+	return result
+}
+
+func (c *container) union(o *container) *container {
+	if c.words == nil && o.words == nil && c.n+o.n <= arrayMax {
+		result := &container{array: make([]uint16, 0, c.n+o.n)}
+		i, j := 0, 0
+		for i < len(c.array) || j < len(o.array) {
+			switch {
+			case j == len(o.array) || (i < len(c.array) && c.array[i] < o.array[j]):
+				result.array = append(result.array, c.array[i])
+				i++
+			case i == len(c.array) || o.array[j] < c.array[i]:
+				result.array = append(result.array, o.array[j])
+				j++
+			default:
+				result.array = append(result.array, c.array[i])
+				i++
+				j++
+			}
+		}
+		result.n = len(result.array)
+		return result
+	}
+	result := &container{words: c.bitmap()}
+	if o.words == nil {
+		for _, low := range o.array {
+			result.words[low/64] |= 1 << (low % 64)
+		}
+	} else {
+		for i, w := range o.words {
+			result.words[i] |= w
+		}
+	}
+	return result.normalize()
+}
+
+func (c *container) intersect(o *container) *container {
+	switch {
+	case c.words == nil:
+		return c.filter(o.contains)
+	case o.words == nil:
+		return o.filter(c.contains)
+	}
+	result := &container{words: make([]uint64, containerWords)}
+	for i, w := range c.words {
+		result.words[i] = w & o.words[i]
+	}
+	return result.normalize()
+}
+
+func (c *container) difference(o *container) *container {
+	if c.words == nil {
+		return c.filter(func(low uint16) bool {
+			return !o.contains(low)
+		})
+	}
+	result := &container{words: slices.Clone(c.words)}
+	if o.words == nil {
+		for _, low := range o.array {
+			result.words[low/64] &^= 1 << (low % 64)
+		}
+	} else {
+		for i, w := range o.words {
+			result.words[i] &^= w
+		}
+	}
+	return result.normalize()
+}
+
+func (c *container) intersects(o *container) bool {
+	switch {
+	case c.words == nil:
+		for _, low := range c.array {
+			if o.contains(low) {
+				return true
+			}
+		}
+		return false
+	case o.words == nil:
+		return o.intersects(c)
+	}
+	for i, w := range c.words {
+		if w&o.words[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// subset returns whether o is a subset of c.
+func (c *container) subset(o *container) bool {
+	switch {
+	case o.n > c.n:
+		return false
+	case o.words == nil:
+		for _, low := range o.array {
+			if !c.contains(low) {
+				return false
+			}
+		}
+		return true
+	}
+	// o is a bitmap container, so c being at least as large is one too
+	for i, w := range o.words {
+		if w&^c.words[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *container) equal(o *container) bool {
+	if c.n != o.n {
+		return false
+	}
+	if c.words != nil {
+		// containers of the same size use the same representation
+		return slices.Equal(c.words, o.words)
+	}
+	return slices.Equal(c.array, o.array)
+}
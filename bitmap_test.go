@@ -0,0 +1,232 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+// clustered returns n random integers drawn from a few dense blocks
+func clustered(rng *rand.Rand, n int) []uint64 {
+	bases := []uint64{0, 1 << 16, 5 << 16, 1 << 40, math.MaxUint64 - 1<<16}
+	result := make([]uint64, n)
+	for i := range result {
+		base := bases[rng.Intn(len(bases))]
+		result[i] = base + uint64(rng.Intn(1<<16))
+	}
+	return result
+}
+
+// sortedSlice returns the elements of s in ascending order
+func sortedSlice(s *Set[uint64]) []uint64 {
+	return s.SortedSlice(Cmp[uint64])
+}
+
+func TestBitmap_Insert(t *testing.T) {
+	b := NewBitmap()
+	must.True(t, b.Empty())
+	must.True(t, b.Insert(3))
+	must.False(t, b.Insert(3))
+	must.True(t, b.Insert(1<<16))
+	must.True(t, b.Insert(math.MaxUint64))
+	must.True(t, b.InsertSlice([]uint64{3, 1}))
+	must.False(t, b.InsertSlice([]uint64{1, 3}))
+	must.Eq(t, 4, b.Size())
+	must.Eq(t, []uint64{1, 3, 1 << 16, math.MaxUint64}, b.Slice())
+	must.Eq(t, "[1 3 65536 18446744073709551615]", b.String())
+}
+
+func TestBitmap_Remove(t *testing.T) {
+	b := BitmapOf(1, 2, 3, 1<<20)
+	must.True(t, b.Remove(1<<20))
+	must.False(t, b.Remove(1<<20))
+	must.True(t, b.RemoveSlice([]uint64{1, 9}))
+	must.False(t, b.RemoveSlice([]uint64{1, 9}))
+	must.Eq(t, []uint64{2, 3}, b.Slice())
+	must.True(t, b.RemoveSlice([]uint64{2, 3}))
+	must.True(t, b.Empty())
+	must.SliceEmpty(t, b.keys)
+}
+
+func TestBitmap_containers(t *testing.T) {
+	b := NewBitmap()
+	for i := uint64(0); i < arrayMax; i++ {
+		b.Insert(i * 2)
+	}
+	must.Nil(t, b.containers[0].words)
+
+	// one more element converts the container into a bitmap
+	b.Insert(1)
+	must.NotNil(t, b.containers[0].words)
+	must.Nil(t, b.containers[0].array)
+	must.Eq(t, arrayMax+1, b.Size())
+	must.True(t, b.Contains(1))
+	must.True(t, b.Contains(8190))
+	must.False(t, b.Contains(3))
+
+	// and removing it converts back into an array
+	b.Remove(1)
+	must.Nil(t, b.containers[0].words)
+	must.Eq(t, arrayMax, b.Size())
+	must.True(t, b.Contains(8190))
+}
+
+func TestBitmap_InsertRange(t *testing.T) {
+	t.Run("small", func(t *testing.T) {
+		b := BitmapOf(5)
+		must.True(t, b.InsertRange(3, 8))
+		must.False(t, b.InsertRange(4, 6))
+		must.False(t, b.InsertRange(6, 6))
+		must.Eq(t, []uint64{3, 4, 5, 6, 7}, b.Slice())
+	})
+
+	t.Run("spanning containers", func(t *testing.T) {
+		b := NewBitmap()
+		must.True(t, b.InsertRange(1<<16-10, 3<<16+10))
+		must.Eq(t, 2<<16+20, b.Size())
+		must.Len(t, 4, b.keys)
+		must.False(t, b.Contains(1<<16-11))
+		must.True(t, b.Contains(1<<16-10))
+		must.True(t, b.Contains(2<<16))
+		must.True(t, b.Contains(3<<16+9))
+		must.False(t, b.Contains(3<<16+10))
+		lo, _ := b.Min()
+		hi, _ := b.Max()
+		must.Eq(t, 1<<16-10, lo)
+		must.Eq(t, 3<<16+9, hi)
+	})
+
+	t.Run("end of space", func(t *testing.T) {
+		b := NewBitmap()
+		must.True(t, b.InsertRange(math.MaxUint64-5, math.MaxUint64))
+		must.Eq(t, 5, b.Size())
+		must.False(t, b.Contains(math.MaxUint64))
+	})
+}
+
+func TestBitmap_MinMax(t *testing.T) {
+	_, ok := NewBitmap().Min()
+	must.False(t, ok)
+	_, ok = NewBitmap().Max()
+	must.False(t, ok)
+
+	b := BitmapOf(9, 1<<30, 7)
+	lo, ok := b.Min()
+	must.True(t, ok)
+	must.Eq(t, 7, lo)
+	hi, ok := b.Max()
+	must.True(t, ok)
+	must.Eq(t, 1<<30, hi)
+}
+
+func TestBitmap_Algebra(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	for _, n := range []int{0, 10, 1000, 40000} {
+		x, y := clustered(rng, n), clustered(rng, n/2)
+		a, b := BitmapFrom(x), BitmapFrom(y)
+		sa, sb := From(x), From(y)
+
+		must.Eq(t, sortedSlice(sa.Union(sb)), a.Union(b).Slice())
+		must.Eq(t, sortedSlice(sa.Intersect(sb)), a.Intersect(b).Slice())
+		must.Eq(t, sortedSlice(sa.Difference(sb)), a.Difference(b).Slice())
+		must.Eq(t, sortedSlice(sb.Difference(sa)), b.Difference(a).Slice())
+		must.Eq(t, sa.Intersects(sb), a.Intersects(b))
+		must.Eq(t, sa.Subset(sb), a.Subset(b))
+		must.Eq(t, sa.Size(), a.Size())
+
+		c := a.Copy()
+		c.InsertSet(b)
+		must.True(t, c.Equal(a.Union(b)))
+		must.True(t, c.Subset(a))
+		must.True(t, c.Subset(b))
+		c.RemoveSet(b)
+		must.True(t, c.Equal(a.Difference(b)))
+	}
+}
+
+func TestBitmap_IntersectsSubset(t *testing.T) {
+	dense := NewBitmap()
+	dense.InsertRange(0, 10000)
+	evens := NewBitmap()
+	for i := uint64(0); i < 20000; i += 2 {
+		evens.Insert(i)
+	}
+	sparse := BitmapOf(1, 3, 9999)
+
+	t.Run("intersects", func(t *testing.T) {
+		must.True(t, dense.Intersects(evens))
+		must.True(t, dense.Intersects(sparse))
+		must.True(t, sparse.Intersects(dense))
+		must.False(t, sparse.Intersects(evens))
+		must.False(t, evens.Intersects(sparse))
+		must.False(t, sparse.Intersects(BitmapOf(2, 1<<40)))
+		must.False(t, NewBitmap().Intersects(dense))
+	})
+
+	t.Run("subset", func(t *testing.T) {
+		must.True(t, dense.Subset(sparse))
+		must.False(t, sparse.Subset(dense))
+		must.False(t, dense.Subset(evens))
+		must.False(t, evens.Subset(dense))
+		must.True(t, evens.Subset(BitmapOf(0, 2, 19998)))
+		must.False(t, evens.Subset(BitmapOf(0, 2, 1<<40)))
+		must.True(t, dense.Subset(dense.Copy()))
+		must.True(t, sparse.Subset(NewBitmap()))
+		must.False(t, NewBitmap().Subset(sparse))
+
+		partial := dense.Copy()
+		partial.RemoveSlice([]uint64{5000, 5001})
+		must.True(t, dense.Subset(partial))
+		must.False(t, partial.Subset(dense))
+	})
+
+	t.Run("allocs", func(t *testing.T) {
+		allocs := testing.AllocsPerRun(10, func() {
+			dense.Intersects(evens)
+			sparse.Intersects(evens)
+			dense.Subset(evens)
+			dense.Subset(sparse)
+		})
+		must.Eq(t, 0.0, allocs)
+	})
+}
+
+func TestBitmap_Equal(t *testing.T) {
+	a := BitmapOf(1, 2, 1<<40)
+	must.True(t, a.Equal(BitmapOf(1<<40, 2, 1)))
+	must.False(t, a.Equal(BitmapOf(1, 2)))
+	must.False(t, a.Equal(BitmapOf(1, 3, 1<<40)))
+	must.True(t, NewBitmap().Equal(NewBitmap()))
+
+	dense := NewBitmap()
+	dense.InsertRange(0, 10000)
+	other := dense.Copy()
+	must.True(t, dense.Equal(other))
+	other.Remove(5000)
+	must.False(t, dense.Equal(other))
+	must.True(t, dense.Contains(5000))
+}
+
+func TestBitmap_ForEach(t *testing.T) {
+	b := NewBitmap()
+	b.InsertRange(100, 5000)
+	b.Insert(1 << 33)
+
+	var visited []uint64
+	b.ForEach(func(item uint64) bool {
+		visited = append(visited, item)
+		return len(visited) < 3
+	})
+	must.Eq(t, []uint64{100, 101, 102}, visited)
+
+	slice := b.Slice()
+	must.True(t, slices.IsSorted(slice))
+	must.Len(t, 4901, slice)
+	must.Eq(t, 1<<33, slice[4900])
+}
@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// bloomParameters returns the number of cells m and hash functions k of a
+// Bloom filter expected to hold n elements with a false positive rate of p.
+//
+// https://en.wikipedia.org/wiki/Bloom_filter#Optimal_number_of_hash_functions
+func bloomParameters(n int, p float64) (m, k int) {
+	n = max(1, n)
+	p = math.Min(math.Max(p, 1e-12), 0.5)
+	m = int(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k = int(math.Round(float64(m) / float64(n) * math.Ln2))
+	return max(1, m), max(1, k)
+}
+
+// bloomIndexes calls f with each of the k cell indexes of a filter with m
+// cells for an element with hash h, using double hashing.
+//
+// https://www.eecs.harvard.edu/~michaelm/postscripts/rsa2008.pdf
+func bloomIndexes(h uint64, m, k int, f func(index int) bool) bool {
+	h1, h2 := h, mix(h)|1
+	for i := 0; i < k; i++ {
+		if !f(int((h1 + uint64(i)*h2) % uint64(m))) {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomFalsePositiveRate estimates the false positive rate of a filter with m
+// cells and k hash functions holding n elements.
+func bloomFalsePositiveRate(m, k, n int) float64 {
+	return math.Pow(1-math.Exp(-float64(k)*float64(n)/float64(m)), float64(k))
+}
+
+// The binary format of a Bloom filter, as produced by MarshalBinary, is
+//
+//	version byte    (bloomVersion)
+//	kind    byte    (the kind of filter, which determines the size of a cell)
+//	m       uvarint (number of cells)
+//	k       uvarint (number of hash functions)
+//	count   uvarint (number of elements)
+//	cells
+const bloomVersion = 1
+
+const (
+	bloomCounting byte = iota + 1
+	bloomCountMin
+)
+
+// marshalBloom will serialize the parameters and cells of a Bloom filter into
+// the binary format.
+func marshalBloom(kind byte, m, k, count int, cells []byte) []byte {
+	buf := []byte{bloomVersion, kind}
+	buf = binary.AppendUvarint(buf, uint64(m))
+	buf = binary.AppendUvarint(buf, uint64(k))
+	buf = binary.AppendUvarint(buf, uint64(count))
+	return append(buf, cells...)
+}
+
+// unmarshalBloom will deserialize the parameters and cells of a Bloom filter
+// of the given kind from the binary format.
+func unmarshalBloom(kind byte, data []byte) (m, k, count int, cells []byte, err error) {
+	if len(data) < 2 {
+		return 0, 0, 0, nil, errBinaryCorrupt
+	}
+	if version := data[0]; version != bloomVersion {
+		return 0, 0, 0, nil, fmt.Errorf("set: unsupported bloom filter format version %d", version)
+	}
+	if data[1] != kind {
+		return 0, 0, 0, nil, fmt.Errorf("set: unexpected bloom filter kind %d", data[1])
+	}
+	data = data[2:]
+	var values [3]int
+	for i := range values {
+		v, n := binary.Uvarint(data)
+		// the number of cells and hash functions are bounded, so that corrupt
+		// data cannot cause a huge allocation
+		if n <= 0 || v > math.MaxInt64 || (i < 2 && v > math.MaxInt32) {
+			return 0, 0, 0, nil, errBinaryCorrupt
+		}
+		values[i], data = int(v), data[n:]
+	}
+	return values[0], values[1], values[2], data, nil
+}
+
+// CountingBloom is a counting Bloom filter, a probabilistic set that reports
+// whether an element is definitely not present or probably present, using a
+// small fixed amount of memory regardless of the size of the elements.
+//
+// Unlike a plain Bloom filter, each cell of a CountingBloom is an 8 bit
+// counter rather than a single bit, so that elements may also be removed.
+// A counter that reaches 255 is never decremented again, so that removals
+// cannot introduce false negatives on overflow.
+//
+// Removing an element that was never inserted may cause false negatives for
+// other elements, so Remove only acts on elements that appear to be present.
+//
+// Not thread safe, and not safe for concurrent modification.
+type CountingBloom[T any] struct {
+	hash     func(T) uint64
+	counters []uint8
+	k        int
+	count    int
+}
+
+// NewCountingBloom creates a CountingBloom sized to hold n elements with a
+// false positive rate of p, hashing elements with hash.
+//
+// The hash function must be deterministic across processes for a serialized
+// filter to be useful elsewhere; the functions of package hasher are suitable.
+func NewCountingBloom[T any](n int, p float64, hash func(T) uint64) *CountingBloom[T] {
+	m, k := bloomParameters(n, p)
+	return &CountingBloom[T]{
+		hash:     hash,
+		counters: make([]uint8, m),
+		k:        k,
+	}
+}
+
+// Insert item into b.
+//
+// Return true if item was definitely not already in b, false if it probably was.
+func (b *CountingBloom[T]) Insert(item T) bool {
+	absent := false
+	bloomIndexes(b.hash(item), len(b.counters), b.k, func(i int) bool {
+		if b.counters[i] == 0 {
+			absent = true
+		}
+		if b.counters[i] < math.MaxUint8 {
+			b.counters[i]++
+		}
+		return true
+	})
+	b.count++
+	return absent
+}
+
+// Remove item from b, if it is probably present.
+//
+// Return true if b was modified (item was probably present), false otherwise.
+func (b *CountingBloom[T]) Remove(item T) bool {
+	h := b.hash(item)
+	if !b.contains(h) {
+		return false
+	}
+	bloomIndexes(h, len(b.counters), b.k, func(i int) bool {
+		if b.counters[i] < math.MaxUint8 {
+			b.counters[i]--
+		}
+		return true
+	})
+	b.count--
+	return true
+}
+
+// Contains returns false if item is definitely not present in b, and true if
+// item is probably present.
+func (b *CountingBloom[T]) Contains(item T) bool {
+	return b.contains(b.hash(item))
+}
+
+func (b *CountingBloom[T]) contains(h uint64) bool {
+	return bloomIndexes(h, len(b.counters), b.k, func(i int) bool {
+		return b.counters[i] > 0
+	})
+}
+
+// Size returns the number of elements inserted into b, less the number removed.
+func (b *CountingBloom[T]) Size() int {
+	return b.count
+}
+
+// Empty returns true if b contains no elements, false otherwise.
+func (b *CountingBloom[T]) Empty() bool {
+	return b.count == 0
+}
+
+// FalsePositiveRate returns an estimate of the probability that Contains
+// returns true for an element that is not present, given the current size of b.
+func (b *CountingBloom[T]) FalsePositiveRate() float64 {
+	return bloomFalsePositiveRate(len(b.counters), b.k, b.count)
+}
+
+// Clear removes every element from b.
+func (b *CountingBloom[T]) Clear() {
+	clear(b.counters)
+	b.count = 0
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+//
+// The hash function of b is not encoded, and must be provided again when
+// creating the filter that is unmarshalled into.
+func (b *CountingBloom[T]) MarshalBinary() ([]byte, error) {
+	return marshalBloom(bloomCounting, len(b.counters), b.k, b.count, b.counters), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. The
+// size and contents of b are replaced, while its hash function is kept.
+func (b *CountingBloom[T]) UnmarshalBinary(data []byte) error {
+	m, k, count, cells, err := unmarshalBloom(bloomCounting, data)
+	if err != nil {
+		return err
+	}
+	if m == 0 || k == 0 || len(cells) != m {
+		return errBinaryCorrupt
+	}
+	b.counters = append(b.counters[:0:0], cells...)
+	b.k = k
+	b.count = count
+	return nil
+}
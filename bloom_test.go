@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"hash/fnv"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func fnvString(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+func TestBloom_parameters(t *testing.T) {
+	m, k := bloomParameters(1000, 0.01)
+	must.Eq(t, 9586, m)
+	must.Eq(t, 7, k)
+
+	m, k = bloomParameters(0, 0)
+	must.Positive(t, m)
+	must.Positive(t, k)
+
+	must.Eq(t, 0, bloomFalsePositiveRate(m, k, 0))
+	must.Between(t, 0.009, bloomFalsePositiveRate(9586, 7, 1000), 0.011)
+}
+
+func TestCountingBloom_Insert(t *testing.T) {
+	b := NewCountingBloom[string](100, 0.01, fnvString)
+	must.True(t, b.Empty())
+	must.False(t, b.Contains("a"))
+
+	must.True(t, b.Insert("a"))
+	must.False(t, b.Insert("a"))
+	must.True(t, b.Contains("a"))
+	must.Eq(t, 2, b.Size())
+
+	for i := 0; i < 100; i++ {
+		b.Insert(strconv.Itoa(i))
+	}
+	for i := 0; i < 100; i++ {
+		must.True(t, b.Contains(strconv.Itoa(i)))
+	}
+}
+
+func TestCountingBloom_Remove(t *testing.T) {
+	b := NewCountingBloom[string](1000, 0.01, fnvString)
+	for i := 0; i < 1000; i++ {
+		b.Insert(strconv.Itoa(i))
+	}
+	for i := 0; i < 1000; i += 2 {
+		must.True(t, b.Remove(strconv.Itoa(i)))
+	}
+	must.Eq(t, 500, b.Size())
+
+	// no false negatives for the elements that remain
+	for i := 1; i < 1000; i += 2 {
+		must.True(t, b.Contains(strconv.Itoa(i)))
+	}
+
+	// elements that were removed are mostly absent
+	present := 0
+	for i := 0; i < 1000; i += 2 {
+		if b.Contains(strconv.Itoa(i)) {
+			present++
+		}
+	}
+	must.Less(t, 25, present)
+
+	must.False(t, b.Remove("never inserted"))
+	b.Clear()
+	must.True(t, b.Empty())
+	must.False(t, b.Contains("1"))
+}
+
+func TestCountingBloom_duplicates(t *testing.T) {
+	b := NewCountingBloom[string](10, 0.01, fnvString)
+	b.Insert("x")
+	b.Insert("x")
+	must.True(t, b.Remove("x"))
+	must.True(t, b.Contains("x"))
+	must.True(t, b.Remove("x"))
+	must.False(t, b.Contains("x"))
+}
+
+func TestCountingBloom_saturated(t *testing.T) {
+	b := NewCountingBloom[string](10, 0.01, fnvString)
+	for i := 0; i < 300; i++ {
+		b.Insert("x")
+	}
+	for i := 0; i < 300; i++ {
+		b.Remove("x")
+	}
+	// saturated counters are never decremented, so no false negatives
+	must.True(t, b.Contains("x"))
+}
+
+func TestCountingBloom_FalsePositiveRate(t *testing.T) {
+	b := NewCountingBloom[string](10000, 0.01, fnvString)
+	for i := 0; i < 10000; i++ {
+		b.Insert(strconv.Itoa(i))
+	}
+	must.Between(t, 0.009, b.FalsePositiveRate(), 0.011)
+
+	positives := 0
+	for i := 10000; i < 20000; i++ {
+		if b.Contains(strconv.Itoa(i)) {
+			positives++
+		}
+	}
+	must.Less(t, 0.02, float64(positives)/10000)
+}
+
+func TestCountingBloom_Binary(t *testing.T) {
+	a := NewCountingBloom[string](100, 0.01, fnvString)
+	for i := 0; i < 50; i++ {
+		a.Insert(strconv.Itoa(i))
+	}
+	data, err := a.MarshalBinary()
+	must.NoError(t, err)
+
+	b := NewCountingBloom[string](1, 0.5, fnvString)
+	must.NoError(t, b.UnmarshalBinary(data))
+	must.Eq(t, a.counters, b.counters)
+	must.Eq(t, 50, b.Size())
+	for i := 0; i < 50; i++ {
+		must.True(t, b.Contains(strconv.Itoa(i)))
+	}
+
+	t.Run("independent", func(t *testing.T) {
+		b.Remove("0")
+		must.True(t, a.Contains("0"))
+	})
+
+	t.Run("corrupt", func(t *testing.T) {
+		c := NewCountingBloom[string](1, 0.5, fnvString)
+		must.Error(t, c.UnmarshalBinary(nil))
+		must.Error(t, c.UnmarshalBinary([]byte{2, bloomCounting}))
+		must.Error(t, c.UnmarshalBinary([]byte{bloomVersion, 9}))
+		must.Error(t, c.UnmarshalBinary(data[:len(data)-1]))
+		must.Error(t, c.UnmarshalBinary(append([]byte{bloomVersion, bloomCounting}, 0xff)))
+	})
+}
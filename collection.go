@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+// ReadOnly is the set of read operations common to every set implementation
+// in this package, so that code may accept any set of T it must not modify.
+//
+// Every Collection is a ReadOnly, and Freeze creates a ReadOnly that cannot be
+// converted back into a Collection.
+type ReadOnly[T any] interface {
+	// Contains returns whether item is present in the collection.
+	Contains(item T) bool
+
+	// Size returns the cardinality of the collection.
+	Size() int
+
+	// Empty returns whether the collection contains no elements.
+	Empty() bool
+
+	// Slice returns the elements of the collection as a slice.
+	Slice() []T
+
+	// ForEach calls visit for each element of the collection, stopping early
+	// if visit returns false. For the types of this package, visiting an
+	// element does not allocate unless documented otherwise.
+	ForEach(visit func(item T) bool)
+}
+
+// Collection is the set of operations common to every set implementation in
+// this package, so that code may accept any set of T without depending on its
+// underlying data structure.
+//
+// Set, HashSet, TreeSet, OrderedSet, SyncSet, and SyncHashSet all implement
+// Collection.
+type Collection[T any] interface {
+	ReadOnly[T]
+
+	// Insert item into the collection, returning whether it was modified.
+	Insert(item T) bool
+
+	// Remove item from the collection, returning whether it was modified.
+	Remove(item T) bool
+}
+
+var (
+	_ Collection[int]    = (*Set[int])(nil)
+	_ Collection[int]    = (*HashSet[int, int])(nil)
+	_ Collection[int]    = (*TreeSet[int, Compare[int]])(nil)
+	_ Collection[int]    = (*OrderedSet[int])(nil)
+	_ Collection[int]    = (*SyncSet[int])(nil)
+	_ Collection[int]    = (*SyncHashSet[int, int])(nil)
+	_ Collection[int]    = (*Instrumented[int])(nil)
+	_ Collection[int]    = (*CopyOnWrite[int])(nil)
+	_ Collection[int]    = (*VersionedSet[int])(nil)
+	_ Collection[int]    = (*KDTree[int])(nil)
+	_ Collection[int]    = (*AdaptiveSet[int])(nil)
+	_ Collection[uint64] = (*Bitmap)(nil)
+	_ Collection[uint64] = (*SparseBitSet)(nil)
+	_ ReadOnly[int]      = (*Immutable[int])(nil)
+	_ ReadOnly[int]      = (*Multiset[int])(nil)
+	_ ReadOnly[int]      = (*TreeBag[int, Compare[int]])(nil)
+	_ ReadOnly[int]      = (*DisjointSet[int])(nil)
+	_ ReadOnly[int]      = (*PersistentSet[int])(nil)
+	_ ReadOnly[int]      = (*TopK[int])(nil)
+	_ ReadOnly[int]      = (*WeightedSet[int])(nil)
+	_ ReadOnly[int]      = (*View[int])(nil)
+	_ ReadOnly[int]      = (*Snapshot[int])(nil)
+	_ ReadOnly[int]      = (*PerfectSet[int])(nil)
+	_ ReadOnly[string]   = (*MappedSet)(nil)
+)
+
+// InsertSliceInto will insert each item in items into c.
+//
+// Return true if c was modified (at least one item was not already in c), false otherwise.
+func InsertSliceInto[T any](c Collection[T], items []T) bool {
+	modified := false
+	for _, item := range items {
+		if c.Insert(item) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// InsertInto will insert each element of src into dst.
+//
+// Return true if dst was modified (at least one element of src was not already
+// in dst), false otherwise.
+func InsertInto[T any](dst Collection[T], src ReadOnly[T]) bool {
+	modified := false
+	src.ForEach(func(item T) bool {
+		if dst.Insert(item) {
+			modified = true
+		}
+		return true
+	})
+	return modified
+}
+
+// ContainsAll returns whether c contains at least every item in items.
+func ContainsAll[T any](c ReadOnly[T], items ...T) bool {
+	for _, item := range items {
+		if !c.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Drain removes every element from c, returning the removed elements as a
+// slice in the order produced by c.Slice.
+func Drain[T any](c Collection[T]) []T {
+	items := c.Slice()
+	for _, item := range items {
+		c.Remove(item)
+	}
+	return items
+}
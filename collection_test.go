@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func identity(i int) int { return i }
+
+func collections() map[string]func() Collection[int] {
+	return map[string]func() Collection[int]{
+		"set":         func() Collection[int] { return New[int](0) },
+		"hashset":     func() Collection[int] { return NewHashSetFunc(identity, 0) },
+		"treeset":     func() Collection[int] { return NewTreeSet[int, Compare[int]](Cmp[int]) },
+		"orderedset":  func() Collection[int] { return NewOrderedSet[int](0) },
+		"syncset":     func() Collection[int] { return NewSyncSet[int](0) },
+		"synchashset": func() Collection[int] { return NewSyncHashSetFunc(identity, 0) },
+	}
+}
+
+func TestCollection(t *testing.T) {
+	for name, create := range collections() {
+		t.Run(name, func(t *testing.T) {
+			c := create()
+			must.True(t, c.Empty())
+			must.Eq(t, 0, c.Size())
+
+			must.True(t, c.Insert(3))
+			must.True(t, c.Insert(1))
+			must.True(t, c.Insert(2))
+			must.False(t, c.Insert(2))
+			must.False(t, c.Empty())
+			must.Eq(t, 3, c.Size())
+
+			must.True(t, c.Contains(1))
+			must.False(t, c.Contains(4))
+
+			must.True(t, c.Remove(1))
+			must.False(t, c.Remove(1))
+			must.False(t, c.Contains(1))
+
+			result := c.Slice()
+			slices.Sort(result)
+			must.Eq(t, []int{2, 3}, result)
+
+			count := 0
+			c.ForEach(func(int) bool {
+				count++
+				return false
+			})
+			must.Eq(t, 1, count)
+		})
+	}
+}
+
+func TestInsertSliceInto(t *testing.T) {
+	for name, create := range collections() {
+		t.Run(name, func(t *testing.T) {
+			c := create()
+			must.True(t, InsertSliceInto(c, []int{1, 2, 2, 3}))
+			must.False(t, InsertSliceInto(c, []int{3, 1}))
+			must.False(t, InsertSliceInto(c, nil))
+			must.Eq(t, 3, c.Size())
+		})
+	}
+}
+
+func TestInsertInto(t *testing.T) {
+	for name, create := range collections() {
+		t.Run(name, func(t *testing.T) {
+			c := create()
+			must.True(t, InsertInto(c, From(ints(3))))
+			must.False(t, InsertInto(c, TreeSetFrom[int, Compare[int]]([]int{2, 3}, Cmp[int])))
+			must.True(t, InsertInto(c, OrderedSetOf(3, 4)))
+			must.Eq(t, 4, c.Size())
+		})
+	}
+}
+
+func TestContainsAll(t *testing.T) {
+	for name, create := range collections() {
+		t.Run(name, func(t *testing.T) {
+			c := create()
+			InsertSliceInto(c, ints(5))
+			must.True(t, ContainsAll(c))
+			must.True(t, ContainsAll(c, 1, 5, 5))
+			must.False(t, ContainsAll(c, 1, 6))
+		})
+	}
+}
+
+func TestDrain(t *testing.T) {
+	for name, create := range collections() {
+		t.Run(name, func(t *testing.T) {
+			c := create()
+			must.SliceEmpty(t, Drain(c))
+
+			InsertSliceInto(c, ints(5))
+			result := Drain(c)
+			slices.Sort(result)
+			must.Eq(t, ints(5), result)
+			must.True(t, c.Empty())
+		})
+	}
+}
+
+// readOnlys returns a set of each type in the package containing items.
+func readOnlys(items []int) map[string]ReadOnly[int] {
+	hs := NewHashSetFunc(identity, len(items))
+	hs.InsertSlice(items)
+	shs := NewSyncHashSetFunc(identity, len(items))
+	shs.InsertSlice(items)
+	topk := NewTopK(len(items), Cmp[int])
+	topk.InsertSlice(items)
+	weighted := NewWeightedSet[int](len(items))
+	for _, item := range items {
+		weighted.Insert(item, 1)
+	}
+	perfect, _ := BuildPerfectSet[int](From(items), func(i int) uint64 { return uint64(i) })
+	half := From(items[:len(items)/2])
+
+	return map[string]ReadOnly[int]{
+		"set":          From(items),
+		"hashset":      hs,
+		"treeset":      TreeSetFrom[int, Compare[int]](items, Cmp[int]),
+		"orderedset":   OrderedSetFrom(items),
+		"syncset":      SyncSetFrom(items),
+		"synchashset":  shs,
+		"multiset":     MultisetFrom(items),
+		"treebag":      TreeBagFrom[int, Compare[int]](items, Cmp[int]),
+		"persistent":   PersistentSetFrom(items),
+		"immutable":    Freeze[int](From(items)),
+		"copyonwrite":  NewCopyOnWrite(From(items)),
+		"instrumented": Instrument[int](From(items), Hooks[int]{}),
+		"versioned":    VersionedSetFrom(items),
+		"snapshot":     VersionedSetFrom(items).Snapshot(),
+		"disjointset":  DisjointSetFrom(items),
+		"adaptive":     AdaptiveSetFrom(items),
+		"kdtree":       KDTreeFrom(items, 1, func(i, _ int) float64 { return float64(i) }),
+		"topk":         topk,
+		"weighted":     weighted,
+		"perfect":      perfect,
+		"union":        UnionView[int](half, From(items)),
+		"intersect":    IntersectView[int](From(items), half),
+		"difference":   DifferenceView[int](From(items), half),
+	}
+}
+
+func TestForEach_allocs(t *testing.T) {
+	// visiting each element must not allocate, so iterating a large set
+	// allocates no more than iterating a small one
+	small, large := readOnlys(ints(10)), readOnlys(ints(1000))
+	for name := range small {
+		t.Run(name, func(t *testing.T) {
+			count := 0
+			visit := func(int) bool {
+				count++
+				return true
+			}
+			exp := testing.AllocsPerRun(10, func() { small[name].ForEach(visit) })
+			allocs := testing.AllocsPerRun(10, func() { large[name].ForEach(visit) })
+			must.Eq(t, exp, allocs)
+		})
+	}
+
+	uints := func(n int) []uint64 {
+		result := make([]uint64, n)
+		for i := range result {
+			result[i] = uint64(i) * 3
+		}
+		return result
+	}
+
+	t.Run("bitmap", func(t *testing.T) {
+		s := BitmapFrom(uints(1000))
+		allocs := testing.AllocsPerRun(10, func() { s.ForEach(func(uint64) bool { return true }) })
+		must.Eq(t, 0.0, allocs)
+	})
+
+	t.Run("sparsebitset", func(t *testing.T) {
+		// the word indexes are sorted in a slice allocated once per call
+		small, large := SparseBitSetFrom(uints(1000)), SparseBitSetFrom(uints(100_000))
+		exp := testing.AllocsPerRun(10, func() { small.ForEach(func(uint64) bool { return true }) })
+		allocs := testing.AllocsPerRun(10, func() { large.ForEach(func(uint64) bool { return true }) })
+		must.Eq(t, exp, allocs)
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"iter"
+)
+
+// maxPowerSet is the largest set for which PowerSet will enumerate subsets.
+const maxPowerSet = 32
+
+// PowerSet returns an iterator over every subset of s, including the empty set
+// and s itself. Subsets are produced lazily, and each yielded Set is newly
+// allocated and owned by the caller.
+//
+// A set of n elements has 2^n subsets. To guard against accidentally unbounded
+// enumeration, PowerSet panics if s contains more than 32 elements.
+//
+// The elements of s are captured when iteration begins; modifying s during
+// iteration does not affect the subsets produced.
+func PowerSet[T comparable](s *Set[T]) iter.Seq[*Set[T]] {
+	if n := s.Size(); n > maxPowerSet {
+		panic(fmt.Sprintf("powerset: set of size %d exceeds limit of %d", n, maxPowerSet))
+	}
+	return func(yield func(*Set[T]) bool) {
+		elements := s.Slice()
+		n := uint64(len(elements))
+		for mask := uint64(0); mask < 1<<n; mask++ {
+			subset := New[T](0)
+			for i := uint64(0); i < n; i++ {
+				if mask&(1<<i) != 0 {
+					subset.Insert(elements[i])
+				}
+			}
+			if !yield(subset) {
+				return
+			}
+		}
+	}
+}
+
+// Combinations returns an iterator over every subset of s containing exactly
+// k elements. Each subset is yielded as a newly allocated slice owned by the
+// caller, and no subset is produced more than once.
+//
+// Within a single iteration, elements appear in each slice in a consistent
+// relative order. If k is negative or larger than the size of s, no subsets
+// are produced.
+//
+// The elements of s are captured when iteration begins; modifying s during
+// iteration does not affect the subsets produced.
+func Combinations[T comparable](s *Set[T], k int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		elements := s.Slice()
+		n := len(elements)
+		if k < 0 || k > n {
+			return
+		}
+
+		// indices holds the positions of the current combination, always
+		// in strictly ascending order
+		indices := make([]int, k)
+		for i := range indices {
+			indices[i] = i
+		}
+
+		for {
+			combination := make([]T, k)
+			for i, index := range indices {
+				combination[i] = elements[index]
+			}
+			if !yield(combination) {
+				return
+			}
+
+			// find the right-most index that can still be advanced
+			i := k - 1
+			for i >= 0 && indices[i] == n-k+i {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			for j := i + 1; j < k; j++ {
+				indices[j] = indices[j-1] + 1
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestPowerSet(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		var subsets []*Set[int]
+		for subset := range PowerSet(New[int](0)) {
+			subsets = append(subsets, subset)
+		}
+		must.Len(t, 1, subsets)
+		must.Empty(t, subsets[0])
+	})
+
+	t.Run("three", func(t *testing.T) {
+		s := Of("a", "b", "c")
+		seen := New[string](8)
+		for subset := range PowerSet(s) {
+			must.True(t, s.Subset(subset))
+			must.True(t, seen.Insert(subset.StringSorted(Cmp[string])))
+		}
+		must.Size(t, 8, seen)
+		must.True(t, seen.ContainsAll(
+			"[]", "[a]", "[b]", "[c]", "[a b]", "[a c]", "[b c]", "[a b c]",
+		))
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		count := 0
+		for range PowerSet(From(ints(20))) {
+			count++
+			if count == 5 {
+				break
+			}
+		}
+		must.Eq(t, 5, count)
+	})
+
+	t.Run("too big", func(t *testing.T) {
+		defer func() {
+			must.NotNil(t, recover())
+		}()
+		PowerSet(From(ints(33)))
+	})
+}
+
+func TestCombinations(t *testing.T) {
+	collect := func(s *Set[int], k int) *Set[string] {
+		result := New[string](0)
+		for combination := range Combinations(s, k) {
+			must.Len(t, k, combination)
+			must.True(t, s.ContainsAll(combination...))
+			must.True(t, result.Insert(From(combination).StringSorted(Cmp[int])))
+		}
+		return result
+	}
+
+	t.Run("k zero", func(t *testing.T) {
+		result := collect(Of(1, 2, 3), 0)
+		must.True(t, result.EqualSlice([]string{"[]"}))
+	})
+
+	t.Run("k negative", func(t *testing.T) {
+		must.Empty(t, collect(Of(1, 2, 3), -1))
+	})
+
+	t.Run("k too large", func(t *testing.T) {
+		must.Empty(t, collect(Of(1, 2, 3), 4))
+	})
+
+	t.Run("k one", func(t *testing.T) {
+		result := collect(Of(1, 2, 3), 1)
+		must.True(t, result.EqualSlice([]string{"[1]", "[2]", "[3]"}))
+	})
+
+	t.Run("k two", func(t *testing.T) {
+		result := collect(Of(1, 2, 3, 4), 2)
+		must.True(t, result.EqualSlice([]string{
+			"[1 2]", "[1 3]", "[1 4]", "[2 3]", "[2 4]", "[3 4]",
+		}))
+	})
+
+	t.Run("k all", func(t *testing.T) {
+		result := collect(Of(1, 2, 3, 4), 4)
+		must.True(t, result.EqualSlice([]string{"[1 2 3 4]"}))
+	})
+
+	t.Run("count", func(t *testing.T) {
+		// 10 choose 4
+		must.Size(t, 210, collect(From(ints(10)), 4))
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		count := 0
+		for range Combinations(From(ints(10)), 3) {
+			count++
+			if count == 7 {
+				break
+			}
+		}
+		must.Eq(t, 7, count)
+	})
+}
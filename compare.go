@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"net/netip"
+	"time"
+)
+
+// CmpPtr is a Compare function for pointers to the specified builtin type B,
+// comparing the values pointed to using Cmp.
+//
+// A nil pointer is considered less than any non-nil pointer, and two nil
+// pointers are considered equal.
+func CmpPtr[B BuiltIn](x, y *B) int {
+	return ComparePtr(Cmp[B])(x, y)
+}
+
+// ComparePtr creates a Compare function for pointers to T, comparing the
+// values pointed to using compare.
+//
+// A nil pointer is considered less than any non-nil pointer, and two nil
+// pointers are considered equal.
+func ComparePtr[T any](compare Compare[T]) Compare[*T] {
+	return func(x, y *T) int {
+		switch {
+		case x == nil && y == nil:
+			return 0
+		case x == nil:
+			return -1
+		case y == nil:
+			return 1
+		default:
+			return compare(*x, *y)
+		}
+	}
+}
+
+// CmpTime is a Compare function for time.Time, ordering instants in time
+// regardless of location or monotonic clock reading.
+func CmpTime(x, y time.Time) int {
+	return x.Compare(y)
+}
+
+// CmpDuration is a Compare function for time.Duration.
+func CmpDuration(x, y time.Duration) int {
+	return Cmp(x, y)
+}
+
+// CmpAddr is a Compare function for netip.Addr.
+//
+// IPv4-mapped IPv6 addresses (e.g. ::ffff:10.0.0.1) are compared as their IPv4
+// equivalent, so the two forms of an address are considered equal. Otherwise
+// addresses are ordered as by netip.Addr.Compare, with IPv4 addresses before
+// IPv6 addresses.
+func CmpAddr(x, y netip.Addr) int {
+	return x.Unmap().Compare(y.Unmap())
+}
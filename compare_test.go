@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestCmpPtr(t *testing.T) {
+	t.Run("values", func(t *testing.T) {
+		must.Negative(t, CmpPtr(ptr(1), ptr(2)))
+		must.Positive(t, CmpPtr(ptr("b"), ptr("a")))
+		must.Zero(t, CmpPtr(ptr(3), ptr(3)))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		must.Zero(t, CmpPtr[int](nil, nil))
+		must.Negative(t, CmpPtr(nil, ptr(-100)))
+		must.Positive(t, CmpPtr(ptr(-100), nil))
+	})
+
+	t.Run("treeset", func(t *testing.T) {
+		ts := TreeSetFrom[*string, Compare[*string]]([]*string{
+			ptr("b"), nil, ptr("a"), ptr("b"), nil,
+		}, CmpPtr[string])
+		must.Size(t, 3, ts)
+		must.Nil(t, ts.Min())
+		must.Eq(t, "b", *ts.Max())
+		invariants(t, ts, CmpPtr[string])
+	})
+}
+
+func TestComparePtr(t *testing.T) {
+	compare := ComparePtr(func(a, b token) int {
+		return Cmp(a.id, b.id)
+	})
+	must.Negative(t, compare(tokenA, tokenB))
+	must.Zero(t, compare(tokenC, &token{id: "C"}))
+	must.Negative(t, compare(nil, tokenA))
+	must.Positive(t, compare(tokenA, nil))
+	must.Zero(t, compare(nil, nil))
+}
+
+func TestCmpTime(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	est := time.FixedZone("EST", -5*60*60)
+	must.Negative(t, CmpTime(now, now.Add(time.Second)))
+	must.Positive(t, CmpTime(now, now.Add(-time.Second)))
+	must.Zero(t, CmpTime(now, now.In(est)))
+
+	ts := TreeSetFrom[time.Time, Compare[time.Time]]([]time.Time{
+		now.Add(time.Hour), now, now.In(est),
+	}, CmpTime)
+	must.Size(t, 2, ts)
+	must.True(t, now.Equal(ts.Min()))
+}
+
+func TestCmpDuration(t *testing.T) {
+	must.Negative(t, CmpDuration(time.Millisecond, time.Second))
+	must.Positive(t, CmpDuration(time.Minute, time.Second))
+	must.Zero(t, CmpDuration(60*time.Second, time.Minute))
+}
+
+func TestCmpAddr(t *testing.T) {
+	v4 := netip.MustParseAddr("10.0.0.1")
+	mapped := netip.MustParseAddr("::ffff:10.0.0.1")
+	v6 := netip.MustParseAddr("::1")
+
+	must.Zero(t, CmpAddr(v4, mapped))
+	must.Negative(t, CmpAddr(v4, netip.MustParseAddr("10.0.0.2")))
+	must.Negative(t, CmpAddr(mapped, v6))
+	must.Positive(t, CmpAddr(v6, v4))
+	must.Negative(t, CmpAddr(netip.Addr{}, v4))
+
+	ts := TreeSetFrom[netip.Addr, Compare[netip.Addr]]([]netip.Addr{
+		v6, mapped, v4,
+	}, CmpAddr)
+	must.Size(t, 2, ts)
+	must.Eq(t, v6, ts.Max())
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+// ToSet creates a new Set containing each element of c.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect. For these types, use ToHashSet instead.
+func ToSet[T comparable](c ReadOnly[T]) *Set[T] {
+	result := New[T](c.Size())
+	InsertInto(result, c)
+	return result
+}
+
+// ToHashSet creates a new HashSet containing each element of c, using hash to
+// compute the hash code of each element.
+//
+// Elements for which hash returns the same value are considered equal, so only
+// one of them is retained.
+func ToHashSet[T any, H Hash](c ReadOnly[T], hash func(T) H) *HashSet[T, H] {
+	result := NewHashSetFunc(hash, c.Size())
+	InsertInto(result, c)
+	return result
+}
+
+// ToTreeSet creates a new TreeSet containing each element of c, ordered
+// according to compare.
+//
+// Elements for which compare returns 0 are considered equal, so only one of
+// them is retained.
+func ToTreeSet[T any](c ReadOnly[T], compare Compare[T]) *TreeSet[T, Compare[T]] {
+	result := NewTreeSet[T](compare)
+	InsertInto(result, c)
+	return result
+}
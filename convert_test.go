@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestToSet(t *testing.T) {
+	t.Run("from hashset", func(t *testing.T) {
+		hs := HashSetOf[*company, string](c1, c2, c3)
+		s := ToSet(hs)
+		must.Size(t, 3, s)
+		must.True(t, s.ContainsAll(c1, c2, c3))
+	})
+
+	t.Run("from treeset", func(t *testing.T) {
+		ts := TreeSetFrom[int, Compare[int]](ints(5), Cmp[int])
+		must.True(t, ToSet(ts).EqualSlice(ints(5)))
+	})
+
+	t.Run("from orderedset", func(t *testing.T) {
+		os := OrderedSetOf("c", "a", "b")
+		must.True(t, ToSet(os).EqualSlice([]string{"a", "b", "c"}))
+	})
+
+	t.Run("from syncset", func(t *testing.T) {
+		ss := SyncSetOf(1, 2, 3)
+		must.True(t, ToSet(ss).EqualSlice([]int{1, 2, 3}))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		must.Empty(t, ToSet(New[int](0)))
+	})
+}
+
+func TestToHashSet(t *testing.T) {
+	t.Run("from set", func(t *testing.T) {
+		s := Of("a", "b", "c")
+		hs := ToHashSet(s, func(s string) string { return s })
+		must.Size(t, 3, hs)
+		must.True(t, hs.ContainsAll("a", "b", "c"))
+	})
+
+	t.Run("merge by hash", func(t *testing.T) {
+		s := Of("go", "Go", "GO", "rust")
+		hs := ToHashSet(s, strings.ToLower)
+		must.Size(t, 2, hs)
+		must.True(t, hs.Contains("gO"))
+		must.True(t, hs.Contains("Rust"))
+	})
+
+	t.Run("from treeset", func(t *testing.T) {
+		ts := TreeSetFrom[int, Compare[int]](ints(10), Cmp[int])
+		hs := ToHashSet(ts, func(i int) int { return i })
+		must.Eq(t, ints(10), hs.SortedSlice(Cmp[int]))
+	})
+}
+
+func TestToTreeSet(t *testing.T) {
+	t.Run("from set", func(t *testing.T) {
+		s := From(shuffle(ints(20)))
+		ts := ToTreeSet(s, Cmp[int])
+		must.Eq(t, ints(20), ts.Slice())
+		invariants(t, ts, Cmp[int])
+	})
+
+	t.Run("from hashset", func(t *testing.T) {
+		hs := HashSetOf[*company, string](c3, c1, c2)
+		ts := ToTreeSet(hs, func(a, b *company) int {
+			return strings.Compare(a.Hash(), b.Hash())
+		})
+		must.Eq(t, []*company{c1, c2, c3}, ts.Slice())
+	})
+
+	t.Run("merge by compare", func(t *testing.T) {
+		s := Of(1, 2, 3, 11, 12)
+		ts := ToTreeSet(s, func(a, b int) int { return Cmp(a%10, b%10) })
+		must.Size(t, 3, ts)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		s := From(ints(50))
+		must.True(t, s.Equal(ToSet(ToTreeSet(s, Cmp[int]))))
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+// CopyOnWrite is a Set that shares its underlying storage with other
+// CopyOnWrite sets created by Share, until it is modified. The first
+// modification made through a CopyOnWrite set whose storage is shared first
+// clones the storage, so that other sharers are unaffected.
+//
+// This makes handing the same set to many readers cheap, while still
+// allowing any of them to modify their own copy.
+//
+// A single CopyOnWrite set is not safe for concurrent use, but CopyOnWrite
+// sets sharing the same storage may each be used by a different goroutine.
+type CopyOnWrite[T comparable] struct {
+	set   *Set[T]
+	owned bool
+}
+
+// NewCopyOnWrite creates a CopyOnWrite set sharing the storage of s. The
+// first modification clones s, so s itself is never modified through the
+// returned set; s must not be modified while it is shared.
+func NewCopyOnWrite[T comparable](s *Set[T]) *CopyOnWrite[T] {
+	return &CopyOnWrite[T]{set: s}
+}
+
+// Share returns a new CopyOnWrite set sharing the storage of s. Both s and
+// the result will clone the storage before their next modification.
+func (s *CopyOnWrite[T]) Share() *CopyOnWrite[T] {
+	s.owned = false
+	return &CopyOnWrite[T]{set: s.set}
+}
+
+// Shared returns whether the storage of s may be shared with another set, in
+// which case the next modification of s will clone it.
+func (s *CopyOnWrite[T]) Shared() bool {
+	return !s.owned
+}
+
+// own clones the underlying storage of s if it may be shared.
+func (s *CopyOnWrite[T]) own() {
+	if !s.owned {
+		s.set = s.set.Copy()
+		s.owned = true
+	}
+}
+
+// Insert item into s.
+//
+// Return true if s was modified (item was not already in s), false otherwise.
+// The storage of s is not cloned if item is already present.
+func (s *CopyOnWrite[T]) Insert(item T) bool {
+	if s.set.Contains(item) {
+		return false
+	}
+	s.own()
+	return s.set.Insert(item)
+}
+
+// InsertSlice will insert each item in items into s.
+//
+// Return true if s was modified (at least one item was not already in s), false otherwise.
+func (s *CopyOnWrite[T]) InsertSlice(items []T) bool {
+	if s.set.ContainsAll(items...) {
+		return false
+	}
+	s.own()
+	return s.set.InsertSlice(items)
+}
+
+// Remove will remove item from s.
+//
+// Return true if s was modified (item was present), false otherwise. The
+// storage of s is not cloned if item is not present.
+func (s *CopyOnWrite[T]) Remove(item T) bool {
+	if !s.set.Contains(item) {
+		return false
+	}
+	s.own()
+	return s.set.Remove(item)
+}
+
+// RemoveSlice will remove each item in items from s.
+//
+// Return true if s was modified (any item was present), false otherwise.
+func (s *CopyOnWrite[T]) RemoveSlice(items []T) bool {
+	if !s.set.ContainsAny(items...) {
+		return false
+	}
+	s.own()
+	return s.set.RemoveSlice(items)
+}
+
+// RemoveFunc will remove each element from s that satisfies condition f.
+//
+// Return true if s was modified, false otherwise.
+func (s *CopyOnWrite[T]) RemoveFunc(f func(item T) bool) bool {
+	if !s.set.ContainsFunc(f) {
+		return false
+	}
+	s.own()
+	return s.set.RemoveFunc(f)
+}
+
+// Contains returns whether item is present in s.
+func (s *CopyOnWrite[T]) Contains(item T) bool {
+	return s.set.Contains(item)
+}
+
+// ContainsAll returns whether s contains at least every item in items.
+func (s *CopyOnWrite[T]) ContainsAll(items ...T) bool {
+	return s.set.ContainsAll(items...)
+}
+
+// Size returns the cardinality of s.
+func (s *CopyOnWrite[T]) Size() int {
+	return s.set.Size()
+}
+
+// Empty returns true if s contains no elements, false otherwise.
+func (s *CopyOnWrite[T]) Empty() bool {
+	return s.set.Empty()
+}
+
+// Slice creates a copy of s as a slice. Elements are in no particular order.
+func (s *CopyOnWrite[T]) Slice() []T {
+	return s.set.Slice()
+}
+
+// ForEach calls visit for each element of s, in no particular order. Iteration
+// stops early if visit returns false.
+func (s *CopyOnWrite[T]) ForEach(visit func(item T) bool) {
+	s.set.ForEach(visit)
+}
+
+// Copy creates an independent Set containing the elements of s.
+func (s *CopyOnWrite[T]) Copy() *Set[T] {
+	return s.set.Copy()
+}
+
+// String creates a string representation of s, using "%v" printf formatting to transform
+// each element into a string. The result contains elements sorted by their lexical
+// string order.
+func (s *CopyOnWrite[T]) String() string {
+	return s.set.String()
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestCopyOnWrite(t *testing.T) {
+	t.Run("source unmodified", func(t *testing.T) {
+		source := Of(1, 2, 3)
+		s := NewCopyOnWrite(source)
+		must.True(t, s.Shared())
+		must.True(t, s.Insert(4))
+		must.False(t, s.Shared())
+		must.True(t, s.Remove(1))
+		must.True(t, source.EqualSlice([]int{1, 2, 3}))
+		must.True(t, ToSet[int](s).EqualSlice([]int{2, 3, 4}))
+	})
+
+	t.Run("no clone without change", func(t *testing.T) {
+		s := NewCopyOnWrite(Of(1, 2, 3))
+		must.False(t, s.Insert(1))
+		must.False(t, s.InsertSlice([]int{2, 3}))
+		must.False(t, s.InsertSlice([]int{1, 1, 1, 1}))
+		must.False(t, s.Remove(4))
+		must.False(t, s.RemoveSlice([]int{4, 5}))
+		must.False(t, s.RemoveFunc(func(i int) bool { return i > 3 }))
+		must.True(t, s.Shared())
+	})
+
+	t.Run("share", func(t *testing.T) {
+		a := NewCopyOnWrite(Of(1, 2))
+		a.Insert(3)
+		must.False(t, a.Shared())
+
+		b := a.Share()
+		must.True(t, a.Shared())
+		must.True(t, b.Shared())
+
+		must.True(t, b.InsertSlice([]int{4, 5}))
+		must.True(t, a.RemoveFunc(func(i int) bool { return i%2 == 0 }))
+		must.True(t, a.RemoveSlice([]int{1}))
+		must.Eq(t, "[3]", a.String())
+		must.Eq(t, "[1 2 3 4 5]", b.String())
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		s := NewCopyOnWrite(Of(1, 2))
+		c := s.Copy()
+		c.Insert(3)
+		must.Eq(t, 2, s.Size())
+		must.False(t, s.Empty())
+		must.True(t, s.ContainsAll(1, 2))
+	})
+
+	t.Run("concurrent sharers", func(t *testing.T) {
+		s := NewCopyOnWrite(From(ints(100)))
+		var wg sync.WaitGroup
+		for w := 0; w < 4; w++ {
+			reader := s.Share()
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for i := 1; i <= 100; i++ {
+					must.True(t, reader.Contains(i))
+				}
+				reader.Insert(1000 + w)
+				must.Eq(t, 101, reader.Size())
+			}(w)
+		}
+		wg.Wait()
+		must.Eq(t, 100, s.Size())
+	})
+}
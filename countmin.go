@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// CountMin is a Count-Min sketch, which estimates the number of occurrences of
+// each element of a stream using a small fixed amount of memory, for when an
+// exact Multiset would be too large. An estimate is never less than the true
+// count, and exceeds it by at most epsilon times the total of all counts with
+// probability 1 - delta.
+//
+// The sketch is a table of counters with one row per hash function; each
+// occurrence increments one counter in each row, and the estimate of an
+// element is the smallest of its counters. Elements are hashed as in a
+// CountingBloom, so the same hash function may be used for both.
+//
+// https://en.wikipedia.org/wiki/Count%E2%80%93min_sketch
+//
+// Not thread safe, and not safe for concurrent modification.
+type CountMin[T any] struct {
+	hash     func(T) uint64
+	width    int
+	depth    int
+	counters []uint64 // depth rows of width counters
+	total    int
+}
+
+// NewCountMin creates a CountMin whose estimates exceed the true count by at
+// most epsilon times the total of all counts, with probability 1 - delta,
+// hashing elements with hash.
+//
+// The hash function must be deterministic across processes for a serialized
+// sketch to be useful elsewhere; the functions of package hasher are suitable.
+func NewCountMin[T any](epsilon, delta float64, hash func(T) uint64) *CountMin[T] {
+	epsilon = math.Min(math.Max(epsilon, 1e-9), 1)
+	delta = math.Min(math.Max(delta, 1e-12), 0.5)
+	width := int(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	return &CountMin[T]{
+		hash:     hash,
+		width:    width,
+		depth:    depth,
+		counters: make([]uint64, width*depth),
+	}
+}
+
+// CountMinOf creates a CountMin as with NewCountMin, containing the
+// occurrences of each element of m.
+func CountMinOf[T comparable](m *Multiset[T], epsilon, delta float64, hash func(T) uint64) *CountMin[T] {
+	c := NewCountMin(epsilon, delta, hash)
+	m.ForEachCount(func(item T, count int) bool {
+		c.AddN(item, count)
+		return true
+	})
+	return c
+}
+
+// Add an occurrence of item to c.
+func (c *CountMin[T]) Add(item T) {
+	c.AddN(item, 1)
+}
+
+// AddN adds n occurrences of item to c. If n is not positive, c is not
+// modified.
+func (c *CountMin[T]) AddN(item T, n int) {
+	if n <= 0 {
+		return
+	}
+	c.each(item, func(cell *uint64) {
+		*cell += uint64(n)
+	})
+	c.total += n
+}
+
+// Estimate returns an estimate of the number of occurrences of item in c,
+// which is never less than the true count.
+func (c *CountMin[T]) Estimate(item T) int {
+	estimate := uint64(math.MaxUint64)
+	c.each(item, func(cell *uint64) {
+		estimate = min(estimate, *cell)
+	})
+	return int(estimate)
+}
+
+// each calls f with the counter of item in each row of c.
+func (c *CountMin[T]) each(item T, f func(cell *uint64)) {
+	row := 0
+	bloomIndexes(c.hash(item), c.width, c.depth, func(i int) bool {
+		f(&c.counters[row*c.width+i])
+		row++
+		return true
+	})
+}
+
+// Merge adds the counts of o to c, so that c estimates the counts of the
+// combined streams of c and o.
+//
+// Merge panics if c and o are of different sizes.
+func (c *CountMin[T]) Merge(o *CountMin[T]) {
+	if c.width != o.width || c.depth != o.depth {
+		panic("countmin: sketches are of different sizes")
+	}
+	for i, v := range o.counters {
+		c.counters[i] += v
+	}
+	c.total += o.total
+}
+
+// Total returns the total of all counts added to c.
+func (c *CountMin[T]) Total() int {
+	return c.total
+}
+
+// Empty returns true if no occurrences were added to c, false otherwise.
+func (c *CountMin[T]) Empty() bool {
+	return c.total == 0
+}
+
+// Clear resets every count of c to zero.
+func (c *CountMin[T]) Clear() {
+	clear(c.counters)
+	c.total = 0
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The format
+// is that of a Bloom filter, in which the number of cells is the width of c,
+// the number of hash functions is its depth, and each counter is encoded as a
+// uvarint.
+//
+// The hash function of c is not encoded, and must be provided again when
+// creating the sketch that is unmarshalled into.
+func (c *CountMin[T]) MarshalBinary() ([]byte, error) {
+	cells := make([]byte, 0, len(c.counters))
+	for _, v := range c.counters {
+		cells = binary.AppendUvarint(cells, v)
+	}
+	return marshalBloom(bloomCountMin, c.width, c.depth, c.total, cells), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. The
+// size and counts of c are replaced, while its hash function is kept.
+func (c *CountMin[T]) UnmarshalBinary(data []byte) error {
+	width, depth, total, cells, err := unmarshalBloom(bloomCountMin, data)
+	if err != nil {
+		return err
+	}
+	// each counter occupies at least one byte, which bounds a corrupt size
+	if width == 0 || depth == 0 || width*depth > len(cells) {
+		return errBinaryCorrupt
+	}
+	counters := make([]uint64, width*depth)
+	for i := range counters {
+		v, n := binary.Uvarint(cells)
+		if n <= 0 {
+			return errBinaryCorrupt
+		}
+		counters[i], cells = v, cells[n:]
+	}
+	if len(cells) > 0 {
+		return errBinaryCorrupt
+	}
+	c.width, c.depth, c.total, c.counters = width, depth, total, counters
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestCountMin_Estimate(t *testing.T) {
+	c := NewCountMin[string](0.001, 0.01, fnvString)
+	must.True(t, c.Empty())
+	must.Eq(t, 0, c.Estimate("a"))
+
+	c.Add("a")
+	c.AddN("b", 5)
+	c.AddN("c", 0)
+	c.AddN("c", -1)
+	must.Eq(t, 1, c.Estimate("a"))
+	must.Eq(t, 5, c.Estimate("b"))
+	must.Eq(t, 0, c.Estimate("c"))
+	must.Eq(t, 6, c.Total())
+
+	c.Clear()
+	must.True(t, c.Empty())
+	must.Eq(t, 0, c.Estimate("b"))
+}
+
+func TestCountMin_accuracy(t *testing.T) {
+	const epsilon = 0.001
+	rng := rand.New(rand.NewSource(42))
+	exact := NewMultiset[string](0)
+	c := NewCountMin[string](epsilon, 0.001, fnvString)
+
+	// a skewed stream, with a few heavy hitters
+	for range 100_000 {
+		item := strconv.Itoa(int(rng.ExpFloat64() * 500))
+		exact.Add(item)
+		c.Add(item)
+	}
+
+	bound := int(epsilon * float64(c.Total()))
+	exact.ForEachCount(func(item string, count int) bool {
+		estimate := c.Estimate(item)
+		must.GreaterEq(t, count, estimate)
+		must.LessEq(t, count+bound, estimate)
+		return true
+	})
+}
+
+func TestCountMin_Merge(t *testing.T) {
+	a := NewCountMin[string](0.01, 0.01, fnvString)
+	b := NewCountMin[string](0.01, 0.01, fnvString)
+	a.AddN("x", 3)
+	b.AddN("x", 4)
+	b.Add("y")
+
+	a.Merge(b)
+	must.Eq(t, 7, a.Estimate("x"))
+	must.Eq(t, 1, a.Estimate("y"))
+	must.Eq(t, 8, a.Total())
+
+	defer func() {
+		must.NotNil(t, recover())
+	}()
+	a.Merge(NewCountMin[string](0.1, 0.01, fnvString))
+}
+
+func TestCountMinOf(t *testing.T) {
+	m := MultisetOf("a", "a", "b")
+	c := CountMinOf(m, 0.01, 0.01, fnvString)
+	must.Eq(t, 2, c.Estimate("a"))
+	must.Eq(t, 1, c.Estimate("b"))
+	must.Eq(t, m.Size(), c.Total())
+}
+
+func TestCountMin_MarshalBinary(t *testing.T) {
+	c := NewCountMin[string](0.01, 0.01, fnvString)
+	for i := range 1000 {
+		c.AddN(strconv.Itoa(i%10), i)
+	}
+	data, err := c.MarshalBinary()
+	must.NoError(t, err)
+
+	result := NewCountMin[string](0.5, 0.5, fnvString)
+	must.NoError(t, result.UnmarshalBinary(data))
+	must.Eq(t, c.Total(), result.Total())
+	for i := range 10 {
+		must.Eq(t, c.Estimate(strconv.Itoa(i)), result.Estimate(strconv.Itoa(i)))
+	}
+
+	must.Error(t, result.UnmarshalBinary(data[:len(data)-1]))
+	must.Error(t, result.UnmarshalBinary(append(data, 0)))
+
+	// a counting bloom filter is not a count-min sketch
+	bloom, _ := NewCountingBloom[string](10, 0.01, fnvString).MarshalBinary()
+	must.Error(t, result.UnmarshalBinary(bloom))
+}
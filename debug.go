@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+)
+
+// verify panics if the internal invariants of s do not hold.
+func (s *HashSet[T, H]) verify() {
+	for key, item := range s.items {
+		if h := s.hash(item); h != key {
+			panic(fmt.Sprintf("hashset: element %v with hash %v stored under hash %v", item, h, key))
+		}
+	}
+	if s.equal == nil && len(s.overflow) > 0 {
+		panic("hashset: overflow elements present without an equality function")
+	}
+	collisions := 0
+	for key, bucket := range s.overflow {
+		first, exists := s.items[key]
+		if !exists {
+			panic(fmt.Sprintf("hashset: overflow bucket for hash %v has no first element", key))
+		}
+		if len(bucket) == 0 {
+			panic(fmt.Sprintf("hashset: empty overflow bucket for hash %v", key))
+		}
+		for i, item := range bucket {
+			if h := s.hash(item); h != key {
+				panic(fmt.Sprintf("hashset: element %v with hash %v stored under hash %v", item, h, key))
+			}
+			if s.equal(first, item) {
+				panic(fmt.Sprintf("hashset: duplicate elements %v and %v with hash %v", first, item, key))
+			}
+			for _, other := range bucket[i+1:] {
+				if s.equal(item, other) {
+					panic(fmt.Sprintf("hashset: duplicate elements %v and %v with hash %v", item, other, key))
+				}
+			}
+		}
+		collisions += len(bucket)
+	}
+	if collisions != s.collisions {
+		panic(fmt.Sprintf("hashset: collision count is %d, expected %d", s.collisions, collisions))
+	}
+	if size := s.Size(); s.capacity < size {
+		panic(fmt.Sprintf("hashset: capacity %d is less than size %d", s.capacity, size))
+	}
+}
+
+// verify panics if the internal invariants of s do not hold.
+func (s *TreeSet[T, C]) verify() {
+	if s.root != nil {
+		if s.root.parent != nil {
+			panic(fmt.Sprintf("treeset: root %v has parent %v", s.root.element, s.root.parent.element))
+		}
+		if s.root.red() {
+			panic(fmt.Sprintf("treeset: root %v is red", s.root.element))
+		}
+	}
+	s.verifyNode(s.root)
+
+	count := 0
+	var previous *node[T]
+	s.infix(func(n *node[T]) bool {
+		if previous != nil && s.comparison(previous.element, n.element) >= 0 {
+			panic(fmt.Sprintf("treeset: element %v is ordered before %v", previous.element, n.element))
+		}
+		previous = n
+		count++
+		return true
+	}, s.root)
+	if count != s.size {
+		panic(fmt.Sprintf("treeset: size is %d, expected %d", s.size, count))
+	}
+}
+
+// verifyNode panics if the subtree rooted at n is not a valid red-black tree,
+// and otherwise returns its black height.
+func (s *TreeSet[T, C]) verifyNode(n *node[T]) int {
+	if n == nil {
+		return 1
+	}
+	for _, child := range []*node[T]{n.left, n.right} {
+		if child == nil {
+			continue
+		}
+		if child.parent != n {
+			panic(fmt.Sprintf("treeset: child %v of %v has wrong parent", child.element, n.element))
+		}
+		if n.red() && child.red() {
+			panic(fmt.Sprintf("treeset: red node %v has red child %v", n.element, child.element))
+		}
+	}
+	left, right := s.verifyNode(n.left), s.verifyNode(n.right)
+	if left != right {
+		panic(fmt.Sprintf("treeset: node %v has black heights %d and %d", n.element, left, right))
+	}
+	if n.black() {
+		left++
+	}
+	return left
+}
@@ -0,0 +1,15 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build setdebug
+
+package set
+
+// debug enables verification of the internal invariants of a HashSet or
+// TreeSet after every modification, panicking with a description of any
+// violation. A violation typically indicates an inconsistent hash, equality,
+// or comparison function.
+//
+// Verification takes time proportional to the size of the set, so it is
+// intended for tests and integration environments only.
+const debug = true
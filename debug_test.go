@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func mustPanic(t *testing.T, contains string, f func()) {
+	t.Helper()
+	defer func() {
+		r := recover()
+		must.NotNil(t, r)
+		must.StrContains(t, fmt.Sprint(r), contains)
+	}()
+	f()
+}
+
+func TestHashSet_verify(t *testing.T) {
+	newSet := func(names ...string) *HashSet[*collider, int] {
+		s := NewHashSetEqual[*collider, int](0)
+		s.InsertSlice(colliders(names...))
+		return s
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		s := newSet("a", "b", "cc", "dd", "eee")
+		s.verify()
+		s.Remove(&collider{name: "b"})
+		s.Compact()
+		s.verify()
+	})
+
+	t.Run("wrong hash", func(t *testing.T) {
+		s := NewHashSetFunc(func(i int) int { return i }, 0)
+		s.Insert(1)
+		s.items[2] = 3
+		s.grew()
+		mustPanic(t, "element 3 with hash 3 stored under hash 2", s.verify)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		s := newSet("a", "b")
+		s.overflow[1] = append(s.overflow[1], s.items[1])
+		s.collisions++
+		mustPanic(t, "duplicate elements", s.verify)
+	})
+
+	t.Run("collisions", func(t *testing.T) {
+		s := newSet("a", "b")
+		s.collisions = 0
+		mustPanic(t, "collision count is 0, expected 1", s.verify)
+	})
+}
+
+func TestTreeSet_verify(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		ts := TreeSetFrom[int, Compare[int]](shuffle(ints(100)), Cmp[int])
+		ts.verify()
+		ts.RemoveSlice(ints(50))
+		ts.verify()
+	})
+
+	t.Run("order", func(t *testing.T) {
+		ts := TreeSetFrom[int, Compare[int]](ints(3), Cmp[int])
+		ts.root.left.element = 5
+		mustPanic(t, "element 5 is ordered before 2", ts.verify)
+	})
+
+	t.Run("red root", func(t *testing.T) {
+		ts := TreeSetFrom[int, Compare[int]](ints(3), Cmp[int])
+		ts.root.color = red
+		mustPanic(t, "root 2 is red", ts.verify)
+	})
+
+	t.Run("black height", func(t *testing.T) {
+		ts := TreeSetFrom[int, Compare[int]](ints(3), Cmp[int])
+		ts.root.left.color = black
+		mustPanic(t, "node 2 has black heights 2 and 1", ts.verify)
+	})
+
+	t.Run("size", func(t *testing.T) {
+		ts := TreeSetFrom[int, Compare[int]](ints(3), Cmp[int])
+		ts.size = 4
+		mustPanic(t, "size is 4, expected 3", ts.verify)
+	})
+}
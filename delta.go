@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrDeltaMismatch is returned by ApplyDelta when a delta was not computed
+// from the set it is applied to, so that applying it would not reproduce the
+// new version of the set. The receiver should fetch the full set instead.
+var ErrDeltaMismatch = errors.New("set: delta does not apply to set")
+
+// SetDelta is the difference between an old and a new version of a set, as
+// returned by Delta. Shipping a SetDelta rather than the new version of a set
+// costs bandwidth proportional to the number of changes rather than the size
+// of the set.
+//
+// A SetDelta is serialized with MarshalBinary, which encodes the added and
+// removed elements in the binary format of Set.
+type SetDelta[T comparable] struct {
+	added   *Set[T]
+	removed *Set[T]
+}
+
+// Delta returns the changes that transform old into new: the elements added
+// (present in new but not in old) and the elements removed (present in old but
+// not in new).
+func Delta[T comparable](old, new *Set[T]) *SetDelta[T] {
+	added, removed := Diff(old, new)
+	return &SetDelta[T]{added: added, removed: removed}
+}
+
+// ApplyDelta modifies s by applying d, so that if s is equal to the old set
+// that d was computed from, it becomes equal to the new set.
+//
+// Returns ErrDeltaMismatch without modifying s if d cannot have been computed
+// from s; that is, if any added element is already in s, or any removed
+// element is not in s.
+func ApplyDelta[T comparable](s *Set[T], d *SetDelta[T]) error {
+	if s.Intersects(d.added) || !s.Subset(d.removed) {
+		return ErrDeltaMismatch
+	}
+	s.Apply(d.added, d.removed)
+	return nil
+}
+
+// Added returns the elements added by d. The result must not be modified.
+func (d *SetDelta[T]) Added() *Set[T] {
+	return d.added
+}
+
+// Removed returns the elements removed by d. The result must not be modified.
+func (d *SetDelta[T]) Removed() *Set[T] {
+	return d.removed
+}
+
+// Size returns the number of changes in d, the number of elements added plus
+// the number of elements removed.
+func (d *SetDelta[T]) Size() int {
+	return d.added.Size() + d.removed.Size()
+}
+
+// Empty returns true if d contains no changes, false otherwise.
+func (d *SetDelta[T]) Empty() bool {
+	return d.added.Empty() && d.removed.Empty()
+}
+
+// Invert returns the delta that undoes d, transforming the new set back into
+// the old set.
+func (d *SetDelta[T]) Invert() *SetDelta[T] {
+	return &SetDelta[T]{added: d.removed, removed: d.added}
+}
+
+// String creates a string representation of d, listing the added elements
+// prefixed with "+" and the removed elements prefixed with "-".
+func (d *SetDelta[T]) String() string {
+	return fmt.Sprintf("+%s -%s", d.added, d.removed)
+}
+
+// The binary format of a SetDelta, as produced by MarshalBinary, is
+//
+//	version byte    (deltaVersion)
+//	length  uvarint (length of the added set)
+//	added   set     (in the binary format of Set)
+//	removed set     (in the binary format of Set)
+const deltaVersion = 1
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (d *SetDelta[T]) MarshalBinary() ([]byte, error) {
+	added, err := d.added.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	removed, err := d.removed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 0, 1+binary.MaxVarintLen64+len(added)+len(removed))
+	buf = append(buf, deltaVersion)
+	buf = binary.AppendUvarint(buf, uint64(len(added)))
+	buf = append(buf, added...)
+	return append(buf, removed...), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. The
+// changes of d are replaced.
+func (d *SetDelta[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 1 {
+		return errBinaryCorrupt
+	}
+	if version := data[0]; version != deltaVersion {
+		return fmt.Errorf("set: unsupported delta format version %d", version)
+	}
+	length, n := binary.Uvarint(data[1:])
+	if n <= 0 || length > uint64(len(data)-1-n) {
+		return errBinaryCorrupt
+	}
+	data = data[1+n:]
+
+	added, removed := New[T](0), New[T](0)
+	if err := added.UnmarshalBinary(data[:length]); err != nil {
+		return err
+	}
+	if err := removed.UnmarshalBinary(data[length:]); err != nil {
+		return err
+	}
+	if added.Intersects(removed) {
+		return errBinaryCorrupt
+	}
+	d.added, d.removed = added, removed
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestDelta(t *testing.T) {
+	old := From([]int{1, 2, 3, 4})
+	updated := From([]int{3, 4, 5})
+
+	d := Delta(old, updated)
+	must.True(t, d.Added().EqualSlice([]int{5}))
+	must.True(t, d.Removed().EqualSlice([]int{1, 2}))
+	must.Eq(t, 3, d.Size())
+	must.False(t, d.Empty())
+	must.Eq(t, "+[5] -[1 2]", d.String())
+
+	s := old.Copy()
+	must.NoError(t, ApplyDelta(s, d))
+	must.Eq(t, updated, s)
+
+	// and back again
+	must.NoError(t, ApplyDelta(s, d.Invert()))
+	must.Eq(t, old, s)
+
+	must.True(t, Delta(old, old.Copy()).Empty())
+}
+
+func TestApplyDelta_mismatch(t *testing.T) {
+	d := Delta(From([]int{1, 2}), From([]int{2, 3}))
+
+	for _, base := range [][]int{
+		{2},       // 1 is not present to be removed
+		{1, 2, 3}, // 3 is already present
+	} {
+		s := From(base)
+		err := ApplyDelta(s, d)
+		must.True(t, errors.Is(err, ErrDeltaMismatch))
+		must.True(t, s.EqualSlice(base))
+	}
+}
+
+func TestSetDelta_MarshalBinary(t *testing.T) {
+	t.Run("ints", func(t *testing.T) {
+		d := Delta(From(ints(1000)), From(ints(1002)[2:]))
+		data, err := d.MarshalBinary()
+		must.NoError(t, err)
+		must.Less(t, 16, len(data))
+
+		var result SetDelta[int]
+		must.NoError(t, result.UnmarshalBinary(data))
+		must.Eq(t, d.Added(), result.Added())
+		must.Eq(t, d.Removed(), result.Removed())
+	})
+
+	t.Run("strings", func(t *testing.T) {
+		d := Delta(From([]string{"a", "b"}), From([]string{"b", "c", "d"}))
+		data, err := d.MarshalBinary()
+		must.NoError(t, err)
+
+		var result SetDelta[string]
+		must.NoError(t, result.UnmarshalBinary(data))
+		must.Eq(t, d.String(), result.String())
+	})
+
+	t.Run("corrupt", func(t *testing.T) {
+		data, err := Delta(From([]int{1}), From([]int{2})).MarshalBinary()
+		must.NoError(t, err)
+
+		var result SetDelta[int]
+		must.Error(t, result.UnmarshalBinary(nil))
+		must.Error(t, result.UnmarshalBinary([]byte{9}))
+		must.Error(t, result.UnmarshalBinary(data[:len(data)-1]))
+		must.Error(t, result.UnmarshalBinary(append([]byte{1, 200}, data[2:]...)))
+
+		// an element may not be both added and removed
+		added, _ := From([]int{1}).MarshalBinary()
+		bad := append([]byte{deltaVersion, byte(len(added))}, added...)
+		must.Error(t, result.UnmarshalBinary(append(bad, added...)))
+	})
+}
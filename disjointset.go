@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+// DisjointSet is a union-find structure, which partitions its elements into
+// disjoint components. Components are merged with Union, and Find returns the
+// representative element of the component containing an element, so that two
+// elements are in the same component if and only if they share a
+// representative.
+//
+// Union and Find run in nearly constant amortized time, using union by rank
+// and path halving.
+//
+// https://en.wikipedia.org/wiki/Disjoint-set_data_structure
+//
+// Not thread safe, and not safe for concurrent modification.
+type DisjointSet[T comparable] struct {
+	parent     map[T]T
+	rank       map[T]int
+	components int
+}
+
+// NewDisjointSet creates a new empty DisjointSet with initial underlying
+// capacity of size.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func NewDisjointSet[T comparable](size int) *DisjointSet[T] {
+	return &DisjointSet[T]{
+		parent: make(map[T]T, max(0, size)),
+		rank:   make(map[T]int),
+	}
+}
+
+// DisjointSetFrom creates a new DisjointSet in which each item in items is in
+// a component of its own.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func DisjointSetFrom[T comparable](items []T) *DisjointSet[T] {
+	d := NewDisjointSet[T](len(items))
+	for _, item := range items {
+		d.Insert(item)
+	}
+	return d
+}
+
+// Insert item into d, in a component of its own.
+//
+// Return true if d was modified (item was not already in d), false otherwise.
+func (d *DisjointSet[T]) Insert(item T) bool {
+	if _, exists := d.parent[item]; exists {
+		return false
+	}
+	d.parent[item] = item
+	d.components++
+	return true
+}
+
+// Find returns the representative element of the component containing item,
+// and true. If item is not in d, the zero value and false are returned.
+func (d *DisjointSet[T]) Find(item T) (T, bool) {
+	parent, exists := d.parent[item]
+	if !exists {
+		var zero T
+		return zero, false
+	}
+	for parent != item {
+		// path halving: point item at its grandparent as we go
+		grandparent := d.parent[parent]
+		d.parent[item] = grandparent
+		item, parent = grandparent, d.parent[grandparent]
+	}
+	return item, true
+}
+
+// Union merges the components containing a and b, inserting either of them
+// into d first if necessary.
+//
+// Return true if d was modified (a and b were not already in the same
+// component), false otherwise.
+func (d *DisjointSet[T]) Union(a, b T) bool {
+	d.Insert(a)
+	d.Insert(b)
+	x, _ := d.Find(a)
+	y, _ := d.Find(b)
+	if x == y {
+		return false
+	}
+	rx, ry := d.rank[x], d.rank[y]
+	if rx < ry {
+		x, y = y, x
+	}
+	d.parent[y] = x
+	if rx == ry {
+		d.rank[x]++
+	}
+	delete(d.rank, y)
+	d.components--
+	return true
+}
+
+// SameSet returns whether a and b are both in d and in the same component.
+func (d *DisjointSet[T]) SameSet(a, b T) bool {
+	x, ok := d.Find(a)
+	if !ok {
+		return false
+	}
+	y, ok := d.Find(b)
+	return ok && x == y
+}
+
+// Contains returns whether item is present in d.
+func (d *DisjointSet[T]) Contains(item T) bool {
+	_, exists := d.parent[item]
+	return exists
+}
+
+// Size returns the number of elements in d.
+func (d *DisjointSet[T]) Size() int {
+	return len(d.parent)
+}
+
+// Empty returns true if d contains no elements, false otherwise.
+func (d *DisjointSet[T]) Empty() bool {
+	return len(d.parent) == 0
+}
+
+// NumComponents returns the number of disjoint components in d.
+func (d *DisjointSet[T]) NumComponents() int {
+	return d.components
+}
+
+// Component returns a Set of the elements in the same component as item,
+// including item itself. If item is not in d, the result is empty.
+func (d *DisjointSet[T]) Component(item T) *Set[T] {
+	result := New[T](0)
+	root, ok := d.Find(item)
+	if !ok {
+		return result
+	}
+	for element := range d.parent {
+		if r, _ := d.Find(element); r == root {
+			result.Insert(element)
+		}
+	}
+	return result
+}
+
+// Components returns a Set of the elements of each component of d. The
+// components are in no particular order.
+func (d *DisjointSet[T]) Components() []*Set[T] {
+	byRoot := make(map[T]*Set[T], d.components)
+	for element := range d.parent {
+		root, _ := d.Find(element)
+		component, exists := byRoot[root]
+		if !exists {
+			component = New[T](0)
+			byRoot[root] = component
+		}
+		component.Insert(element)
+	}
+	result := make([]*Set[T], 0, len(byRoot))
+	for _, component := range byRoot {
+		result = append(result, component)
+	}
+	return result
+}
+
+// Slice creates a copy of d as a slice. Elements are in no particular order.
+func (d *DisjointSet[T]) Slice() []T {
+	result := make([]T, 0, len(d.parent))
+	for element := range d.parent {
+		result = append(result, element)
+	}
+	return result
+}
+
+// ForEach calls visit for each element of d, in no particular order.
+// Iteration stops early if visit returns false.
+func (d *DisjointSet[T]) ForEach(visit func(item T) bool) {
+	for element := range d.parent {
+		if !visit(element) {
+			return
+		}
+	}
+}
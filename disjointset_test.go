@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+// componentStrings returns the sorted string form of each component of d
+func componentStrings(d *DisjointSet[int]) []string {
+	var result []string
+	for _, component := range d.Components() {
+		result = append(result, component.StringSorted(Cmp[int]))
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestDisjointSet_Insert(t *testing.T) {
+	d := NewDisjointSet[int](0)
+	must.True(t, d.Empty())
+	must.True(t, d.Insert(1))
+	must.False(t, d.Insert(1))
+	must.True(t, d.Contains(1))
+	must.False(t, d.Contains(2))
+
+	d = DisjointSetFrom([]int{1, 2, 3, 2})
+	must.Eq(t, 3, d.Size())
+	must.Eq(t, 3, d.NumComponents())
+}
+
+func TestDisjointSet_Union(t *testing.T) {
+	d := DisjointSetFrom(ints(6))
+	must.True(t, d.Union(1, 2))
+	must.False(t, d.Union(2, 1))
+	must.True(t, d.Union(3, 4))
+	must.True(t, d.Union(2, 4))
+	must.False(t, d.Union(1, 3))
+	must.Eq(t, 3, d.NumComponents())
+
+	// unknown elements are inserted
+	must.True(t, d.Union(7, 5))
+	must.Eq(t, 7, d.Size())
+	must.Eq(t, 3, d.NumComponents())
+
+	must.Eq(t, []string{"[1 2 3 4]", "[5 7]", "[6]"}, componentStrings(d))
+}
+
+func TestDisjointSet_Find(t *testing.T) {
+	d := NewDisjointSet[string](0)
+	_, ok := d.Find("a")
+	must.False(t, ok)
+
+	d.Union("a", "b")
+	d.Union("c", "d")
+	d.Union("b", "d")
+	ra, ok := d.Find("a")
+	must.True(t, ok)
+	for _, item := range []string{"b", "c", "d"} {
+		r, _ := d.Find(item)
+		must.Eq(t, ra, r)
+	}
+
+	must.True(t, d.SameSet("a", "c"))
+	must.False(t, d.SameSet("a", "z"))
+	must.False(t, d.SameSet("z", "z"))
+	d.Insert("z")
+	must.True(t, d.SameSet("z", "z"))
+	must.False(t, d.SameSet("a", "z"))
+}
+
+func TestDisjointSet_Component(t *testing.T) {
+	d := NewDisjointSet[int](0)
+	for i := 0; i < 100; i++ {
+		d.Union(i, i%7)
+	}
+	must.Eq(t, 7, d.NumComponents())
+	must.Len(t, 7, d.Components())
+
+	c := d.Component(10)
+	must.Size(t, 14, c)
+	must.True(t, c.Contains(3))
+	must.True(t, c.Contains(94))
+	must.Empty(t, d.Component(1000))
+}
+
+func TestDisjointSet_chain(t *testing.T) {
+	// a long chain of unions stays shallow and correct
+	d := NewDisjointSet[int](0)
+	for i := 1; i < 10000; i++ {
+		d.Union(i-1, i)
+	}
+	must.Eq(t, 1, d.NumComponents())
+	must.True(t, d.SameSet(0, 9999))
+	for item := range d.parent {
+		root, _ := d.Find(item)
+		must.Eq(t, root, d.parent[root])
+	}
+}
+
+func TestDisjointSet_ForEach(t *testing.T) {
+	d := DisjointSetFrom([]int{1, 2, 3})
+	must.SliceContainsAll(t, []int{1, 2, 3}, d.Slice())
+
+	visits := 0
+	d.ForEach(func(int) bool {
+		visits++
+		return false
+	})
+	must.Eq(t, 1, visits)
+}
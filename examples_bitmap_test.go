@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+)
+
+func ExampleBitmap_InsertRange() {
+	allocated := NewBitmap()
+	allocated.InsertRange(1000, 1005)
+	allocated.Insert(1 << 32)
+
+	released := BitmapOf(1001, 1003)
+
+	fmt.Println(allocated.Difference(released))
+	fmt.Println(allocated.Size())
+
+	// Output:
+	// [1000 1002 1004 4294967296]
+	// 6
+}
+
+func ExampleSparseBitSet_Union() {
+	a := SparseBitSetOf(1<<40, 7, 3)
+	b := SparseBitSetOf(3, 1<<50)
+
+	fmt.Println(a.Union(b))
+	fmt.Println(a.Intersect(b))
+
+	// Output:
+	// [3 7 1099511627776 1125899906842624]
+	// [3]
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+)
+
+func ExampleCountingBloom_Remove() {
+	b := NewCountingBloom[string](1000, 0.01, fnvString)
+	b.Insert("session-1")
+	b.Insert("session-2")
+	b.Remove("session-1")
+
+	fmt.Println(b.Contains("session-1"), b.Contains("session-2"))
+
+	// Output:
+	// false true
+}
+
+func ExampleCountMin_Estimate() {
+	c := NewCountMin[string](0.001, 0.01, fnvString)
+	c.AddN("/api/v1/jobs", 120)
+	c.AddN("/api/v1/nodes", 30)
+	c.Add("/ui")
+
+	fmt.Println(c.Estimate("/api/v1/jobs"), c.Estimate("/ui"), c.Total())
+
+	// Output:
+	// 120 1 151
+}
@@ -5,6 +5,7 @@ package set
 
 import (
 	"fmt"
+	"time"
 )
 
 type person struct {
@@ -62,6 +63,21 @@ func ExampleHashSet_Contains() {
 
 // ContainsSlice
 
+func ExampleHashSet_ContainsFunc() {
+	anna := &person{name: "anna", id: 94}
+	bill := &person{name: "bill", id: 50}
+	carl := &person{name: "carl", id: 10}
+	s := HashSetFrom[*person, string]([]*person{anna, bill, carl})
+
+	// queries that cannot be expressed as a hash lookup
+	fmt.Println(s.ContainsFunc(func(p *person) bool { return p.id < 20 }))
+	fmt.Println(s.ContainsFunc(func(p *person) bool { return p.name == "dave" }))
+
+	// Output:
+	// true
+	// false
+}
+
 // Subset
 
 // Size
@@ -81,3 +97,18 @@ func ExampleHashSet_Contains() {
 // Slice
 
 // String
+
+func ExampleNewHashSetFunc() {
+	// time.Duration does not implement HashFunc, so provide a hash function
+	// that buckets durations to the nearest second
+	s := NewHashSetFunc(func(d time.Duration) int64 {
+		return int64(d.Round(time.Second) / time.Second)
+	}, 10)
+	s.Insert(1 * time.Second)
+	s.Insert(1100 * time.Millisecond)
+	s.Insert(2 * time.Second)
+
+	fmt.Println(s.Size())
+	// Output:
+	// 2
+}
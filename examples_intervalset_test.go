@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+func ExampleIntervalSet_Insert() {
+	ports := NewIntervalSet[int, Compare[int]](Cmp[int])
+	ports.Insert(8000, 8010)
+	ports.Insert(8010, 8020)
+	ports.Insert(9000, 9005)
+	ports.Remove(8005, 8007)
+
+	fmt.Println(ports)
+	fmt.Println(ports.Contains(8006), ports.Contains(9004))
+	fmt.Println(ports.Gaps(8000, 9000))
+
+	// Output:
+	// [[8000, 8005) [8007, 8020) [9000, 9005)]
+	// false true
+	// [[8005, 8007) [8020, 9000)]
+}
+
+func ExampleIntervalTree_Overlapping() {
+	windows := NewIntervalTree[int, Compare[int]](Cmp[int])
+	windows.Insert(100, 200)
+	windows.Insert(150, 300)
+	windows.Insert(400, 500)
+
+	fmt.Println(windows.Stab(175))
+	fmt.Println(windows.Overlapping(250, 450))
+	fmt.Println(windows.Overlaps(300, 400))
+
+	// Output:
+	// [[100, 200) [150, 300)]
+	// [[150, 300) [400, 500)]
+	// false
+}
+
+func ExampleIPSet_InsertPrefix() {
+	s := NewIPSet()
+	s.InsertPrefix(netip.MustParsePrefix("10.0.0.0/25"))
+	s.InsertPrefix(netip.MustParsePrefix("10.0.0.128/25"))
+	s.InsertPrefix(netip.MustParsePrefix("192.168.0.0/16"))
+	s.RemovePrefix(netip.MustParsePrefix("192.168.0.0/17"))
+
+	fmt.Println(s)
+	fmt.Println(s.Contains(netip.MustParseAddr("10.0.0.200")))
+
+	// Output:
+	// [10.0.0.0/24 192.168.128.0/17]
+	// true
+}
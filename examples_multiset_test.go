@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+)
+
+func ExampleMultiset_Add() {
+	m := NewMultiset[string](10)
+	m.Add("apple")
+	m.Add("banana")
+	m.Add("apple")
+
+	fmt.Println(m)
+	fmt.Println(m.Count("apple"), m.Size())
+
+	// Output:
+	// [apple:2 banana:1]
+	// 2 3
+}
+
+func ExampleMultiset_Union() {
+	a := MultisetOf(1, 1, 2)
+	b := MultisetOf(1, 2, 2, 3)
+
+	fmt.Println(a.Union(b))
+	fmt.Println(a.Intersect(b))
+	fmt.Println(a.Difference(b))
+
+	// Output:
+	// [1:2 2:2 3:1]
+	// [1:1 2:1]
+	// [1:1]
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+)
+
+func ExampleOrderedSet_Insert() {
+	s := NewOrderedSet[string](10)
+	s.Insert("mitchell")
+	s.Insert("armon")
+	s.Insert("jack")
+	s.Insert("armon")
+
+	fmt.Println(s)
+
+	// Output:
+	// [mitchell armon jack]
+}
+
+func ExampleOrderedSet_Remove() {
+	s := OrderedSetOf(3, 1, 2)
+	s.Remove(3)
+	s.Insert(3)
+
+	fmt.Println(s)
+
+	// Output:
+	// [1 2 3]
+}
+
+func ExampleOrderedSet_Union() {
+	a := OrderedSetOf("z", "y")
+	b := OrderedSetOf("x", "y", "w")
+
+	fmt.Println(a.Union(b))
+
+	// Output:
+	// [z y x w]
+}
@@ -5,6 +5,9 @@ package set
 
 import (
 	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
 	"sort"
 )
 
@@ -97,8 +100,8 @@ func ExampleSet_Contains() {
 func ExampleSet_ContainsAll() {
 	s := From([]string{"red", "green", "blue"})
 
-	fmt.Println(s.ContainsAll([]string{"red", "blue"}))
-	fmt.Println(s.ContainsAll([]string{"red", "orange"}))
+	fmt.Println(s.ContainsAll("red", "blue"))
+	fmt.Println(s.ContainsAll("red", "orange"))
 
 	// Output:
 	// true
@@ -247,3 +250,232 @@ func ExampleSet_String() {
 	// Output:
 	// [blue green red]
 }
+
+func ExampleDiff() {
+	desired := From([]string{"api", "web", "worker"})
+	actual := From([]string{"web", "cron"})
+
+	create, destroy := Diff(actual, desired)
+
+	fmt.Println(create)
+	fmt.Println(destroy)
+
+	// Output:
+	// [api worker]
+	// [cron]
+}
+
+func ExampleSet_Apply() {
+	replica := From([]string{"web", "cron"})
+	create, destroy := Diff(replica, From([]string{"api", "web"}))
+
+	replica.Apply(create, destroy)
+	fmt.Println(replica)
+
+	replica.Revert(create, destroy)
+	fmt.Println(replica)
+
+	// Output:
+	// [api web]
+	// [cron web]
+}
+
+func ExampleUnique() {
+	names := []string{"mitchell", "armon", "jack", "dave", "armon", "dave"}
+	fmt.Println(Unique(names))
+
+	// Output:
+	// [mitchell armon jack dave]
+}
+
+func ExampleFromSplit() {
+	s := FromSplit("us-east-1, us-west-2,,eu-west-1", ",")
+	fmt.Println(s)
+
+	// Output:
+	// [eu-west-1 us-east-1 us-west-2]
+}
+
+func ExampleJaccard() {
+	a := Of("env=prod", "team=infra", "region=us")
+	b := Of("env=prod", "team=infra", "region=eu")
+
+	fmt.Println(Jaccard(a, b))
+
+	// Output:
+	// 0.5
+}
+
+func ExampleDisjointSet_Union() {
+	d := NewDisjointSet[string](0)
+	d.Union("api", "db")
+	d.Union("web", "api")
+	d.Insert("batch")
+
+	fmt.Println(d.SameSet("web", "db"), d.SameSet("web", "batch"))
+	fmt.Println(d.NumComponents())
+	fmt.Println(d.Component("db"))
+
+	// Output:
+	// true false
+	// 2
+	// [api db web]
+}
+
+func ExamplePersistentSet_Insert() {
+	v1 := PersistentSetOf("a", "b")
+	v2 := v1.Insert("c")
+	v3 := v2.Remove("a")
+
+	fmt.Println(v1, v2, v3)
+
+	// Output:
+	// [a b] [a b c] [b c]
+}
+
+func ExampleTopK_Insert() {
+	top := NewTopK(3, Cmp[int])
+	top.InsertSlice([]int{42, 7, 99, 13, 64, 3, 81})
+
+	fmt.Println(top)
+
+	threshold, _ := top.Threshold()
+	fmt.Println(threshold)
+
+	// Output:
+	// [99 81 64]
+	// 64
+}
+
+func ExampleWeightedSet_PickWeighted() {
+	// nodes weighted by their free capacity
+	nodes := WeightedSetFrom(map[string]float64{
+		"node-a": 8,
+		"node-b": 2,
+		"node-c": 0,
+	})
+
+	rng := rand.New(rand.NewSource(1))
+	placed := make(map[string]int)
+	for range 1000 {
+		node, _ := nodes.PickWeighted(rng)
+		placed[node]++
+	}
+
+	fmt.Println(placed["node-a"] > placed["node-b"], placed["node-c"])
+
+	// Output:
+	// true 0
+}
+
+func ExampleUnionView() {
+	allowed := From([]string{"alice", "bob"})
+	admins := From([]string{"carol"})
+
+	// no combined set is built
+	v := UnionView[string](allowed, admins)
+
+	fmt.Println(v.Contains("carol"), v.Contains("dave"), v.Size())
+
+	// Output:
+	// true false 3
+}
+
+func ExampleVersionedSet_Snapshot() {
+	s := VersionedSetFrom([]string{"a", "b"})
+	snap := s.Snapshot()
+
+	// modification continues while the snapshot is read
+	s.Insert("c")
+	s.Remove("a")
+
+	fmt.Println(snap, snap.Version())
+	fmt.Println(s, s.Version())
+
+	// Output:
+	// [a b] 0
+	// [b c] 2
+}
+
+func ExampleDelta() {
+	old := From([]string{"node-1", "node-2", "node-3"})
+	updated := From([]string{"node-2", "node-3", "node-4"})
+
+	// only the changes are sent to the replica
+	data, _ := Delta(old, updated).MarshalBinary()
+
+	var d SetDelta[string]
+	_ = d.UnmarshalBinary(data)
+	replica := old.Copy()
+	_ = ApplyDelta(replica, &d)
+
+	fmt.Println(d.String())
+	fmt.Println(replica)
+
+	// Output:
+	// +[node-4] -[node-1]
+	// [node-2 node-3 node-4]
+}
+
+func ExampleKDTree_Nearest() {
+	type node struct {
+		name string
+		x, y float64
+	}
+	nodes := KDTreeFrom([]node{
+		{"a", 0, 0},
+		{"b", 10, 0},
+		{"c", 5, 8},
+	}, 2, func(n node, axis int) float64 {
+		return [2]float64{n.x, n.y}[axis]
+	})
+
+	nearest, _ := nodes.Nearest([]float64{6, 6})
+	fmt.Println(nearest.name)
+
+	// Output:
+	// c
+}
+
+func ExamplePerfectSet_Contains() {
+	denied, _ := BuildPerfectSet[string](From([]string{"evil.example", "spam.example", "phish.example"}), fnvString)
+
+	fmt.Println(denied.Contains("spam.example"), denied.Contains("good.example"), denied.Size())
+
+	// Output:
+	// true false 3
+}
+
+func ExampleOpenMappedSet() {
+	dir, _ := os.MkdirTemp("", "example")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "denylist.mph")
+
+	f, _ := os.Create(path)
+	_ = WriteMappedSet(f, From([]string{"evil.example", "spam.example"}))
+	_ = f.Close()
+
+	denied, _ := OpenMappedSet(path)
+	defer denied.Close()
+
+	fmt.Println(denied.Contains("spam.example"), denied.Contains("good.example"))
+
+	// Output:
+	// true false
+}
+
+func ExampleAdaptiveSet_Insert() {
+	s := NewAdaptiveSet[int](0)
+	s.Insert(1)
+	s.Insert(2)
+	fmt.Println(s, s.Upgraded())
+
+	for i := range 100 {
+		s.Insert(i)
+	}
+	fmt.Println(s.Size(), s.Upgraded())
+
+	// Output:
+	// [1 2] false
+	// 100 true
+}
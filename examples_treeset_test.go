@@ -5,6 +5,7 @@ package set
 
 import (
 	"fmt"
+	"slices"
 )
 
 func ExampleCompare_contestant() {
@@ -413,3 +414,31 @@ func ExampleTreeSet_BelowEqual() {
 	// [1 2 3]
 	// [1 2 3 4 5]
 }
+
+func ExampleTreeBag_Range() {
+	b := TreeBagFrom[int, Compare[int]]([]int{7, 3, 5, 3, 9, 5, 5}, Cmp[int])
+
+	fmt.Println(b)
+	fmt.Println(b.Min(), b.Max())
+	fmt.Println(b.Range(4, 9))
+
+	// Output:
+	// [3:2 5:3 7:1 9:1]
+	// 3 9
+	// [5 5 5 7]
+}
+
+func ExampleMergeDifference() {
+	exported := slices.Values([]int{1, 2, 3, 5, 8, 13})
+	deleted := slices.Values([]int{2, 8, 21})
+
+	for id := range MergeDifference(exported, deleted, Cmp[int]) {
+		fmt.Println(id)
+	}
+
+	// Output:
+	// 1
+	// 3
+	// 5
+	// 13
+}
@@ -0,0 +1,344 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"slices"
+)
+
+// ExternalSet is a set of elements too numerous to hold in memory, which are
+// sorted and de-duplicated with the help of temporary files. Elements are
+// buffered in memory until the buffer holds limit elements, at which point the
+// buffer is sorted and spilled to a temporary file as a sorted run. All then
+// streams the elements in sorted order by merging the runs, so that at most
+// limit elements (plus one per run) are held in memory at any time.
+//
+// Elements are written to temporary files with encoding/gob, so T must be
+// encodable by gob.
+//
+// An ExternalSet must be closed to remove its temporary files.
+//
+// Not thread safe, and not safe for concurrent modification.
+type ExternalSet[T any] struct {
+	compare Compare[T]
+	dir     string
+	limit   int
+	buf     []T
+	runs    []string
+	err     error
+}
+
+// NewExternalSet creates a new empty ExternalSet ordered by compare, which
+// holds at most limit elements in memory, and creates its temporary files in
+// dir. If dir is the empty string, the default directory for temporary files
+// is used, as with os.CreateTemp.
+func NewExternalSet[T any](compare Compare[T], limit int, dir string) *ExternalSet[T] {
+	limit = max(1, limit)
+	return &ExternalSet[T]{
+		compare: compare,
+		dir:     dir,
+		limit:   limit,
+		buf:     make([]T, 0, limit),
+	}
+}
+
+// Insert item into s, spilling the buffered elements of s to a temporary file
+// if the buffer is full.
+//
+// Returns the first error encountered by s, if any.
+func (s *ExternalSet[T]) Insert(item T) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.buf = append(s.buf, item)
+	if len(s.buf) >= s.limit {
+		s.spill()
+	}
+	return s.err
+}
+
+// InsertSeq will insert each item produced by seq into s.
+//
+// Returns the first error encountered by s, if any.
+func (s *ExternalSet[T]) InsertSeq(seq iter.Seq[T]) error {
+	for item := range seq {
+		if err := s.Insert(item); err != nil {
+			return err
+		}
+	}
+	return s.err
+}
+
+// spill sorts the buffered elements of s and writes them to a new run.
+func (s *ExternalSet[T]) spill() {
+	s.sortBuffer()
+	f, err := os.CreateTemp(s.dir, "go-set-*.run")
+	if err != nil {
+		s.err = fmt.Errorf("set: creating run: %w", err)
+		return
+	}
+	s.runs = append(s.runs, f.Name())
+
+	w := bufio.NewWriter(f)
+	enc := gob.NewEncoder(w)
+	for _, item := range s.buf {
+		if err = enc.Encode(item); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		s.err = fmt.Errorf("set: writing run: %w", err)
+		return
+	}
+	clear(s.buf)
+	s.buf = s.buf[:0]
+}
+
+// sortBuffer sorts and de-duplicates the buffered elements of s.
+func (s *ExternalSet[T]) sortBuffer() {
+	slices.SortFunc(s.buf, s.compare)
+	s.buf = slices.CompactFunc(s.buf, func(a, b T) bool {
+		return s.compare(a, b) == 0
+	})
+}
+
+// Runs returns the number of sorted runs s has spilled to temporary files.
+func (s *ExternalSet[T]) Runs() int {
+	return len(s.runs)
+}
+
+// All returns an iterator over the distinct elements of s, in the order of
+// its compare function. Each call of the iterator reads the temporary files
+// of s again. Elements must not be inserted while iterating.
+//
+// If an error is encountered while reading a temporary file, iteration
+// stops early, and the error is returned by Err.
+func (s *ExternalSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if s.err != nil {
+			return
+		}
+		s.sortBuffer()
+		seqs := make([]iter.Seq[T], 0, len(s.runs)+1)
+		for _, name := range s.runs {
+			seqs = append(seqs, s.read(name))
+		}
+		seqs = append(seqs, slices.Values(s.buf))
+		mergeSorted(seqs, s.compare)(yield)
+	}
+}
+
+// read returns an iterator over the elements of the run in the file name.
+func (s *ExternalSet[T]) read(name string) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		f, err := os.Open(name)
+		if err != nil {
+			s.err = fmt.Errorf("set: opening run: %w", err)
+			return
+		}
+		defer f.Close()
+
+		dec := gob.NewDecoder(bufio.NewReader(f))
+		for {
+			var item T
+			if err := dec.Decode(&item); err != nil {
+				if !errors.Is(err, io.EOF) {
+					s.err = fmt.Errorf("set: reading run: %w", err)
+				}
+				return
+			}
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Err returns the first error encountered by s, if any.
+func (s *ExternalSet[T]) Err() error {
+	return s.err
+}
+
+// Close removes the temporary files of s. The elements of s are discarded.
+func (s *ExternalSet[T]) Close() error {
+	var errs []error
+	for _, name := range s.runs {
+		if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	s.runs = nil
+	clear(s.buf)
+	s.buf = s.buf[:0]
+	return errors.Join(errs...)
+}
+
+// MergeUnion returns an iterator over the elements produced by a or b, each
+// of which must produce elements in the order of compare. The result is in
+// the same order, and elements comparing as equal are produced once.
+//
+// The inputs are streamed, so MergeUnion uses constant memory regardless of
+// their length.
+func MergeUnion[T any](a, b iter.Seq[T], compare Compare[T]) iter.Seq[T] {
+	return mergeSorted([]iter.Seq[T]{a, b}, compare)
+}
+
+// MergeIntersect returns an iterator over the elements produced by both a
+// and b, each of which must produce elements in the order of compare. The
+// result is in the same order, and elements comparing as equal are produced
+// once.
+//
+// The inputs are streamed, so MergeIntersect uses constant memory regardless
+// of their length.
+func MergeIntersect[T any](a, b iter.Seq[T], compare Compare[T]) iter.Seq[T] {
+	return mergeTwo(a, b, compare, func(inA, inB bool) bool {
+		return inA && inB
+	})
+}
+
+// MergeDifference returns an iterator over the elements produced by a but
+// not b, each of which must produce elements in the order of compare. The
+// result is in the same order, and elements comparing as equal are produced
+// once.
+//
+// The inputs are streamed, so MergeDifference uses constant memory regardless
+// of their length.
+func MergeDifference[T any](a, b iter.Seq[T], compare Compare[T]) iter.Seq[T] {
+	return mergeTwo(a, b, compare, func(inA, inB bool) bool {
+		return inA && !inB
+	})
+}
+
+// mergeTwo walks the sorted sequences a and b in step, producing each
+// distinct element for which keep returns true given whether it is in a and
+// whether it is in b.
+func mergeTwo[T any](a, b iter.Seq[T], compare Compare[T], keep func(inA, inB bool) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nextA, stopA := iter.Pull(distinct(a, compare))
+		defer stopA()
+		nextB, stopB := iter.Pull(distinct(b, compare))
+		defer stopB()
+
+		x, okA := nextA()
+		y, okB := nextB()
+		for okA || okB {
+			c := 0
+			switch {
+			case !okB:
+				c = -1
+			case !okA:
+				c = 1
+			default:
+				c = compare(x, y)
+			}
+
+			item := x
+			if c > 0 {
+				item = y
+			}
+			if keep(c <= 0, c >= 0) && !yield(item) {
+				return
+			}
+			if c <= 0 {
+				x, okA = nextA()
+			}
+			if c >= 0 {
+				y, okB = nextB()
+			}
+		}
+	}
+}
+
+// mergeSorted returns an iterator over the distinct elements of seqs, each of
+// which must produce elements in the order of compare, using a min-heap of the
+// next element of each sequence.
+func mergeSorted[T any](seqs []iter.Seq[T], compare Compare[T]) iter.Seq[T] {
+	type head struct {
+		item T
+		next func() (T, bool)
+	}
+	return func(yield func(T) bool) {
+		heads := make([]head, 0, len(seqs))
+		for _, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			if item, ok := next(); ok {
+				heads = append(heads, head{item: item, next: next})
+			}
+		}
+
+		less := func(i, j int) bool {
+			return compare(heads[i].item, heads[j].item) < 0
+		}
+		down := func(i int) {
+			for {
+				smallest := i
+				if left := 2*i + 1; left < len(heads) && less(left, smallest) {
+					smallest = left
+				}
+				if right := 2*i + 2; right < len(heads) && less(right, smallest) {
+					smallest = right
+				}
+				if smallest == i {
+					return
+				}
+				heads[i], heads[smallest] = heads[smallest], heads[i]
+				i = smallest
+			}
+		}
+		for i := len(heads)/2 - 1; i >= 0; i-- {
+			down(i)
+		}
+
+		var last T
+		started := false
+		for len(heads) > 0 {
+			item := heads[0].item
+			if !started || compare(item, last) != 0 {
+				if !yield(item) {
+					return
+				}
+				last, started = item, true
+			}
+			if next, ok := heads[0].next(); ok {
+				heads[0].item = next
+			} else {
+				heads[0] = heads[len(heads)-1]
+				heads = heads[:len(heads)-1]
+			}
+			down(0)
+		}
+	}
+}
+
+// distinct returns an iterator over seq, which must produce elements in the
+// order of compare, skipping elements equal to the previous element.
+func distinct[T any](seq iter.Seq[T], compare Compare[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var last T
+		started := false
+		for item := range seq {
+			if started && compare(item, last) == 0 {
+				continue
+			}
+			if !yield(item) {
+				return
+			}
+			last, started = item, true
+		}
+	}
+}
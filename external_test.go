@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"math/rand"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestExternalSet(t *testing.T) {
+	dir := t.TempDir()
+	rng := rand.New(rand.NewSource(42))
+
+	s := NewExternalSet(Cmp[int], 100, dir)
+	expect := New[int](0)
+	for range 2_000 {
+		i := rng.Intn(1_500)
+		must.NoError(t, s.Insert(i))
+		expect.Insert(i)
+	}
+	must.Positive(t, s.Runs())
+
+	sorted := slices.Collect(s.All())
+	must.Eq(t, expect.SortedSlice(Cmp[int]), sorted)
+
+	// the runs are read again by every iteration
+	must.Eq(t, sorted, slices.Collect(s.All()))
+	must.NoError(t, s.Err())
+
+	entries, err := os.ReadDir(dir)
+	must.NoError(t, err)
+	must.SliceLen(t, s.Runs(), entries)
+
+	must.NoError(t, s.Close())
+	entries, err = os.ReadDir(dir)
+	must.NoError(t, err)
+	must.SliceEmpty(t, entries)
+}
+
+func TestExternalSet_InsertSeq(t *testing.T) {
+	s := NewExternalSet(Cmp[string], 2, t.TempDir())
+	defer s.Close()
+
+	must.NoError(t, s.InsertSeq(slices.Values([]string{"c", "a", "b", "a", "d", "c"})))
+	must.Eq(t, []string{"a", "b", "c", "d"}, slices.Collect(s.All()))
+
+	// stops early
+	for item := range s.All() {
+		must.Eq(t, "a", item)
+		break
+	}
+}
+
+func TestExternalSet_errors(t *testing.T) {
+	t.Run("missing dir", func(t *testing.T) {
+		s := NewExternalSet(Cmp[int], 1, "/does/not/exist")
+		must.Error(t, s.Insert(1))
+		must.Error(t, s.Insert(2))
+		must.Error(t, s.Err())
+		must.SliceEmpty(t, slices.Collect(s.All()))
+	})
+
+	t.Run("removed run", func(t *testing.T) {
+		dir := t.TempDir()
+		s := NewExternalSet(Cmp[int], 1, dir)
+		must.NoError(t, s.InsertSeq(slices.Values(ints(3))))
+		must.NoError(t, os.RemoveAll(dir))
+		_ = slices.Collect(s.All())
+		must.Error(t, s.Err())
+		must.NoError(t, s.Close())
+	})
+}
+
+func TestMergeUnion(t *testing.T) {
+	a := slices.Values([]int{1, 1, 3, 5, 7})
+	b := slices.Values([]int{2, 3, 3, 4, 8, 9})
+	must.Eq(t, []int{1, 2, 3, 4, 5, 7, 8, 9}, slices.Collect(MergeUnion(a, b, Cmp[int])))
+
+	empty := slices.Values([]int{})
+	must.Eq(t, []int{1, 3, 5, 7}, slices.Collect(MergeUnion(a, empty, Cmp[int])))
+	must.SliceEmpty(t, slices.Collect(MergeUnion(empty, empty, Cmp[int])))
+}
+
+func TestMergeIntersect(t *testing.T) {
+	a := slices.Values([]int{1, 2, 2, 3, 5, 8})
+	b := slices.Values([]int{2, 3, 3, 4, 8, 9})
+	must.Eq(t, []int{2, 3, 8}, slices.Collect(MergeIntersect(a, b, Cmp[int])))
+	must.SliceEmpty(t, slices.Collect(MergeIntersect(a, slices.Values([]int{4, 6}), Cmp[int])))
+}
+
+func TestMergeDifference(t *testing.T) {
+	a := slices.Values([]int{1, 2, 2, 3, 5, 8, 10, 10})
+	b := slices.Values([]int{2, 3, 3, 4, 8, 9})
+	must.Eq(t, []int{1, 5, 10}, slices.Collect(MergeDifference(a, b, Cmp[int])))
+	must.Eq(t, []int{4, 9}, slices.Collect(MergeDifference(b, a, Cmp[int])))
+
+	// stops early
+	for item := range MergeDifference(a, b, Cmp[int]) {
+		must.Eq(t, 1, item)
+		break
+	}
+}
+
+func TestExternalSet_merge(t *testing.T) {
+	a := NewExternalSet(Cmp[int], 10, t.TempDir())
+	defer a.Close()
+	b := NewExternalSet(Cmp[int], 10, t.TempDir())
+	defer b.Close()
+
+	must.NoError(t, a.InsertSeq(slices.Values(ints(100))))
+	must.NoError(t, b.InsertSeq(intRange(50, 150, 1)))
+
+	union := slices.Collect(MergeUnion(a.All(), b.All(), Cmp[int]))
+	must.Eq(t, ints(149), union)
+
+	intersect := slices.Collect(MergeIntersect(a.All(), b.All(), Cmp[int]))
+	must.Eq(t, slices.Collect(intRange(50, 101, 1)), intersect)
+}
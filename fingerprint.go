@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"hash/maphash"
+)
+
+// fingerprintSeed is the seed used to hash elements for Fingerprint.
+var fingerprintSeed = maphash.MakeSeed()
+
+// fingerprint combines the hash of each element visited by each into a value
+// that does not depend on the order in which elements are visited.
+func fingerprint[K comparable](each func(visit func(key K) bool)) uint64 {
+	var sum, count uint64
+	each(func(key K) bool {
+		sum += mix(maphash.Comparable(fingerprintSeed, key))
+		count++
+		return true
+	})
+	return mix(sum ^ count)
+}
+
+// mix is the finalizer of the splitmix64 generator, which spreads the bits
+// of h so that sums of hashes are not easily cancelled.
+func mix(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
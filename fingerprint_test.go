@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestSet_Fingerprint(t *testing.T) {
+	t.Run("order independent", func(t *testing.T) {
+		a := From(ints(100))
+		b := From(shuffle(ints(100)))
+		must.Eq(t, a.Fingerprint(), b.Fingerprint())
+		must.Eq(t, a.Fingerprint(), a.Copy().Fingerprint())
+	})
+
+	t.Run("membership change", func(t *testing.T) {
+		s := From(ints(10))
+		before := s.Fingerprint()
+		s.Remove(3)
+		must.NotEq(t, before, s.Fingerprint())
+		s.Insert(3)
+		must.Eq(t, before, s.Fingerprint())
+		s.Insert(11)
+		must.NotEq(t, before, s.Fingerprint())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		must.Eq(t, New[int](0).Fingerprint(), New[int](10).Fingerprint())
+		must.NotEq(t, New[int](0).Fingerprint(), Of(0).Fingerprint())
+	})
+
+	t.Run("distinct", func(t *testing.T) {
+		seen := New[uint64](0)
+		for i := 0; i < 100; i++ {
+			for j := i + 1; j < 100; j++ {
+				must.True(t, seen.Insert(Of(i, j).Fingerprint()))
+			}
+		}
+	})
+}
+
+func TestHashSet_Fingerprint(t *testing.T) {
+	a := HashSetOf[*company, string](c1, c2, c3)
+	b := HashSetOf[*company, string](c3, c2, c1)
+	must.Eq(t, a.Fingerprint(), b.Fingerprint())
+
+	b.Remove(c2)
+	must.NotEq(t, a.Fingerprint(), b.Fingerprint())
+}
+
+func TestOrderedSet_Fingerprint(t *testing.T) {
+	a := OrderedSetOf("a", "b", "c")
+	b := OrderedSetOf("c", "b", "a")
+	must.Eq(t, a.Fingerprint(), b.Fingerprint())
+	must.Eq(t, a.Fingerprint(), From(a.Slice()).Fingerprint())
+}
+
+func TestSyncSet_Fingerprint(t *testing.T) {
+	a := SyncSetOf(1, 2, 3)
+	must.Eq(t, Of(3, 2, 1).Fingerprint(), a.Fingerprint())
+
+	h := NewSyncHashSet[*company, string](0)
+	h.InsertSlice([]*company{c1, c2, c3})
+	must.Eq(t, HashSetOf[*company, string](c1, c2, c3).Fingerprint(), h.Fingerprint())
+}
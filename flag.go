@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Flag is a flag.Value that accumulates the values of a repeated command line
+// flag into a Set, e.g. "--tag x --tag y". Each value may also contain several
+// comma separated elements, e.g. "--tag x,y".
+//
+// Flag also implements the Type method and the SliceValue interface of the
+// github.com/spf13/pflag package, so it may be used with pflag.Var.
+type Flag[T comparable] struct {
+	set   *Set[T]
+	parse func(string) (T, error)
+	kind  string
+}
+
+// NewFlag creates a Flag that inserts into s each value parsed by parse.
+// kind is the name of the type of value, as reported by Type.
+func NewFlag[T comparable](s *Set[T], kind string, parse func(string) (T, error)) *Flag[T] {
+	return &Flag[T]{set: s, parse: parse, kind: kind}
+}
+
+// StringFlag creates a Flag that inserts each value into s.
+func StringFlag(s *Set[string]) *Flag[string] {
+	return NewFlag(s, "stringSet", func(value string) (string, error) {
+		return value, nil
+	})
+}
+
+// IntFlag creates a Flag that inserts each value into s, parsed as a decimal,
+// hexadecimal, octal, or binary integer as by strconv.ParseInt.
+func IntFlag(s *Set[int]) *Flag[int] {
+	return NewFlag(s, "intSet", func(value string) (int, error) {
+		i, err := strconv.ParseInt(value, 0, strconv.IntSize)
+		return int(i), err
+	})
+}
+
+// Set implements flag.Value, inserting each comma separated element of value
+// into the underlying Set. Surrounding whitespace is trimmed from each element
+// and empty elements are ignored.
+//
+// If any element fails to parse, no elements of value are inserted.
+func (f *Flag[T]) Set(value string) error {
+	items, err := f.parseAll(strings.Split(value, ","))
+	if err != nil {
+		return err
+	}
+	f.set.InsertSlice(items)
+	return nil
+}
+
+// String implements flag.Value, returning the elements of the underlying Set
+// sorted by their lexical string order.
+func (f *Flag[T]) String() string {
+	if f == nil || f.set == nil {
+		return "[]"
+	}
+	return f.set.String()
+}
+
+// Type returns the name of the type of value accepted by f, for use by pflag.
+func (f *Flag[T]) Type() string {
+	return f.kind
+}
+
+// Append inserts the single element value into the underlying Set, without
+// splitting on commas. It implements pflag.SliceValue.
+func (f *Flag[T]) Append(value string) error {
+	item, err := f.parse(value)
+	if err != nil {
+		return err
+	}
+	f.set.Insert(item)
+	return nil
+}
+
+// Replace replaces the elements of the underlying Set with values. It
+// implements pflag.SliceValue.
+func (f *Flag[T]) Replace(values []string) error {
+	items, err := f.parseAll(values)
+	if err != nil {
+		return err
+	}
+	f.set.RemoveFunc(func(T) bool { return true })
+	f.set.InsertSlice(items)
+	return nil
+}
+
+// GetSlice returns the elements of the underlying Set as strings, sorted by
+// their lexical order. It implements pflag.SliceValue.
+func (f *Flag[T]) GetSlice() []string {
+	result := make([]string, 0, f.set.Size())
+	f.set.ForEach(func(item T) bool {
+		result = append(result, fmt.Sprintf("%v", item))
+		return true
+	})
+	sort.Strings(result)
+	return result
+}
+
+// parseAll parses each non-empty element of values, after trimming whitespace.
+func (f *Flag[T]) parseAll(values []string) ([]T, error) {
+	items := make([]T, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		item, err := f.parse(value)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
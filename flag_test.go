@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestStringFlag(t *testing.T) {
+	t.Run("repeated", func(t *testing.T) {
+		tags := New[string](0)
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.Var(StringFlag(tags), "tag", "tags to apply")
+
+		err := fs.Parse([]string{"--tag", "x", "--tag", "y,z", "-tag=x", "--tag", " w , ,"})
+		must.NoError(t, err)
+		must.True(t, tags.EqualSlice([]string{"w", "x", "y", "z"}))
+	})
+
+	t.Run("string", func(t *testing.T) {
+		f := StringFlag(Of("b", "a"))
+		must.Eq(t, "[a b]", f.String())
+		must.Eq(t, "stringSet", f.Type())
+		must.Eq(t, "[]", new(Flag[string]).String())
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		// PrintDefaults calls String on a zero value Flag
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.Var(StringFlag(New[string](0)), "tag", "tags to apply")
+		fs.PrintDefaults()
+	})
+}
+
+func TestIntFlag(t *testing.T) {
+	t.Run("repeated", func(t *testing.T) {
+		ports := New[int](0)
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.Var(IntFlag(ports), "port", "ports to open")
+
+		err := fs.Parse([]string{"--port", "80", "--port", "443,0x1f90"})
+		must.NoError(t, err)
+		must.True(t, ports.EqualSlice([]int{80, 443, 8080}))
+		must.Eq(t, "intSet", IntFlag(ports).Type())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ports := New[int](0)
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.Var(IntFlag(ports), "port", "ports to open")
+
+		err := fs.Parse([]string{"--port", "80,http"})
+		must.ErrorContains(t, err, `invalid value "80,http" for flag -port`)
+		must.Empty(t, ports)
+	})
+}
+
+func TestFlag_SliceValue(t *testing.T) {
+	s := New[int](0)
+	f := IntFlag(s)
+
+	must.NoError(t, f.Append("10"))
+	must.NoError(t, f.Append("9"))
+	must.Error(t, f.Append("1,2"))
+	must.Eq(t, []string{"10", "9"}, f.GetSlice())
+
+	must.NoError(t, f.Replace([]string{"1", "2"}))
+	must.True(t, s.EqualSlice([]int{1, 2}))
+
+	must.Error(t, f.Replace([]string{"3", "x"}))
+	must.True(t, s.EqualSlice([]int{1, 2}))
+}
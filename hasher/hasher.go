@@ -0,0 +1,210 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package hasher provides ready-made hash functions for use with HashSet, such
+// as with set.NewHashSetFunc.
+//
+// The FNV-1a and XXH64 functions hash strings and byte slices without
+// allocating. Fields combines the hash of several struct fields into one.
+//
+// The FNV-1a and XXH64 functions are not cryptographically secure, and are
+// deterministic across processes. For elements from untrusted input, prefer the
+// randomly seeded MapHash.
+package hasher
+
+import (
+	"math"
+	"math/bits"
+)
+
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// FNV64a returns the 64-bit FNV-1a hash of s.
+//
+// Equivalent to hashing s with hash/fnv.New64a, without allocating.
+func FNV64a(s string) uint64 {
+	return fnv64a(fnvOffset64, s)
+}
+
+// FNV64aBytes returns the 64-bit FNV-1a hash of b.
+//
+// Equivalent to hashing b with hash/fnv.New64a, without allocating.
+func FNV64aBytes(b []byte) uint64 {
+	return fnv64a(fnvOffset64, b)
+}
+
+func fnv64a[B ~string | ~[]byte](sum uint64, b B) uint64 {
+	for i := 0; i < len(b); i++ {
+		sum ^= uint64(b[i])
+		sum *= fnvPrime64
+	}
+	return sum
+}
+
+// Fields computes an FNV-1a hash over a sequence of fields, e.g. the fields of
+// a struct that together identify it. Each method returns the updated Fields,
+// so calls may be chained.
+//
+//	func (e *employee) Hash() uint64 {
+//		return hasher.NewFields().String(e.name).Int(e.id).Sum()
+//	}
+//
+// Variable length fields are hashed along with their length, so that adjacent
+// fields cannot be confused with one another (e.g. "ab", "c" and "a", "bc").
+type Fields struct {
+	sum uint64
+}
+
+// NewFields creates a Fields with no fields hashed yet.
+func NewFields() Fields {
+	return Fields{sum: fnvOffset64}
+}
+
+// String adds s to f.
+func (f Fields) String(s string) Fields {
+	f = f.Int(len(s))
+	f.sum = fnv64a(f.sum, s)
+	return f
+}
+
+// Bytes adds b to f.
+func (f Fields) Bytes(b []byte) Fields {
+	f = f.Int(len(b))
+	f.sum = fnv64a(f.sum, b)
+	return f
+}
+
+// Uint64 adds u to f.
+func (f Fields) Uint64(u uint64) Fields {
+	for i := 0; i < 8; i++ {
+		f.sum ^= u & 0xff
+		f.sum *= fnvPrime64
+		u >>= 8
+	}
+	return f
+}
+
+// Int adds i to f.
+func (f Fields) Int(i int) Fields {
+	return f.Uint64(uint64(i))
+}
+
+// Float64 adds x to f.
+func (f Fields) Float64(x float64) Fields {
+	return f.Uint64(math.Float64bits(x))
+}
+
+// Bool adds b to f.
+func (f Fields) Bool(b bool) Fields {
+	if b {
+		return f.Uint64(1)
+	}
+	return f.Uint64(0)
+}
+
+// Sum returns the hash of the fields added to f.
+func (f Fields) Sum() uint64 {
+	return f.sum
+}
+
+const (
+	xxPrime1 uint64 = 11400714785074694791
+	xxPrime2 uint64 = 14029467366897019727
+	xxPrime3 uint64 = 1609587929392839161
+	xxPrime4 uint64 = 9650029242287828579
+	xxPrime5 uint64 = 2870177450012600261
+)
+
+// XXH64 returns the 64-bit xxHash (XXH64) of s, using a seed of zero.
+//
+// XXH64 is considerably faster than FNV64a for long strings.
+func XXH64(s string) uint64 {
+	return xxh64(s)
+}
+
+// XXH64Bytes returns the 64-bit xxHash (XXH64) of b, using a seed of zero.
+//
+// XXH64Bytes is considerably faster than FNV64aBytes for long byte slices.
+func XXH64Bytes(b []byte) uint64 {
+	return xxh64(b)
+}
+
+func xxh64[B ~string | ~[]byte](b B) uint64 {
+	n := len(b)
+	i := 0
+
+	var h uint64
+	if n >= 32 {
+		// the initial accumulators for a seed of zero, where the arithmetic
+		// is intended to wrap around
+		p1, p2 := xxPrime1, xxPrime2
+		v1 := p1 + p2
+		v2 := p2
+		v3 := uint64(0)
+		v4 := -p1
+		for ; i+32 <= n; i += 32 {
+			v1 = xxRound(v1, le64(b, i))
+			v2 = xxRound(v2, le64(b, i+8))
+			v3 = xxRound(v3, le64(b, i+16))
+			v4 = xxRound(v4, le64(b, i+24))
+		}
+		h = bits.RotateLeft64(v1, 1) + bits.RotateLeft64(v2, 7) +
+			bits.RotateLeft64(v3, 12) + bits.RotateLeft64(v4, 18)
+		h = xxMerge(h, v1)
+		h = xxMerge(h, v2)
+		h = xxMerge(h, v3)
+		h = xxMerge(h, v4)
+	} else {
+		h = xxPrime5
+	}
+
+	h += uint64(n)
+
+	for ; i+8 <= n; i += 8 {
+		h ^= xxRound(0, le64(b, i))
+		h = bits.RotateLeft64(h, 27)*xxPrime1 + xxPrime4
+	}
+	if i+4 <= n {
+		h ^= uint64(le32(b, i)) * xxPrime1
+		h = bits.RotateLeft64(h, 23)*xxPrime2 + xxPrime3
+		i += 4
+	}
+	for ; i < n; i++ {
+		h ^= uint64(b[i]) * xxPrime5
+		h = bits.RotateLeft64(h, 11) * xxPrime1
+	}
+
+	h ^= h >> 33
+	h *= xxPrime2
+	h ^= h >> 29
+	h *= xxPrime3
+	h ^= h >> 32
+	return h
+}
+
+func xxRound(acc, input uint64) uint64 {
+	acc += input * xxPrime2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * xxPrime1
+}
+
+func xxMerge(acc, v uint64) uint64 {
+	acc ^= xxRound(0, v)
+	return acc*xxPrime1 + xxPrime4
+}
+
+// le64 reads the little endian uint64 at offset i of b.
+func le64[B ~string | ~[]byte](b B, i int) uint64 {
+	_ = b[i+7]
+	return uint64(b[i]) | uint64(b[i+1])<<8 | uint64(b[i+2])<<16 | uint64(b[i+3])<<24 |
+		uint64(b[i+4])<<32 | uint64(b[i+5])<<40 | uint64(b[i+6])<<48 | uint64(b[i+7])<<56
+}
+
+// le32 reads the little endian uint32 at offset i of b.
+func le32[B ~string | ~[]byte](b B, i int) uint32 {
+	_ = b[i+3]
+	return uint32(b[i]) | uint32(b[i+1])<<8 | uint32(b[i+2])<<16 | uint32(b[i+3])<<24
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hasher
+
+import (
+	"hash/fnv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-set"
+	"github.com/shoenig/test/must"
+)
+
+var inputs = []string{
+	"",
+	"a",
+	"abc",
+	"hello, world",
+	"Nobody inspects the spammish repetition",
+	strings.Repeat("0123456789", 10),
+}
+
+func TestFNV64a(t *testing.T) {
+	for _, input := range inputs {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(input))
+		exp := h.Sum64()
+		must.Eq(t, exp, FNV64a(input))
+		must.Eq(t, exp, FNV64aBytes([]byte(input)))
+	}
+}
+
+func TestXXH64(t *testing.T) {
+	cases := []struct {
+		input string
+		exp   uint64
+	}{
+		{input: "", exp: 0xef46db3751d8e999},
+		{input: "abc", exp: 0x44bc2cf5ad770999},
+		{input: "Nobody inspects the spammish repetition", exp: 0xfbcea83c8a378bf1},
+	}
+	for _, tc := range cases {
+		must.Eq(t, tc.exp, XXH64(tc.input), must.Sprintf("input %q", tc.input))
+		must.Eq(t, tc.exp, XXH64Bytes([]byte(tc.input)), must.Sprintf("input %q", tc.input))
+	}
+}
+
+func TestXXH64_lengths(t *testing.T) {
+	// every length up to and beyond two stripes hashes distinctly
+	seen := set.New[uint64](100)
+	long := strings.Repeat("x", 100)
+	for n := 0; n <= len(long); n++ {
+		must.True(t, seen.Insert(XXH64(long[:n])))
+		must.Eq(t, XXH64(long[:n]), XXH64Bytes([]byte(long[:n])))
+	}
+}
+
+func TestFields(t *testing.T) {
+	t.Run("deterministic", func(t *testing.T) {
+		a := NewFields().String("alice").Int(42).Bool(true).Sum()
+		b := NewFields().String("alice").Int(42).Bool(true).Sum()
+		must.Eq(t, a, b)
+	})
+
+	t.Run("order", func(t *testing.T) {
+		a := NewFields().Int(1).Int(2).Sum()
+		b := NewFields().Int(2).Int(1).Sum()
+		must.NotEq(t, a, b)
+	})
+
+	t.Run("boundaries", func(t *testing.T) {
+		a := NewFields().String("ab").String("c").Sum()
+		b := NewFields().String("a").String("bc").Sum()
+		must.NotEq(t, a, b)
+
+		c := NewFields().Bytes([]byte("ab")).Bytes(nil).Sum()
+		d := NewFields().Bytes(nil).Bytes([]byte("ab")).Sum()
+		must.NotEq(t, c, d)
+	})
+
+	t.Run("types", func(t *testing.T) {
+		must.NotEq(t, NewFields().Sum(), NewFields().Bool(false).Sum())
+		must.NotEq(t, NewFields().Float64(1).Sum(), NewFields().Float64(-1).Sum())
+		must.Eq(t, NewFields().Int(7).Sum(), NewFields().Uint64(7).Sum())
+	})
+}
+
+type employee struct {
+	name  string
+	id    int
+	roles []string // not comparable
+}
+
+func TestHashSet(t *testing.T) {
+	t.Run("strings", func(t *testing.T) {
+		s := set.NewHashSetFunc(XXH64, 10)
+		must.True(t, s.InsertMany("a", "b", "c"))
+		must.False(t, s.Insert("b"))
+		must.Size(t, 3, s)
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		s := set.NewHashSetFunc(func(e *employee) uint64 {
+			return NewFields().String(e.name).Int(e.id).Sum()
+		}, 10)
+		must.True(t, s.Insert(&employee{name: "armon", id: 2, roles: []string{"founder"}}))
+		must.True(t, s.Insert(&employee{name: "armon", id: 3}))
+		must.False(t, s.Insert(&employee{name: "armon", id: 2}))
+		must.Size(t, 2, s)
+	})
+}
+
+func BenchmarkFNV64a(b *testing.B) {
+	input := strings.Repeat("0123456789", 10)
+	for i := 0; i < b.N; i++ {
+		_ = FNV64a(input)
+	}
+}
+
+func BenchmarkXXH64(b *testing.B) {
+	input := strings.Repeat("0123456789", 10)
+	for i := 0; i < b.N; i++ {
+		_ = XXH64(input)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hasher
+
+import (
+	"hash/maphash"
+)
+
+// MapHash hashes strings and byte slices using hash/maphash with a random
+// seed, so that hash values differ between processes and between MapHash
+// values.
+//
+// Randomized hashing protects against hash flooding, where untrusted input is
+// crafted so that many elements share the same hash value. MapHash methods are
+// suitable for use with set.NewHashSetEqualFunc, which tolerates collisions.
+//
+// Because hash values depend on the seed, every set whose elements are compared
+// with one another (e.g. by Union) must be created using the same MapHash.
+type MapHash struct {
+	seed maphash.Seed
+}
+
+// NewMapHash creates a MapHash with a new random seed.
+func NewMapHash() MapHash {
+	return MapHash{seed: maphash.MakeSeed()}
+}
+
+// String returns the hash of s.
+func (m MapHash) String(s string) uint64 {
+	return maphash.String(m.seed, s)
+}
+
+// Bytes returns the hash of b.
+func (m MapHash) Bytes(b []byte) uint64 {
+	return maphash.Bytes(m.seed, b)
+}
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hasher
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-set"
+	"github.com/shoenig/test/must"
+)
+
+func TestMapHash(t *testing.T) {
+	t.Run("consistent", func(t *testing.T) {
+		m := NewMapHash()
+		must.Eq(t, m.String("abc"), m.String("abc"))
+		must.Eq(t, m.String("abc"), m.Bytes([]byte("abc")))
+		must.NotEq(t, m.String("abc"), m.String("abd"))
+	})
+
+	t.Run("seeded", func(t *testing.T) {
+		a := NewMapHash()
+		b := NewMapHash()
+		must.NotEq(t, a.String("abc"), b.String("abc"))
+	})
+
+	t.Run("hash set", func(t *testing.T) {
+		m := NewMapHash()
+		equal := func(a, b string) bool { return a == b }
+		s1 := set.NewHashSetEqualFunc(m.String, equal, 0)
+		s1.InsertMany("a", "b", "c")
+		s2 := set.NewHashSetEqualFunc(m.String, equal, 0)
+		s2.InsertMany("c", "d")
+		must.True(t, s1.Union(s2).EqualSlice([]string{"a", "b", "c", "d"}))
+		must.True(t, s1.Intersect(s2).EqualSlice([]string{"c"}))
+	})
+}
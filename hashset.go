@@ -5,6 +5,9 @@ package set
 
 import (
 	"fmt"
+	"iter"
+	"math/rand"
+	"slices"
 	"sort"
 )
 
@@ -12,9 +15,9 @@ import (
 //
 // A Hash could be string-like or int-like. A string hash could be something like
 // and md5, sha1, or GoString() representation of a type. An int hash could be
-// something like the prime multiple hash code of a type.
+// something like the prime multiple hash code of a type, or a uint64 digest.
 type Hash interface {
-	~string | ~int | ~uint | ~int64 | ~uint64 | ~int32 | ~uint32 | ~int16 | ~uint16 | ~int8 | ~uint8
+	~string | ~int | ~uint | ~int64 | ~uint64 | ~int32 | ~uint32 | ~int16 | ~uint16 | ~int8 | ~uint8 | ~uintptr
 }
 
 // HashFunc is a generic type constraint for any type that implements a Hash()
@@ -23,10 +26,43 @@ type HashFunc[H Hash] interface {
 	Hash() H
 }
 
+// HashEqualFunc is a generic type constraint for any type that implements a
+// Hash() method with a Hash return type, and an Equal() method for comparing
+// elements whose hash values collide.
+type HashEqualFunc[T any, H Hash] interface {
+	HashFunc[H]
+	Equal(T) bool
+}
+
 // HashSet is a generic implementation of the mathematical data structure, oriented
 // around the use of a HashFunc to make hash values from other types.
-type HashSet[T HashFunc[H], H Hash] struct {
+//
+// The hash function is typically the Hash() method of T, as used by NewHashSet.
+// A HashSet created by NewHashSetFunc may instead hold elements of any type, by
+// providing the hash function directly.
+//
+// By default elements with the same hash value are considered equal, so a
+// HashSet holds at most one element per hash value. A HashSet created by
+// NewHashSetEqual or NewHashSetEqualFunc instead keeps distinct elements whose
+// hash values collide, disambiguating them with an equality function.
+//
+// Operations combining two HashSets (e.g. Union) assume both sets use the same
+// hash and equality functions; sets derived from s use the functions of s.
+type HashSet[T any, H Hash] struct {
+	hash  func(T) H
+	equal func(T, T) bool
+
+	// items contains the first element inserted for each hash value
 	items map[H]T
+
+	// overflow contains any further distinct elements with the same hash value
+	// as an element of items, and is only used when equal is set
+	overflow   map[H][]T
+	collisions int
+
+	// capacity is the number of elements the underlying storage has been
+	// sized for, since it was last rebuilt
+	capacity int
 }
 
 // NewHashSet creates a HashSet with underlying capacity of size.
@@ -37,12 +73,180 @@ type HashSet[T HashFunc[H], H Hash] struct {
 // T must implement HashFunc[H], where H is of Hash type. This allows custom types
 // that include non-comparable fields to provide their own hash algorithm.
 func NewHashSet[T HashFunc[H], H Hash](size int) *HashSet[T, H] {
+	return NewHashSetFunc(func(item T) H {
+		return item.Hash()
+	}, size)
+}
+
+// NewHashSetFunc creates a HashSet with underlying capacity of size, using hash
+// to make hash values from elements.
+//
+// Unlike NewHashSet, T may be any type, including types from other packages
+// that do not implement HashFunc[H].
+func NewHashSetFunc[T any, H Hash](hash func(T) H, size int) *HashSet[T, H] {
+	return NewHashSetEqualFunc(hash, nil, size)
+}
+
+// NewHashSetEqual creates a HashSet with underlying capacity of size, which
+// keeps distinct elements whose hash values collide.
+//
+// T must implement HashEqualFunc[T, H]. Elements with the same hash value are
+// compared using their Equal() method.
+func NewHashSetEqual[T HashEqualFunc[T, H], H Hash](size int) *HashSet[T, H] {
+	return NewHashSetEqualFunc(func(item T) H {
+		return item.Hash()
+	}, func(a, b T) bool {
+		return a.Equal(b)
+	}, size)
+}
+
+// NewHashSetEqualFunc creates a HashSet with underlying capacity of size, using
+// hash to make hash values from elements, and equal to compare elements whose
+// hash values collide.
+//
+// If equal is nil, elements with the same hash value are considered equal, as
+// with NewHashSetFunc.
+func NewHashSetEqualFunc[T any, H Hash](hash func(T) H, equal func(T, T) bool, size int) *HashSet[T, H] {
 	return &HashSet[T, H]{
-		items: make(map[H]T, max(0, size)),
+		hash:     hash,
+		equal:    equal,
+		items:    make(map[H]T, max(0, size)),
+		capacity: max(0, size),
 	}
 }
 
-// HashSetFrom creates a new HashSet containing each item in items.
+// empty creates a new HashSet with underlying capacity of size, using the same
+// hash and equality functions as s.
+func (s *HashSet[T, H]) empty(size int) *HashSet[T, H] {
+	return NewHashSetEqualFunc(s.hash, s.equal, size)
+}
+
+// add inserts item with hash value key into s, returning whether s was modified.
+func (s *HashSet[T, H]) add(key H, item T) bool {
+	existing, exists := s.items[key]
+	if !exists {
+		s.items[key] = item
+		s.grew()
+		if debug {
+			s.verify()
+		}
+		return true
+	}
+	if s.equal == nil || s.equal(existing, item) {
+		return false
+	}
+	for _, other := range s.overflow[key] {
+		if s.equal(other, item) {
+			return false
+		}
+	}
+	if s.overflow == nil {
+		s.overflow = make(map[H][]T)
+	}
+	s.overflow[key] = append(s.overflow[key], item)
+	s.collisions++
+	s.grew()
+	if debug {
+		s.verify()
+	}
+	return true
+}
+
+// grew records that the size of s may have exceeded its capacity.
+func (s *HashSet[T, H]) grew() {
+	if size := s.Size(); size > s.capacity {
+		s.capacity = size
+	}
+}
+
+// lookup returns whether item with hash value key is present in s.
+func (s *HashSet[T, H]) lookup(key H, item T) bool {
+	_, exists := s.find(key, item)
+	return exists
+}
+
+// find returns the element of s equal to item with hash value key, if any.
+func (s *HashSet[T, H]) find(key H, item T) (T, bool) {
+	existing, exists := s.items[key]
+	if exists && (s.equal == nil || s.equal(existing, item)) {
+		return existing, true
+	}
+	if exists {
+		for _, other := range s.overflow[key] {
+			if s.equal(other, item) {
+				return other, true
+			}
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// del removes item with hash value key from s, returning whether s was modified.
+func (s *HashSet[T, H]) del(key H, item T) bool {
+	existing, exists := s.items[key]
+	if !exists {
+		return false
+	}
+	bucket := s.overflow[key]
+	if s.equal == nil || s.equal(existing, item) {
+		if len(bucket) == 0 {
+			delete(s.items, key)
+		} else {
+			// promote the first overflow element into items
+			s.items[key] = bucket[0]
+			s.unlink(key, bucket, 0)
+		}
+		if debug {
+			s.verify()
+		}
+		return true
+	}
+	for i, other := range bucket {
+		if s.equal(other, item) {
+			s.unlink(key, bucket, i)
+			if debug {
+				s.verify()
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// unlink removes the i'th element of the overflow bucket for key.
+func (s *HashSet[T, H]) unlink(key H, bucket []T, i int) {
+	s.collisions--
+	if len(bucket) == 1 {
+		delete(s.overflow, key)
+		return
+	}
+	s.overflow[key] = slices.Delete(bucket, i, i+1)
+}
+
+// each calls visit with the hash value and element of each element of s, in no
+// particular order. Iteration stops early if visit returns false, in which case
+// each also returns false.
+func (s *HashSet[T, H]) each(visit func(key H, item T) bool) bool {
+	for key, item := range s.items {
+		if !visit(key, item) {
+			return false
+		}
+		if s.collisions == 0 {
+			continue
+		}
+		for _, other := range s.overflow[key] {
+			if !visit(key, other) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// HashSetFrom creates a new HashSet containing each item in items, sized to
+// hold items without growing. To leave room for more elements, create the set
+// with NewHashSet and insert items with InsertSlice instead.
 //
 // T must implement HashFunc[H], where H is of type Hash. This allows custom types
 // that include non-comparable fields to provide their own hash algorithm.
@@ -52,16 +256,55 @@ func HashSetFrom[T HashFunc[H], H Hash](items []T) *HashSet[T, H] {
 	return s
 }
 
+// HashSetOf creates a new HashSet containing each item passed as an argument.
+//
+// T must implement HashFunc[H], where H is of type Hash. This allows custom types
+// that include non-comparable fields to provide their own hash algorithm.
+func HashSetOf[T HashFunc[H], H Hash](items ...T) *HashSet[T, H] {
+	return HashSetFrom[T, H](items)
+}
+
+// HashSetCollect creates a new HashSet containing each item produced by seq.
+//
+// T must implement HashFunc[H], where H is of type Hash. This allows custom types
+// that include non-comparable fields to provide their own hash algorithm.
+func HashSetCollect[T HashFunc[H], H Hash](seq iter.Seq[T]) *HashSet[T, H] {
+	s := NewHashSet[T, H](0)
+	for item := range seq {
+		s.Insert(item)
+	}
+	return s
+}
+
 // Insert item into s.
 //
 // Return true if s was modified (item was not already in s), false otherwise.
 func (s *HashSet[T, H]) Insert(item T) bool {
-	key := item.Hash()
-	if _, exists := s.items[key]; exists {
+	return s.add(s.hash(item), item)
+}
+
+// InsertOrReplace inserts item into s, replacing the element of s equal to item
+// if there is one. Unlike Insert, the stored element is always updated to item,
+// which is useful when elements with the same hash may differ in other fields.
+//
+// Return true if item was not already in s, false if an element was replaced.
+func (s *HashSet[T, H]) InsertOrReplace(item T) bool {
+	key := s.hash(item)
+	existing, exists := s.items[key]
+	if exists && (s.equal == nil || s.equal(existing, item)) {
+		s.items[key] = item
 		return false
 	}
-	s.items[key] = item
-	return true
+	if exists {
+		bucket := s.overflow[key]
+		for i, other := range bucket {
+			if s.equal(other, item) {
+				bucket[i] = item
+				return false
+			}
+		}
+	}
+	return s.add(key, item)
 }
 
 // InsertAll will insert each item in items into s.
@@ -86,17 +329,24 @@ func (s *HashSet[T, H]) InsertSlice(items []T) bool {
 	return modified
 }
 
+// InsertMany will insert each item passed as an argument into s.
+//
+// Return true if s was modified (at least one item was not already in s), false otherwise.
+func (s *HashSet[T, H]) InsertMany(items ...T) bool {
+	return s.InsertSlice(items)
+}
+
 // InsertSet will insert each element of o into s.
 //
 // Return true if s was modified (at least one item of o was not already in s), false otherwise.
 func (s *HashSet[T, H]) InsertSet(o *HashSet[T, H]) bool {
 	modified := false
-	for key, value := range o.items {
-		if _, exists := s.items[key]; !exists {
+	o.each(func(key H, item T) bool {
+		if s.add(key, item) {
 			modified = true
 		}
-		s.items[key] = value
-	}
+		return true
+	})
 	return modified
 }
 
@@ -104,12 +354,7 @@ func (s *HashSet[T, H]) InsertSet(o *HashSet[T, H]) bool {
 //
 // Return true if s was modified (item was present), false otherwise.
 func (s *HashSet[T, H]) Remove(item T) bool {
-	key := item.Hash()
-	if _, exists := s.items[key]; !exists {
-		return false
-	}
-	delete(s.items, key)
-	return true
+	return s.del(s.hash(item), item)
 }
 
 // RemoveAll will remove each item in items from s.
@@ -134,45 +379,89 @@ func (s *HashSet[T, H]) RemoveSlice(items []T) bool {
 	return modified
 }
 
+// RemoveMany will remove each item passed as an argument from s.
+//
+// Return true if s was modified (any item was present), false otherwise.
+func (s *HashSet[T, H]) RemoveMany(items ...T) bool {
+	return s.RemoveSlice(items)
+}
+
 // RemoveSet will remove each element of o from s.
 //
 // Return true if s was modified (any item of o was present in s), false otherwise.
 func (s *HashSet[T, H]) RemoveSet(o *HashSet[T, H]) bool {
 	modified := false
-	for key := range o.items {
-		if _, exists := s.items[key]; exists {
+	o.each(func(key H, item T) bool {
+		if s.del(key, item) {
 			modified = true
-			delete(s.items, key)
 		}
-	}
+		return true
+	})
 	return modified
 }
 
+// RetainSet will remove each element of s that is not in o, leaving s as the
+// intersection of s and o.
+//
+// Return true if s was modified (any item of s was not present in o), false otherwise.
+func (s *HashSet[T, H]) RetainSet(o *HashSet[T, H]) bool {
+	return s.RemoveFunc(func(item T) bool {
+		return !o.Contains(item)
+	})
+}
+
 // RemoveFunc will remove each element from s that satisfies condition f.
 //
 // Return true if s was modified, false otherwise.
 func (s *HashSet[T, H]) RemoveFunc(f func(item T) bool) bool {
-	modified := false
-	for _, item := range s.items {
+	type entry struct {
+		key  H
+		item T
+	}
+	var remove []entry
+	s.each(func(key H, item T) bool {
 		if applies := f(item); applies {
-			s.Remove(item)
-			modified = true
+			remove = append(remove, entry{key: key, item: item})
 		}
+		return true
+	})
+	for _, e := range remove {
+		s.del(e.key, e.item)
 	}
-	return modified
+	return len(remove) > 0
+}
+
+// Filter returns a new set containing each element of s that satisfies
+// condition f. s is not modified.
+func (s *HashSet[T, H]) Filter(f func(item T) bool) *HashSet[T, H] {
+	result := s.empty(0)
+	s.each(func(key H, item T) bool {
+		if f(item) {
+			result.add(key, item)
+		}
+		return true
+	})
+	return result
 }
 
 // Contains returns whether item is present in s.
 func (s *HashSet[T, H]) Contains(item T) bool {
-	_, exists := s.items[item.Hash()]
-	return exists
+	return s.lookup(s.hash(item), item)
+}
+
+// Get returns the element of s equal to item, and true. Elements are equal if
+// they have the same hash value (and satisfy the equality function of s, if it
+// has one). If there is no such element the zero value of T and false are
+// returned.
+//
+// The element returned is the one stored in s, which may differ from item in
+// fields not covered by the hash.
+func (s *HashSet[T, H]) Get(item T) (T, bool) {
+	return s.find(s.hash(item), item)
 }
 
 // ContainsAll returns whether s contains at least every item in items.
-func (s *HashSet[T, H]) ContainsAll(items []T) bool {
-	if len(s.items) < len(items) {
-		return false
-	}
+func (s *HashSet[T, H]) ContainsAll(items ...T) bool {
 	for _, item := range items {
 		if !s.Contains(item) {
 			return false
@@ -181,31 +470,74 @@ func (s *HashSet[T, H]) ContainsAll(items []T) bool {
 	return true
 }
 
+// ContainsAny returns whether s contains at least one of the items passed as
+// an argument.
+func (s *HashSet[T, H]) ContainsAny(items ...T) bool {
+	for _, item := range items {
+		if s.Contains(item) {
+			return true
+		}
+	}
+	return false
+}
+
 // ContainsSlice returns whether s contains the same set of of elements
 // that are in items. The elements of items may contain duplicates.
 //
 // If the slice is known to be set-like (no duplicates), EqualSlice provides
 // a more efficient implementation.
 func (s *HashSet[T, H]) ContainsSlice(items []T) bool {
-	return s.Equal(HashSetFrom[T, H](items))
+	o := s.empty(len(items))
+	o.InsertSlice(items)
+	return s.Equal(o)
+}
+
+// ContainsFunc returns whether s contains at least one element that satisfies
+// condition f.
+func (s *HashSet[T, H]) ContainsFunc(f func(item T) bool) bool {
+	return !s.each(func(_ H, item T) bool {
+		return !f(item)
+	})
 }
 
 // Subset returns whether o is a subset of s.
 func (s *HashSet[T, H]) Subset(o *HashSet[T, H]) bool {
-	if len(s.items) < len(o.items) {
+	if s.Size() < o.Size() {
 		return false
 	}
-	for _, item := range o.items {
-		if !s.Contains(item) {
-			return false
-		}
+	return o.each(s.lookup)
+}
+
+// ProperSubset returns whether o is a proper subset of s, that is whether o is
+// a subset of s and s contains at least one element not in o.
+func (s *HashSet[T, H]) ProperSubset(o *HashSet[T, H]) bool {
+	if s.Size() <= o.Size() {
+		return false
 	}
-	return true
+	return s.Subset(o)
+}
+
+// Intersects returns whether s and o have at least one element in common.
+//
+// Returns as soon as a common element is found.
+func (s *HashSet[T, H]) Intersects(o *HashSet[T, H]) bool {
+	small, big := s, o
+	if s.Size() > o.Size() {
+		small, big = o, s
+	}
+	return !small.each(func(key H, item T) bool {
+		return !big.lookup(key, item)
+	})
+}
+
+// Disjoint returns whether s and o have no elements in common.
+func (s *HashSet[T, H]) Disjoint(o *HashSet[T, H]) bool {
+	return !s.Intersects(o)
 }
 
 // Size returns the cardinality of s.
 func (s *HashSet[T, H]) Size() int {
-	return len(s.items)
+	return len(s.items) + s.collisions
 }
 
 // Empty returns true if s contains no elements, false otherwise.
@@ -213,50 +545,72 @@ func (s *HashSet[T, H]) Empty() bool {
 	return s.Size() == 0
 }
 
-// Union returns a set that contains all elements of s and o combined.
+// Union returns a set that contains all elements of s and o combined. Where s
+// and o contain equal elements, the element of o is kept.
 func (s *HashSet[T, H]) Union(o *HashSet[T, H]) *HashSet[T, H] {
-	result := NewHashSet[T, H](s.Size())
-	for key, item := range s.items {
-		result.items[key] = item
-	}
-	for key, item := range o.items {
-		result.items[key] = item
-	}
+	result := s.empty(s.Size() + o.Size())
+	result.InsertSet(o)
+	result.InsertSet(s)
 	return result
 }
 
 // Difference returns a set that contains elements of s that are not in o.
 func (s *HashSet[T, H]) Difference(o *HashSet[T, H]) *HashSet[T, H] {
-	result := NewHashSet[T, H](max(0, s.Size()-o.Size()))
-	for key, item := range s.items {
-		if _, exists := o.items[key]; !exists {
-			result.items[key] = item
+	result := s.empty(s.Size())
+	s.each(func(key H, item T) bool {
+		if !o.lookup(key, item) {
+			result.add(key, item)
 		}
-	}
+		return true
+	})
 	return result
 }
 
 // Intersect returns a set that contains elements that are present in both s and o.
 func (s *HashSet[T, H]) Intersect(o *HashSet[T, H]) *HashSet[T, H] {
-	result := NewHashSet[T, H](0)
 	big, small := s, o
 	if s.Size() < o.Size() {
 		big, small = o, s
 	}
-	for _, item := range small.items {
-		if big.Contains(item) {
-			result.Insert(item)
+	result := s.empty(small.Size())
+	small.each(func(key H, item T) bool {
+		if big.lookup(key, item) {
+			result.add(key, item)
 		}
-	}
+		return true
+	})
+	return result
+}
+
+// SymmetricDifference returns a set that contains elements that are present in
+// either s or o, but not in both.
+func (s *HashSet[T, H]) SymmetricDifference(o *HashSet[T, H]) *HashSet[T, H] {
+	result := s.empty(s.Size() + o.Size())
+	s.each(func(key H, item T) bool {
+		if !o.lookup(key, item) {
+			result.add(key, item)
+		}
+		return true
+	})
+	o.each(func(key H, item T) bool {
+		if !s.lookup(key, item) {
+			result.add(key, item)
+		}
+		return true
+	})
 	return result
 }
 
 // Copy creates a shallow copy of s.
+//
+// The copy is independent of s, sized to hold the elements of s, and uses the
+// same hash and equality functions as s.
 func (s *HashSet[T, H]) Copy() *HashSet[T, H] {
-	result := NewHashSet[T, H](s.Size())
-	for key, item := range s.items {
-		result.items[key] = item
-	}
+	result := s.empty(s.Size())
+	s.each(func(key H, item T) bool {
+		result.add(key, item)
+		return true
+	})
 	return result
 }
 
@@ -265,9 +619,140 @@ func (s *HashSet[T, H]) Copy() *HashSet[T, H] {
 // The result is not ordered.
 func (s *HashSet[T, H]) Slice() []T {
 	result := make([]T, 0, s.Size())
-	for _, item := range s.items {
+	s.each(func(_ H, item T) bool {
 		result = append(result, item)
+		return true
+	})
+	return result
+}
+
+// ForEach calls visit for each element of s, in no particular order. Iteration
+// stops early if visit returns false.
+func (s *HashSet[T, H]) ForEach(visit func(item T) bool) {
+	s.each(func(_ H, item T) bool {
+		return visit(item)
+	})
+}
+
+// Capacity returns the number of elements the underlying storage of s has been
+// sized for. This is the larger of the size s was created or last rebuilt with,
+// and the most elements s has held since.
+//
+// The builtin map never shrinks, so a Capacity much larger than Size indicates
+// memory that Compact would release.
+func (s *HashSet[T, H]) Capacity() int {
+	return s.capacity
+}
+
+// Grow increases the underlying capacity of s so that at least n more items
+// can be inserted without the set needing to grow again.
+//
+// The existing elements of s are copied into the resized storage, so Grow is
+// best used once before inserting a large batch of items.
+func (s *HashSet[T, H]) Grow(n int) {
+	if n <= 0 {
+		return
+	}
+	s.rebuild(s.Size() + n)
+}
+
+// Compact rebuilds the underlying storage of s sized to its current number of
+// elements.
+//
+// The builtin map never shrinks, so a set that once held many more elements
+// than it does now continues to hold on to that memory. Compact releases it.
+func (s *HashSet[T, H]) Compact() {
+	s.rebuild(s.Size())
+}
+
+// rebuild copies the elements of s into new storage sized for size elements.
+func (s *HashSet[T, H]) rebuild(size int) {
+	items := make(map[H]T, len(s.items)+size-s.Size())
+	for key, item := range s.items {
+		items[key] = item
+	}
+	s.items = items
+	if s.collisions > 0 {
+		overflow := make(map[H][]T, len(s.overflow))
+		for key, bucket := range s.overflow {
+			overflow[key] = slices.Clone(bucket)
+		}
+		s.overflow = overflow
+	} else {
+		s.overflow = nil
+	}
+	s.capacity = size
+	if debug {
+		s.verify()
 	}
+}
+
+// SortedSlice creates a copy of s as a slice, with elements sorted according
+// to compare.
+func (s *HashSet[T, H]) SortedSlice(compare Compare[T]) []T {
+	result := s.Slice()
+	sort.Slice(result, func(i, j int) bool {
+		return compare(result[i], result[j]) < 0
+	})
+	return result
+}
+
+// Min returns the smallest element of s according to compare, and true. If s
+// is empty the zero value of T and false are returned.
+//
+// Min is computed in a single pass over s, without sorting.
+func (s *HashSet[T, H]) Min(compare Compare[T]) (T, bool) {
+	return s.extreme(func(item, best T) bool {
+		return compare(item, best) < 0
+	})
+}
+
+// Max returns the largest element of s according to compare, and true. If s
+// is empty the zero value of T and false are returned.
+//
+// Max is computed in a single pass over s, without sorting.
+func (s *HashSet[T, H]) Max(compare Compare[T]) (T, bool) {
+	return s.extreme(func(item, best T) bool {
+		return compare(item, best) > 0
+	})
+}
+
+// extreme returns the element of s that is better than every other element.
+func (s *HashSet[T, H]) extreme(better func(item, best T) bool) (T, bool) {
+	var best T
+	found := false
+	s.each(func(_ H, item T) bool {
+		if !found || better(item, best) {
+			best = item
+			found = true
+		}
+		return true
+	})
+	return best, found
+}
+
+// Sample returns n distinct elements of s chosen uniformly at random using rng,
+// in no particular order. If n is greater than the size of s, every element
+// of s is returned. If rng is nil, the default source of package math/rand
+// is used.
+//
+// Sample makes a single pass over s and allocates only the result.
+func (s *HashSet[T, H]) Sample(n int, rng *rand.Rand) []T {
+	intn := rand.Intn
+	if rng != nil {
+		intn = rng.Intn
+	}
+	result := make([]T, 0, max(0, min(n, s.Size())))
+	i := 0
+	s.each(func(_ H, item T) bool {
+		if len(result) < n {
+			result = append(result, item)
+		} else if j := intn(i + 1); j < n {
+			result[j] = item
+		}
+		i++
+		return true
+	})
 	return result
 }
 
@@ -291,24 +776,34 @@ func (s *HashSet[T, H]) String() string {
 // into a string. The result contains elements sorted by their string order.
 func (s *HashSet[T, H]) StringFunc(f func(element T) string) string {
 	l := make([]string, 0, s.Size())
-	for _, item := range s.items {
+	s.each(func(_ H, item T) bool {
 		l = append(l, f(item))
-	}
+		return true
+	})
 	sort.Strings(l)
 	return fmt.Sprintf("%s", l)
 }
 
+// StringSorted creates a string representation of s, using "%v" printf formatting
+// to transform each element into a string. Unlike String, the result contains
+// elements sorted according to compare rather than by their lexical string order.
+func (s *HashSet[T, H]) StringSorted(compare Compare[T]) string {
+	l := make([]string, 0, s.Size())
+	for _, item := range s.SortedSlice(compare) {
+		l = append(l, fmt.Sprintf("%v", item))
+	}
+	return fmt.Sprintf("%s", l)
+}
+
 // Equal returns whether s and o contain the same elements.
+//
+// Elements are compared by hash value, and also by the equality function of o
+// if one was provided (see NewHashSetEqual).
 func (s *HashSet[T, H]) Equal(o *HashSet[T, H]) bool {
-	if len(s.items) != len(o.items) {
+	if s.Size() != o.Size() {
 		return false
 	}
-	for _, item := range s.items {
-		if !o.Contains(item) {
-			return false
-		}
-	}
-	return true
+	return s.each(o.lookup)
 }
 
 // EqualSlice returns whether s and items contain the same elements.
@@ -317,10 +812,24 @@ func (s *HashSet[T, H]) Equal(o *HashSet[T, H]) bool {
 // assumed that items is itself set-like. For comparing equality with
 // a slice that may contain duplicates, use ContainsSlice.
 func (s *HashSet[T, H]) EqualSlice(items []T) bool {
-	if len(s.items) != len(items) {
+	if s.Size() != len(items) {
 		return false
 	}
-	return s.ContainsAll(items)
+	return s.ContainsAll(items...)
+}
+
+// Fingerprint returns a hash of the elements of s that does not depend on the
+// order of iteration, computed from the hash value of each element. Sets
+// containing the same elements have the same fingerprint, while sets with
+// different elements have different fingerprints with high probability.
+//
+// Fingerprints are only comparable within a single process.
+func (s *HashSet[T, H]) Fingerprint() uint64 {
+	return fingerprint(func(visit func(key H) bool) {
+		s.each(func(key H, _ T) bool {
+			return visit(key)
+		})
+	})
 }
 
 // MarshalJSON implements the json.Marshaler interface.
@@ -329,6 +838,47 @@ func (s *HashSet[T, H]) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// Each element is hashed as it is decoded. A zero value HashSet (e.g. one
+// allocated by the json package for a struct field) is initialized to use the
+// Hash() method of T, which must then implement HashFunc[H].
 func (s *HashSet[T, H]) UnmarshalJSON(data []byte) error {
+	if err := s.init(); err != nil {
+		return err
+	}
 	return unmarshalJSON[T](s, data)
 }
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s *HashSet[T, H]) MarshalBinary() ([]byte, error) {
+	return marshalBinary[T](s)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+//
+// As with UnmarshalJSON, a zero value HashSet is initialized to use the Hash()
+// method of T, which must then implement HashFunc[H].
+func (s *HashSet[T, H]) UnmarshalBinary(data []byte) error {
+	if err := s.init(); err != nil {
+		return err
+	}
+	return unmarshalBinary[T](s, data)
+}
+
+// init prepares a zero value HashSet for use, hashing elements with the Hash()
+// method of T.
+func (s *HashSet[T, H]) init() error {
+	if s.hash == nil {
+		var zero T
+		if _, ok := any(zero).(HashFunc[H]); !ok {
+			return fmt.Errorf("hashset: %T does not implement HashFunc, use NewHashSetFunc", zero)
+		}
+		s.hash = func(item T) H {
+			return any(item).(HashFunc[H]).Hash()
+		}
+	}
+	if s.items == nil {
+		s.items = make(map[H]T)
+	}
+	return nil
+}
@@ -4,7 +4,12 @@
 package set
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -73,6 +78,69 @@ var (
 	s3 = &coded{i: 3}
 )
 
+// collider is an example type with a poor hash function, where many distinct
+// values share the same hash value
+type collider struct {
+	name string
+}
+
+func (c *collider) Hash() int {
+	return len(c.name)
+}
+
+func (c *collider) Equal(o *collider) bool {
+	return c.name == o.name
+}
+
+func colliders(names ...string) []*collider {
+	result := make([]*collider, 0, len(names))
+	for _, name := range names {
+		result = append(result, &collider{name: name})
+	}
+	return result
+}
+
+func names(s *HashSet[*collider, int]) []string {
+	result := make([]string, 0, s.Size())
+	for _, c := range s.Slice() {
+		result = append(result, c.name)
+	}
+	slices.Sort(result)
+	return result
+}
+
+// digest is an example type exposing a content digest, implementing Hash() uint64
+type digest struct {
+	content []byte // not comparable
+}
+
+func (d *digest) Hash() uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(d.content)
+	return h.Sum64()
+}
+
+// checksum is a named string type used as a Hash
+type checksum string
+
+// blob is an example type implementing Hash() checksum
+type blob struct {
+	content []byte // not comparable
+}
+
+func (b *blob) Hash() checksum {
+	return checksum(fmt.Sprintf("%x", sha256.Sum256(b.content)))
+}
+
+// handle is an example type implementing Hash() uintptr
+type handle struct {
+	fd []uintptr // not comparable
+}
+
+func (h *handle) Hash() uintptr {
+	return h.fd[0]
+}
+
 func TestHashSet_New(t *testing.T) {
 	t.Run("positive", func(t *testing.T) {
 		s := NewHashSet[*company, string](1)
@@ -90,6 +158,211 @@ func TestHashSet_New(t *testing.T) {
 	})
 }
 
+func TestHashSet_HashTypes(t *testing.T) {
+	t.Run("uint64", func(t *testing.T) {
+		s := NewHashSet[*digest, uint64](0)
+		must.True(t, s.Insert(&digest{content: []byte("hello")}))
+		must.False(t, s.Insert(&digest{content: []byte("hello")}))
+		must.True(t, s.Insert(&digest{content: []byte("world")}))
+		must.True(t, s.Contains(&digest{content: []byte("world")}))
+		must.Size(t, 2, s)
+	})
+
+	t.Run("named string", func(t *testing.T) {
+		s := HashSetOf[*blob, checksum](
+			&blob{content: []byte("a")},
+			&blob{content: []byte("b")},
+			&blob{content: []byte("a")},
+		)
+		must.Size(t, 2, s)
+		must.True(t, s.Contains(&blob{content: []byte("b")}))
+	})
+
+	t.Run("uintptr", func(t *testing.T) {
+		s := HashSetOf[*handle, uintptr](
+			&handle{fd: []uintptr{3}},
+			&handle{fd: []uintptr{4}},
+		)
+		must.True(t, s.Contains(&handle{fd: []uintptr{4}}))
+		must.False(t, s.Contains(&handle{fd: []uintptr{5}}))
+	})
+}
+
+func TestHashSet_NewFunc(t *testing.T) {
+	// url.URL does not implement HashFunc, and contains a pointer field
+	hash := func(u *url.URL) string { return u.String() }
+
+	parse := func(raw string) *url.URL {
+		u, err := url.Parse(raw)
+		must.NoError(t, err)
+		return u
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		s := NewHashSetFunc(hash, 0)
+		must.MapEmpty(t, s.items)
+	})
+
+	t.Run("insert contains remove", func(t *testing.T) {
+		s := NewHashSetFunc(hash, 10)
+		must.True(t, s.Insert(parse("https://example.com/a")))
+		must.False(t, s.Insert(parse("https://example.com/a")))
+		must.True(t, s.Insert(parse("https://example.com/b")))
+		must.True(t, s.Contains(parse("https://example.com/b")))
+		must.True(t, s.Remove(parse("https://example.com/a")))
+		must.Size(t, 1, s)
+	})
+
+	t.Run("derived sets keep hash", func(t *testing.T) {
+		a := NewHashSetFunc(hash, 0)
+		a.InsertMany(parse("https://a.example.com"), parse("https://b.example.com"))
+		b := NewHashSetFunc(hash, 0)
+		b.InsertMany(parse("https://b.example.com"), parse("https://c.example.com"))
+
+		union := a.Union(b)
+		must.Size(t, 3, union)
+		must.False(t, union.Insert(parse("https://c.example.com")))
+
+		for _, derived := range []*HashSet[*url.URL, string]{
+			a.Copy(), a.Difference(b), a.Intersect(b), a.SymmetricDifference(b),
+		} {
+			must.True(t, derived.Insert(parse("https://d.example.com")))
+			must.True(t, derived.Contains(parse("https://d.example.com")))
+		}
+
+		must.True(t, a.ContainsSlice([]*url.URL{
+			parse("https://b.example.com"), parse("https://a.example.com"), parse("https://a.example.com"),
+		}))
+	})
+}
+
+func TestHashSet_NewEqual(t *testing.T) {
+	newSet := func(names ...string) *HashSet[*collider, int] {
+		s := NewHashSetEqual[*collider, int](0)
+		s.InsertSlice(colliders(names...))
+		return s
+	}
+
+	t.Run("default drops collisions", func(t *testing.T) {
+		s := NewHashSet[*collider, int](0)
+		must.True(t, s.InsertSlice(colliders("a", "b", "cc")))
+		must.Size(t, 2, s)
+	})
+
+	t.Run("insert contains", func(t *testing.T) {
+		s := NewHashSetEqual[*collider, int](0)
+		must.True(t, s.Insert(&collider{name: "a"}))
+		must.True(t, s.Insert(&collider{name: "b"}))
+		must.True(t, s.Insert(&collider{name: "c"}))
+		must.False(t, s.Insert(&collider{name: "b"}))
+		must.True(t, s.Insert(&collider{name: "dd"}))
+		must.Size(t, 4, s)
+		must.MapLen(t, 2, s.items)
+		must.True(t, s.ContainsAll(colliders("a", "b", "c", "dd")...))
+		must.False(t, s.Contains(&collider{name: "e"}))
+		must.Eq(t, []string{"a", "b", "c", "dd"}, names(s))
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		s := newSet("a", "b", "c", "dd")
+
+		// remove from overflow
+		must.True(t, s.Remove(&collider{name: "c"}))
+		must.False(t, s.Remove(&collider{name: "c"}))
+		must.Eq(t, []string{"a", "b", "dd"}, names(s))
+
+		// remove from items, promoting from overflow
+		must.True(t, s.Remove(&collider{name: "a"}))
+		must.True(t, s.Contains(&collider{name: "b"}))
+		must.Eq(t, []string{"b", "dd"}, names(s))
+
+		must.True(t, s.Remove(&collider{name: "b"}))
+		must.False(t, s.Remove(&collider{name: "e"}))
+		must.Eq(t, []string{"dd"}, names(s))
+		must.Zero(t, s.collisions)
+		must.MapEmpty(t, s.overflow)
+	})
+
+	t.Run("remove func", func(t *testing.T) {
+		s := newSet("a", "b", "c", "d", "ee")
+		must.True(t, s.RemoveFunc(func(c *collider) bool {
+			return c.name != "c"
+		}))
+		must.Eq(t, []string{"c"}, names(s))
+	})
+
+	t.Run("algebra", func(t *testing.T) {
+		a := newSet("a", "b", "c", "xx")
+		b := newSet("b", "c", "d", "yy")
+		must.Eq(t, []string{"a", "b", "c", "d", "xx", "yy"}, names(a.Union(b)))
+		must.Eq(t, []string{"a", "xx"}, names(a.Difference(b)))
+		must.Eq(t, []string{"b", "c"}, names(a.Intersect(b)))
+		must.Eq(t, []string{"b", "c"}, names(b.Intersect(a)))
+		must.Eq(t, []string{"a", "d", "xx", "yy"}, names(a.SymmetricDifference(b)))
+		must.True(t, a.Intersects(b))
+		must.False(t, a.Disjoint(b))
+		must.True(t, newSet("a", "b").Disjoint(newSet("c", "d")))
+	})
+
+	t.Run("subset equal", func(t *testing.T) {
+		a := newSet("a", "b", "c")
+		must.True(t, a.Subset(newSet("c", "a")))
+		must.False(t, a.Subset(newSet("c", "d")))
+		must.True(t, a.Equal(newSet("c", "b", "a")))
+		must.False(t, a.Equal(newSet("c", "b", "d")))
+		must.True(t, a.EqualSlice(colliders("b", "c", "a")))
+		must.True(t, a.ContainsSlice(colliders("b", "c", "a", "a")))
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		a := newSet("a", "b")
+		b := a.Copy()
+		must.True(t, b.Insert(&collider{name: "c"}))
+		must.True(t, b.Remove(&collider{name: "b"}))
+		must.Eq(t, []string{"a", "b"}, names(a))
+		must.Eq(t, []string{"a", "c"}, names(b))
+	})
+
+	t.Run("insert remove set", func(t *testing.T) {
+		a := newSet("a", "b")
+		must.True(t, a.InsertSet(newSet("b", "c")))
+		must.False(t, a.InsertSet(newSet("a", "c")))
+		must.Eq(t, []string{"a", "b", "c"}, names(a))
+		must.True(t, a.RemoveSet(newSet("c", "a")))
+		must.Eq(t, []string{"b"}, names(a))
+	})
+
+	t.Run("equal func", func(t *testing.T) {
+		s := NewHashSetEqualFunc(func(s string) int {
+			return len(s)
+		}, func(a, b string) bool {
+			return a == b
+		}, 10)
+		must.True(t, s.InsertMany("one", "two", "six", "four"))
+		must.Size(t, 4, s)
+		must.True(t, s.ContainsFunc(func(item string) bool { return item == "six" }))
+	})
+}
+
+func TestHashSet_Of(t *testing.T) {
+	t.Run("of none", func(t *testing.T) {
+		s := HashSetOf[*company, string]()
+		must.MapEmpty(t, s.items)
+	})
+
+	t.Run("of some", func(t *testing.T) {
+		s := HashSetOf[*company, string](c1, c2, c1)
+		must.MapContainsKeys(t, s.items, []string{"street:1", "street:2"})
+		must.Size(t, 2, s)
+	})
+}
+
+func TestHashSet_Collect(t *testing.T) {
+	s := HashSetCollect[*company, string](slices.Values([]*company{c1, c2, c1}))
+	must.MapContainsKeys(t, s.items, []string{"street:1", "street:2"})
+	must.Size(t, 2, s)
+}
+
 func TestHashSet_Insert(t *testing.T) {
 	t.Run("one", func(t *testing.T) {
 		s := NewHashSet[*company, string](1)
@@ -131,6 +404,23 @@ func TestHashSet_InsertSlice(t *testing.T) {
 	})
 }
 
+func TestHashSet_InsertMany(t *testing.T) {
+	t.Run("insert none", func(t *testing.T) {
+		empty := NewHashSet[*company, string](0)
+		must.False(t, empty.InsertMany())
+		must.MapEmpty(t, empty.items)
+	})
+
+	t.Run("insert some", func(t *testing.T) {
+		s := NewHashSet[*company, string](0)
+		must.True(t, s.InsertMany(c1, c2, c3))
+		must.False(t, s.InsertMany(c1, c3))
+		must.MapContainsKeys(t, s.items, []string{
+			"street:1", "street:2", "street:3",
+		})
+	})
+}
+
 func TestHashSet_InsertSet(t *testing.T) {
 	t.Run("insert empty", func(t *testing.T) {
 		a := HashSetFrom[*company, string]([]*company{c1, c2, c3})
@@ -199,6 +489,21 @@ func TestHashSet_RemoveSlice(t *testing.T) {
 	})
 }
 
+func TestHashSet_RemoveMany(t *testing.T) {
+	t.Run("empty remove many", func(t *testing.T) {
+		s := NewHashSet[*company, string](10)
+		must.False(t, s.RemoveMany(c1, c2))
+		must.MapEmpty(t, s.items)
+	})
+
+	t.Run("set remove some", func(t *testing.T) {
+		s := HashSetOf[*company, string](c1, c2, c3, c4)
+		must.True(t, s.RemoveMany(c2, c4, c6))
+		must.MapContainsKeys(t, s.items, []string{"street:1", "street:3"})
+		must.False(t, s.RemoveMany(c2, c4))
+	})
+}
+
 func TestHashSet_RemoveSet(t *testing.T) {
 	t.Run("empty remove empty", func(t *testing.T) {
 		a := NewHashSet[*company, string](0)
@@ -224,6 +529,65 @@ func TestHashSet_RemoveSet(t *testing.T) {
 	})
 }
 
+func TestHashSet_Filter(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := NewHashSet[*company, string](0)
+		must.MapEmpty(t, s.Filter(func(*company) bool { return true }).items)
+	})
+
+	t.Run("some", func(t *testing.T) {
+		s := HashSetOf[*company, string](c1, c2, c3, c4, c5)
+		odd := s.Filter(func(c *company) bool { return c.floor%2 == 1 })
+		must.MapLen(t, 3, odd.items)
+		must.MapContainsKeys(t, odd.items, []string{"street:1", "street:3", "street:5"})
+		must.MapLen(t, 5, s.items)
+	})
+
+	t.Run("colliding hash", func(t *testing.T) {
+		s := NewHashSetEqual[*collider, int](0)
+		s.InsertSlice(colliders("a", "b", "c", "dd"))
+		result := s.Filter(func(c *collider) bool { return c.name != "a" })
+		must.Eq(t, []string{"b", "c", "dd"}, names(result))
+		must.True(t, result.Insert(&collider{name: "e"}))
+	})
+}
+
+func TestHashSet_RetainSet(t *testing.T) {
+	t.Run("empty empty", func(t *testing.T) {
+		a := NewHashSet[*company, string](0)
+		must.False(t, a.RetainSet(NewHashSet[*company, string](0)))
+		must.MapEmpty(t, a.items)
+	})
+
+	t.Run("some empty", func(t *testing.T) {
+		a := HashSetOf[*company, string](c1, c2)
+		must.True(t, a.RetainSet(NewHashSet[*company, string](0)))
+		must.MapEmpty(t, a.items)
+	})
+
+	t.Run("overlap", func(t *testing.T) {
+		a := HashSetOf[*company, string](c1, c2, c3, c4)
+		must.True(t, a.RetainSet(HashSetOf[*company, string](c2, c4, c6)))
+		must.MapLen(t, 2, a.items)
+		must.MapContainsKeys(t, a.items, []string{"street:2", "street:4"})
+	})
+
+	t.Run("superset", func(t *testing.T) {
+		a := HashSetOf[*company, string](c1, c2)
+		must.False(t, a.RetainSet(HashSetOf[*company, string](c1, c2, c3)))
+		must.MapLen(t, 2, a.items)
+	})
+
+	t.Run("colliding hash", func(t *testing.T) {
+		a := NewHashSetEqual[*collider, int](0)
+		a.InsertSlice(colliders("a", "b", "c"))
+		o := NewHashSetEqual[*collider, int](0)
+		o.InsertSlice(colliders("b", "d"))
+		must.True(t, a.RetainSet(o))
+		must.Eq(t, []string{"b"}, names(a))
+	})
+}
+
 func TestHashSet_RemoveFunc(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		s := NewHashSet[*company, string](10)
@@ -265,6 +629,89 @@ func TestHashSet_RemoveFunc(t *testing.T) {
 	})
 }
 
+// lease is an example type where the hash covers only its identity, and other
+// fields may change over time
+type lease struct {
+	id      string
+	expires int
+}
+
+func (l *lease) Hash() string {
+	return l.id
+}
+
+func TestHashSet_Get(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := NewHashSet[*lease, string](0)
+		result, exists := s.Get(&lease{id: "a"})
+		must.False(t, exists)
+		must.Nil(t, result)
+	})
+
+	t.Run("stored element", func(t *testing.T) {
+		stored := &lease{id: "a", expires: 10}
+		s := HashSetOf[*lease, string](stored, &lease{id: "b", expires: 20})
+		result, exists := s.Get(&lease{id: "a"})
+		must.True(t, exists)
+		must.Eq(t, stored, result)
+		must.Eq(t, 10, result.expires)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		s := HashSetOf[*lease, string](&lease{id: "a", expires: 10})
+		_, exists := s.Get(&lease{id: "c"})
+		must.False(t, exists)
+	})
+
+	t.Run("colliding hash", func(t *testing.T) {
+		s := NewHashSetEqual[*collider, int](0)
+		s.InsertSlice(colliders("a", "b", "c"))
+		result, exists := s.Get(&collider{name: "c"})
+		must.True(t, exists)
+		must.Eq(t, "c", result.name)
+		_, exists = s.Get(&collider{name: "d"})
+		must.False(t, exists)
+	})
+}
+
+func TestHashSet_InsertOrReplace(t *testing.T) {
+	t.Run("insert", func(t *testing.T) {
+		s := NewHashSet[*lease, string](0)
+		must.True(t, s.InsertOrReplace(&lease{id: "a", expires: 10}))
+		must.Size(t, 1, s)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		s := HashSetOf[*lease, string](&lease{id: "a", expires: 10})
+		must.False(t, s.Insert(&lease{id: "a", expires: 20}))
+		result, _ := s.Get(&lease{id: "a"})
+		must.Eq(t, 10, result.expires)
+
+		must.False(t, s.InsertOrReplace(&lease{id: "a", expires: 30}))
+		result, _ = s.Get(&lease{id: "a"})
+		must.Eq(t, 30, result.expires)
+		must.Size(t, 1, s)
+	})
+
+	t.Run("colliding hash", func(t *testing.T) {
+		s := NewHashSetEqualFunc(func(l *lease) int {
+			return len(l.id)
+		}, func(a, b *lease) bool {
+			return a.id == b.id
+		}, 0)
+		must.True(t, s.InsertOrReplace(&lease{id: "a", expires: 1}))
+		must.True(t, s.InsertOrReplace(&lease{id: "b", expires: 2}))
+		must.False(t, s.InsertOrReplace(&lease{id: "b", expires: 3}))
+		must.False(t, s.InsertOrReplace(&lease{id: "a", expires: 4}))
+		must.Size(t, 2, s)
+
+		a, _ := s.Get(&lease{id: "a"})
+		must.Eq(t, 4, a.expires)
+		b, _ := s.Get(&lease{id: "b"})
+		must.Eq(t, 3, b.expires)
+	})
+}
+
 func TestHashSet_Contains(t *testing.T) {
 	t.Run("empty contains", func(t *testing.T) {
 		a := NewHashSet[*company, string](0)
@@ -285,12 +732,34 @@ func TestHashSet_Contains(t *testing.T) {
 func TestHashSet_ContainsAll(t *testing.T) {
 	t.Run("contains subset", func(t *testing.T) {
 		s := HashSetFrom[*company, string]([]*company{c1, c2, c3, c4, c5})
-		must.True(t, s.ContainsAll([]*company{c2, c3, c4}))
+		must.True(t, s.ContainsAll(c2, c3, c4))
 	})
 
 	t.Run("contains missing", func(t *testing.T) {
 		s := HashSetFrom[*company, string]([]*company{c1, c3})
-		must.False(t, s.ContainsAll([]*company{c1, c2, c3}))
+		must.False(t, s.ContainsAll(c1, c2, c3))
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		s := HashSetFrom[*company, string]([]*company{c1})
+		must.True(t, s.ContainsAll(c1, c1))
+	})
+}
+
+func TestHashSet_ContainsAny(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := NewHashSet[*company, string](10)
+		must.False(t, s.ContainsAny(c1, c2))
+	})
+
+	t.Run("contains one", func(t *testing.T) {
+		s := HashSetOf[*company, string](c1, c2, c3)
+		must.True(t, s.ContainsAny(c5, c3))
+	})
+
+	t.Run("contains none", func(t *testing.T) {
+		s := HashSetOf[*company, string](c1, c2, c3)
+		must.False(t, s.ContainsAny(c4, c5, c6))
 	})
 }
 
@@ -344,6 +813,27 @@ func TestHashSet_ContainsSlice(t *testing.T) {
 	})
 }
 
+func TestHashSet_ContainsFunc(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := NewHashSet[*company, string](10)
+		must.False(t, s.ContainsFunc(func(*company) bool { return true }))
+	})
+
+	t.Run("match", func(t *testing.T) {
+		s := HashSetOf[*company, string](c1, c2, c10)
+		must.True(t, s.ContainsFunc(func(c *company) bool {
+			return c.floor > 9
+		}))
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		s := HashSetOf[*company, string](c1, c2, c3)
+		must.False(t, s.ContainsFunc(func(c *company) bool {
+			return c.floor > 9
+		}))
+	})
+}
+
 func TestHashSet_Subset(t *testing.T) {
 	t.Run("empty empty", func(t *testing.T) {
 		a := NewHashSet[*company, string](0)
@@ -380,6 +870,16 @@ func TestHashSet_Subset(t *testing.T) {
 		b := HashSetFrom[*company, string]([]*company{c3, c1, c2, c4})
 		must.False(t, a.Subset(b))
 	})
+
+	t.Run("allocs", func(t *testing.T) {
+		a := HashSetFrom[hashint, int](random[hashint](1000))
+		b := HashSetFrom[hashint, int](a.Slice()[:500])
+		allocs := testing.AllocsPerRun(10, func() {
+			a.Subset(b)
+			a.ProperSubset(b)
+		})
+		must.Eq(t, 0.0, allocs)
+	})
 }
 
 func TestHashSet_Size(t *testing.T) {
@@ -410,6 +910,20 @@ func TestHashSet_Empty(t *testing.T) {
 	})
 }
 
+func TestHashSet_Union(t *testing.T) {
+	byName := func(e employee) string { return e.name }
+	a := NewHashSetFunc(byName, 0)
+	a.InsertMany(employee{"alice", 1}, employee{"bob", 2})
+	b := NewHashSetFunc(byName, 0)
+	b.InsertMany(employee{"bob", 3}, employee{"carol", 4})
+
+	union := a.Union(b)
+	must.Size(t, 3, union)
+	bob, ok := union.Get(employee{name: "bob"})
+	must.True(t, ok)
+	must.Eq(t, 3, bob.id)
+}
+
 func TestHashSet_Difference(t *testing.T) {
 	t.Run("empty \\ empty", func(t *testing.T) {
 		a := NewHashSet[*company, string](10)
@@ -485,6 +999,37 @@ func TestHashSet_Intersect(t *testing.T) {
 	})
 }
 
+func TestHashSet_SymmetricDifference(t *testing.T) {
+	t.Run("empty △ empty", func(t *testing.T) {
+		a := NewHashSet[*company, string](10)
+		b := NewHashSet[*company, string](10)
+		result := a.SymmetricDifference(b)
+		must.MapEmpty(t, result.items)
+	})
+
+	t.Run("set △ empty", func(t *testing.T) {
+		a := HashSetFrom[*company, string]([]*company{c1, c2})
+		b := NewHashSet[*company, string](10)
+		must.MapContainsKeys(t, a.SymmetricDifference(b).items, []string{"street:1", "street:2"})
+		must.MapContainsKeys(t, b.SymmetricDifference(a).items, []string{"street:1", "street:2"})
+	})
+
+	t.Run("overlap", func(t *testing.T) {
+		a := HashSetFrom[*company, string]([]*company{c2, c3, c4, c6})
+		b := HashSetFrom[*company, string]([]*company{c4, c5, c6, c7})
+		result := a.SymmetricDifference(b)
+		must.Size(t, 4, result)
+		must.MapContainsKeys(t, result.items, []string{
+			"street:2", "street:3", "street:5", "street:7",
+		})
+	})
+
+	t.Run("same", func(t *testing.T) {
+		a := HashSetFrom[*company, string]([]*company{c1, c2})
+		must.MapEmpty(t, a.SymmetricDifference(a).items)
+	})
+}
+
 func TestHashSet_Copy(t *testing.T) {
 	t.Run("copy empty", func(t *testing.T) {
 		a := NewHashSet[*company, string](0)
@@ -507,6 +1052,16 @@ func TestHashSet_Copy(t *testing.T) {
 			"street:1", "street:2", "street:3", "street:4",
 		})
 	})
+
+	t.Run("copy functions", func(t *testing.T) {
+		a := NewHashSetEqual[*collider, int](0)
+		a.InsertSlice(colliders("a", "b"))
+		b := a.Copy()
+		must.True(t, b.Insert(&collider{name: "c"}))
+		must.True(t, b.Remove(&collider{name: "a"}))
+		must.Eq(t, []string{"b", "c"}, names(b))
+		must.Eq(t, []string{"a", "b"}, names(a))
+	})
 }
 
 func TestHashSet_Slice(t *testing.T) {
@@ -525,6 +1080,191 @@ func TestHashSet_Slice(t *testing.T) {
 	})
 }
 
+func TestHashSet_ForEach(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := NewHashSet[*company, string](10)
+		visits := 0
+		s.ForEach(func(*company) bool {
+			visits++
+			return true
+		})
+		must.Zero(t, visits)
+	})
+
+	t.Run("visit all", func(t *testing.T) {
+		s := HashSetOf[*company, string](c1, c2, c3, c4, c5)
+		result := NewHashSet[*company, string](5)
+		s.ForEach(func(c *company) bool {
+			result.Insert(c)
+			return true
+		})
+		must.True(t, s.Equal(result))
+	})
+
+	t.Run("visit collisions", func(t *testing.T) {
+		s := NewHashSetEqual[*collider, int](0)
+		s.InsertSlice(colliders("a", "b", "c", "dd"))
+		visits := 0
+		s.ForEach(func(*collider) bool {
+			visits++
+			return true
+		})
+		must.Eq(t, 4, visits)
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		s := HashSetOf[*company, string](c1, c2, c3, c4, c5)
+		visits := 0
+		s.ForEach(func(*company) bool {
+			visits++
+			return visits < 3
+		})
+		must.Eq(t, 3, visits)
+	})
+}
+
+func TestHashSet_Capacity(t *testing.T) {
+	t.Run("constructor", func(t *testing.T) {
+		must.Eq(t, 10, NewHashSet[*company, string](10).Capacity())
+		must.Zero(t, NewHashSet[*company, string](-1).Capacity())
+	})
+
+	t.Run("high water mark", func(t *testing.T) {
+		s := NewHashSet[*company, string](2)
+		s.InsertMany(c1, c2, c3, c4)
+		must.Eq(t, 4, s.Capacity())
+		s.RemoveMany(c1, c2, c3)
+		must.Eq(t, 4, s.Capacity())
+		must.Size(t, 1, s)
+	})
+
+	t.Run("collisions", func(t *testing.T) {
+		s := NewHashSetEqual[*collider, int](0)
+		s.InsertSlice(colliders("a", "b", "c"))
+		must.Eq(t, 3, s.Capacity())
+	})
+}
+
+func TestHashSet_Grow(t *testing.T) {
+	t.Run("grow empty", func(t *testing.T) {
+		s := NewHashSet[*company, string](0)
+		s.Grow(100)
+		must.MapEmpty(t, s.items)
+		must.Eq(t, 100, s.Capacity())
+	})
+
+	t.Run("grow some", func(t *testing.T) {
+		s := HashSetOf[*company, string](c1, c2, c3)
+		s.Grow(1000)
+		must.MapContainsKeys(t, s.items, []string{"street:1", "street:2", "street:3"})
+		must.Size(t, 3, s)
+		must.Eq(t, 1003, s.Capacity())
+	})
+
+	t.Run("grow negative", func(t *testing.T) {
+		s := HashSetOf[*company, string](c1, c2, c3)
+		s.Grow(-1)
+		must.Size(t, 3, s)
+		must.Eq(t, 3, s.Capacity())
+	})
+}
+
+func TestHashSet_Compact(t *testing.T) {
+	t.Run("compact empty", func(t *testing.T) {
+		s := NewHashSet[*company, string](1000)
+		s.Compact()
+		must.MapEmpty(t, s.items)
+		must.Zero(t, s.Capacity())
+	})
+
+	t.Run("compact after remove", func(t *testing.T) {
+		s := HashSetOf[*company, string](c1, c2, c3, c4, c5, c6)
+		s.RemoveFunc(func(c *company) bool { return c.floor > 2 })
+		s.Compact()
+		must.MapContainsKeys(t, s.items, []string{"street:1", "street:2"})
+		must.Size(t, 2, s)
+		must.Eq(t, 2, s.Capacity())
+		must.True(t, s.Insert(c3))
+	})
+
+	t.Run("compact collisions", func(t *testing.T) {
+		s := NewHashSetEqual[*collider, int](0)
+		s.InsertSlice(colliders("a", "b", "c", "dd"))
+		s.Remove(&collider{name: "b"})
+		s.Compact()
+		must.Eq(t, []string{"a", "c", "dd"}, names(s))
+		must.Eq(t, 3, s.Capacity())
+		must.True(t, s.Remove(&collider{name: "c"}))
+		must.True(t, s.Insert(&collider{name: "b"}))
+	})
+}
+
+func TestHashSet_SortedSlice(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		a := NewHashSet[*company, string](10)
+		l := a.SortedSlice(func(x, y *company) int {
+			return x.floor - y.floor
+		})
+		must.SliceEmpty(t, l)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		a := HashSetFrom[*company, string]([]*company{c3, c1, c10, c2})
+		l := a.SortedSlice(func(x, y *company) int {
+			return x.floor - y.floor
+		})
+		must.Eq(t, []*company{c1, c2, c3, c10}, l)
+	})
+}
+
+func TestHashSet_MinMax(t *testing.T) {
+	byFloor := func(a, b *company) int { return a.floor - b.floor }
+
+	t.Run("empty", func(t *testing.T) {
+		s := NewHashSet[*company, string](0)
+		minimum, ok := s.Min(byFloor)
+		must.False(t, ok)
+		must.Nil(t, minimum)
+		maximum, ok := s.Max(byFloor)
+		must.False(t, ok)
+		must.Nil(t, maximum)
+	})
+
+	t.Run("many", func(t *testing.T) {
+		s := HashSetOf[*company, string](c3, c1, c5, c2, c4)
+		minimum, ok := s.Min(byFloor)
+		must.True(t, ok)
+		must.Eq(t, c1, minimum)
+		maximum, ok := s.Max(byFloor)
+		must.True(t, ok)
+		must.Eq(t, c5, maximum)
+	})
+}
+
+func TestHashSet_Sample(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+
+	t.Run("empty", func(t *testing.T) {
+		s := NewHashSet[*company, string](0)
+		must.SliceEmpty(t, s.Sample(3, rng))
+	})
+
+	t.Run("more than size", func(t *testing.T) {
+		s := HashSetOf[*company, string](c1, c2, c3)
+		must.True(t, s.EqualSlice(s.Sample(5, rng)))
+	})
+
+	t.Run("distinct", func(t *testing.T) {
+		s := HashSetOf[*company, string](c1, c2, c3, c4, c5, c6, c7, c8)
+		for i := 0; i < 100; i++ {
+			sample := s.Sample(3, rng)
+			must.Len(t, 3, sample)
+			must.True(t, s.ContainsAll(sample...))
+			must.Size(t, 3, HashSetFrom[*company, string](sample))
+		}
+	})
+}
+
 func TestHashSet_List(t *testing.T) {
 	t.Run("list empty", func(t *testing.T) {
 		a := NewHashSet[*company, string](10)
@@ -563,6 +1303,141 @@ func TestHashSet_StringFunc(t *testing.T) {
 	})
 }
 
+func TestHashSet_StringSorted(t *testing.T) {
+	byFloor := func(x, y *company) int {
+		return x.floor - y.floor
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		a := NewHashSet[*company, string](10)
+		s := a.StringSorted(byFloor)
+		must.Eq(t, "[]", s)
+	})
+
+	t.Run("some", func(t *testing.T) {
+		a := HashSetFrom[*company, string]([]*company{c10, c2, c1})
+		must.Eq(t, "[<street 10> <street 1> <street 2>]", a.String())
+		must.Eq(t, "[<street 1> <street 2> <street 10>]", a.StringSorted(byFloor))
+	})
+}
+
+func TestHashSet_ProperSubset(t *testing.T) {
+	t.Run("empty empty", func(t *testing.T) {
+		a := NewHashSet[*company, string](0)
+		b := NewHashSet[*company, string](0)
+		must.False(t, a.ProperSubset(b))
+	})
+
+	t.Run("some empty", func(t *testing.T) {
+		a := HashSetOf[*company, string](c1)
+		b := NewHashSet[*company, string](0)
+		must.True(t, a.ProperSubset(b))
+		must.False(t, b.ProperSubset(a))
+	})
+
+	t.Run("equal", func(t *testing.T) {
+		a := HashSetOf[*company, string](c1, c2, c3)
+		b := HashSetOf[*company, string](c3, c2, c1)
+		must.True(t, a.Subset(b))
+		must.False(t, a.ProperSubset(b))
+	})
+
+	t.Run("proper", func(t *testing.T) {
+		running := HashSetOf[*company, string](c1, c2, c3, c4)
+		desired := HashSetOf[*company, string](c2, c4)
+		must.True(t, running.ProperSubset(desired))
+		must.False(t, desired.ProperSubset(running))
+	})
+
+	t.Run("not subset", func(t *testing.T) {
+		a := HashSetOf[*company, string](c1, c2, c3, c4)
+		b := HashSetOf[*company, string](c4, c5)
+		must.False(t, a.ProperSubset(b))
+	})
+}
+
+func TestHashSet_Intersects(t *testing.T) {
+	t.Run("empty some", func(t *testing.T) {
+		a := NewHashSet[*company, string](0)
+		b := HashSetOf[*company, string](c1, c2)
+		must.False(t, a.Intersects(b))
+		must.True(t, b.Disjoint(a))
+	})
+
+	t.Run("common", func(t *testing.T) {
+		a := HashSetOf[*company, string](c1, c2, c3, c4)
+		b := HashSetOf[*company, string](c4, c5)
+		must.True(t, a.Intersects(b))
+		must.False(t, b.Disjoint(a))
+	})
+
+	t.Run("nothing common", func(t *testing.T) {
+		a := HashSetOf[*company, string](c1, c2, c3, c4)
+		b := HashSetOf[*company, string](c5, c6)
+		must.False(t, a.Intersects(b))
+		must.True(t, b.Disjoint(a))
+	})
+}
+
+func TestHashSet_Equal(t *testing.T) {
+	t.Run("empty empty", func(t *testing.T) {
+		a := NewHashSet[*company, string](0)
+		b := NewHashSet[*company, string](10)
+		must.True(t, a.Equal(b))
+	})
+
+	t.Run("empty some", func(t *testing.T) {
+		a := NewHashSet[*company, string](0)
+		b := HashSetOf[*company, string](c1)
+		must.False(t, a.Equal(b))
+		must.False(t, b.Equal(a))
+	})
+
+	t.Run("equal", func(t *testing.T) {
+		a := HashSetOf[*company, string](c1, c2, c3)
+		b := HashSetOf[*company, string](c3, c2, c1)
+		must.True(t, a.Equal(b))
+	})
+
+	t.Run("equal by hash", func(t *testing.T) {
+		a := HashSetOf[*company, string](c1, c2)
+		b := HashSetOf[*company, string](
+			&company{address: "street", floor: 1},
+			&company{address: "street", floor: 2},
+		)
+		must.True(t, a.Equal(b))
+	})
+
+	t.Run("not equal", func(t *testing.T) {
+		a := HashSetOf[*company, string](c1, c2, c3)
+		b := HashSetOf[*company, string](c1, c2, c4)
+		must.False(t, a.Equal(b))
+	})
+
+	t.Run("colliding hash", func(t *testing.T) {
+		a := NewHashSetEqual[*collider, int](0)
+		a.InsertSlice(colliders("a", "b"))
+		b := NewHashSetEqual[*collider, int](0)
+		b.InsertSlice(colliders("b", "c"))
+		must.False(t, a.Equal(b))
+		must.False(t, a.EqualSlice(colliders("b", "c")))
+
+		b.Remove(&collider{name: "c"})
+		b.Insert(&collider{name: "a"})
+		must.True(t, a.Equal(b))
+		must.True(t, a.EqualSlice(colliders("b", "a")))
+	})
+
+	t.Run("allocs", func(t *testing.T) {
+		a := HashSetFrom[hashint, int](random[hashint](1000))
+		b := a.Copy()
+		allocs := testing.AllocsPerRun(10, func() {
+			a.Equal(b)
+		})
+		must.Eq(t, 0.0, allocs)
+	})
+}
+
 func TestHashSet_EqualSlice(t *testing.T) {
 	t.Run("empty empty", func(t *testing.T) {
 		a := NewHashSet[*company, string](0)
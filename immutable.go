@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+// Immutable is a read-only view of a set. It exposes only the operations of
+// ReadOnly, and the underlying set is not accessible through it, so code given
+// an Immutable cannot modify the set it was created from.
+//
+// An Immutable does not copy the underlying set; modifications made by the
+// owner of the underlying set are visible through the view. To hand out a
+// view that never changes, Freeze a Copy of the set.
+type Immutable[T any] struct {
+	set ReadOnly[T]
+}
+
+// Freeze creates an Immutable view of s.
+func Freeze[T any](s ReadOnly[T]) *Immutable[T] {
+	if frozen, ok := s.(*Immutable[T]); ok {
+		return frozen
+	}
+	return &Immutable[T]{set: s}
+}
+
+// Contains returns whether item is present in s.
+func (s *Immutable[T]) Contains(item T) bool {
+	return s.set.Contains(item)
+}
+
+// Size returns the cardinality of s.
+func (s *Immutable[T]) Size() int {
+	return s.set.Size()
+}
+
+// Empty returns true if s contains no elements, false otherwise.
+func (s *Immutable[T]) Empty() bool {
+	return s.set.Empty()
+}
+
+// Slice creates a copy of s as a slice, in the order of the underlying set.
+func (s *Immutable[T]) Slice() []T {
+	return s.set.Slice()
+}
+
+// ForEach calls visit for each element of s, in the order of the underlying
+// set. Iteration stops early if visit returns false.
+func (s *Immutable[T]) ForEach(visit func(item T) bool) {
+	s.set.ForEach(visit)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestFreeze(t *testing.T) {
+	t.Run("read", func(t *testing.T) {
+		f := Freeze[int](TreeSetFrom[int, Compare[int]]([]int{3, 1, 2}, Cmp[int]))
+		must.True(t, f.Contains(1))
+		must.False(t, f.Contains(4))
+		must.Eq(t, 3, f.Size())
+		must.False(t, f.Empty())
+		must.Eq(t, []int{1, 2, 3}, f.Slice())
+
+		var visited []int
+		f.ForEach(func(i int) bool {
+			visited = append(visited, i)
+			return i < 2
+		})
+		must.Eq(t, []int{1, 2}, visited)
+	})
+
+	t.Run("not a collection", func(t *testing.T) {
+		var r ReadOnly[int] = Freeze[int](Of(1, 2))
+		_, ok := r.(Collection[int])
+		must.False(t, ok)
+	})
+
+	t.Run("view", func(t *testing.T) {
+		s := Of(1, 2)
+		f := Freeze[int](s)
+		s.Insert(3)
+		must.True(t, f.Contains(3))
+
+		snapshot := Freeze[int](s.Copy())
+		s.Insert(4)
+		must.False(t, snapshot.Contains(4))
+	})
+
+	t.Run("refreeze", func(t *testing.T) {
+		f := Freeze[int](Of(1))
+		must.Eq(t, f, Freeze[int](f))
+	})
+
+	t.Run("convert", func(t *testing.T) {
+		f := Freeze[int](Of(1, 2, 3))
+		s := ToSet(f)
+		must.True(t, s.Insert(4))
+		must.Eq(t, 3, f.Size())
+		must.True(t, ContainsAll(f, 1, 2))
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"sync/atomic"
+)
+
+// Hooks are optional callbacks invoked by an Instrumented set. Any hook may be
+// left nil.
+//
+// Hooks are called synchronously after the underlying operation completes, so
+// they must be fast and must not call methods on the Instrumented set.
+type Hooks[T any] struct {
+	// Insert is called with each item that is newly inserted into the set.
+	Insert func(item T)
+
+	// Remove is called with each item that is removed from the set.
+	Remove func(item T)
+
+	// Hit is called with each item passed to Contains that is present.
+	Hit func(item T)
+
+	// Miss is called with each item passed to Contains that is not present.
+	Miss func(item T)
+}
+
+// Stats is a snapshot of the counters of an Instrumented set.
+type Stats struct {
+	Inserts uint64 // number of items newly inserted
+	Removes uint64 // number of items removed
+	Hits    uint64 // number of Contains calls that found the item
+	Misses  uint64 // number of Contains calls that did not find the item
+	Size    int    // current size of the set
+}
+
+// Instrumented wraps a Collection, counting inserts, removes, hits, and misses
+// and invoking the configured Hooks for each.
+//
+// Counters are updated atomically, so an Instrumented set is safe for
+// concurrent use if the underlying Collection is (e.g. a SyncSet).
+type Instrumented[T any] struct {
+	set   Collection[T]
+	hooks Hooks[T]
+
+	inserts atomic.Uint64
+	removes atomic.Uint64
+	hits    atomic.Uint64
+	misses  atomic.Uint64
+}
+
+// Instrument creates an Instrumented set wrapping c, invoking hooks for each
+// operation. Operations must be made through the Instrumented set to be
+// counted.
+func Instrument[T any](c Collection[T], hooks Hooks[T]) *Instrumented[T] {
+	return &Instrumented[T]{
+		set:   c,
+		hooks: hooks,
+	}
+}
+
+// Insert item into s.
+//
+// Return true if s was modified (item was not already in s), false otherwise.
+func (s *Instrumented[T]) Insert(item T) bool {
+	if !s.set.Insert(item) {
+		return false
+	}
+	s.inserts.Add(1)
+	if s.hooks.Insert != nil {
+		s.hooks.Insert(item)
+	}
+	return true
+}
+
+// Remove will remove item from s.
+//
+// Return true if s was modified (item was present), false otherwise.
+func (s *Instrumented[T]) Remove(item T) bool {
+	if !s.set.Remove(item) {
+		return false
+	}
+	s.removes.Add(1)
+	if s.hooks.Remove != nil {
+		s.hooks.Remove(item)
+	}
+	return true
+}
+
+// Contains returns whether item is present in s.
+func (s *Instrumented[T]) Contains(item T) bool {
+	if s.set.Contains(item) {
+		s.hits.Add(1)
+		if s.hooks.Hit != nil {
+			s.hooks.Hit(item)
+		}
+		return true
+	}
+	s.misses.Add(1)
+	if s.hooks.Miss != nil {
+		s.hooks.Miss(item)
+	}
+	return false
+}
+
+// Size returns the cardinality of s.
+func (s *Instrumented[T]) Size() int {
+	return s.set.Size()
+}
+
+// Empty returns true if s contains no elements, false otherwise.
+func (s *Instrumented[T]) Empty() bool {
+	return s.set.Empty()
+}
+
+// Slice creates a copy of s as a slice, in the order of the underlying set.
+func (s *Instrumented[T]) Slice() []T {
+	return s.set.Slice()
+}
+
+// ForEach calls visit for each element of s, in the order of the underlying
+// set. Iteration stops early if visit returns false.
+func (s *Instrumented[T]) ForEach(visit func(item T) bool) {
+	s.set.ForEach(visit)
+}
+
+// Stats returns a snapshot of the counters of s.
+func (s *Instrumented[T]) Stats() Stats {
+	return Stats{
+		Inserts: s.inserts.Load(),
+		Removes: s.removes.Load(),
+		Hits:    s.hits.Load(),
+		Misses:  s.misses.Load(),
+		Size:    s.set.Size(),
+	}
+}
+
+// Unwrap returns the underlying Collection of s. Operations made directly on
+// the underlying Collection are not counted.
+func (s *Instrumented[T]) Unwrap() Collection[T] {
+	return s.set
+}
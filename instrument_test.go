@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestInstrument(t *testing.T) {
+	t.Run("counters", func(t *testing.T) {
+		s := Instrument[int](New[int](0), Hooks[int]{})
+		must.Eq(t, Stats{}, s.Stats())
+
+		must.True(t, s.Insert(1))
+		must.True(t, s.Insert(2))
+		must.False(t, s.Insert(2))
+		must.True(t, s.Contains(1))
+		must.False(t, s.Contains(3))
+		must.False(t, s.Contains(4))
+		must.True(t, s.Remove(1))
+		must.False(t, s.Remove(1))
+
+		must.Eq(t, Stats{
+			Inserts: 2,
+			Removes: 1,
+			Hits:    1,
+			Misses:  2,
+			Size:    1,
+		}, s.Stats())
+	})
+
+	t.Run("hooks", func(t *testing.T) {
+		var inserted, removed, hit, missed []string
+		s := Instrument[string](NewTreeSet[string, Compare[string]](Cmp[string]), Hooks[string]{
+			Insert: func(item string) { inserted = append(inserted, item) },
+			Remove: func(item string) { removed = append(removed, item) },
+			Hit:    func(item string) { hit = append(hit, item) },
+			Miss:   func(item string) { missed = append(missed, item) },
+		})
+		s.Insert("a")
+		s.Insert("b")
+		s.Insert("a")
+		s.Contains("a")
+		s.Contains("c")
+		s.Remove("b")
+		s.Remove("c")
+
+		must.Eq(t, []string{"a", "b"}, inserted)
+		must.Eq(t, []string{"b"}, removed)
+		must.Eq(t, []string{"a"}, hit)
+		must.Eq(t, []string{"c"}, missed)
+		must.Eq(t, []string{"a"}, s.Slice())
+	})
+
+	t.Run("unwrap", func(t *testing.T) {
+		u := Of(1, 2, 3)
+		s := Instrument[int](u, Hooks[int]{})
+		must.Eq[Collection[int]](t, u, s.Unwrap())
+		must.True(t, s.Unwrap().Insert(4))
+		must.Eq(t, 0, s.Stats().Inserts)
+		must.Eq(t, 4, s.Stats().Size)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		s := Instrument[int](NewSyncSet[int](0), Hooks[int]{})
+		var wg sync.WaitGroup
+		for w := 0; w < 4; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					s.Insert(w*100 + i)
+					s.Contains(i)
+				}
+			}(w)
+		}
+		wg.Wait()
+		stats := s.Stats()
+		must.Eq(t, 400, stats.Inserts)
+		must.Eq(t, 400, stats.Hits+stats.Misses)
+		must.Eq(t, 400, stats.Size)
+	})
+}
@@ -0,0 +1,289 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Interval is the half-open range of values from Lo up to but not including Hi.
+type Interval[T any] struct {
+	Lo T
+	Hi T
+}
+
+// String creates a string representation of i, using "%v" printf formatting
+// to transform each bound into a string.
+func (i Interval[T]) String() string {
+	return fmt.Sprintf("[%v, %v)", i.Lo, i.Hi)
+}
+
+// IntervalSet is a set of values of type T stored as disjoint half-open
+// intervals. Intervals that overlap or are adjacent are coalesced as they are
+// inserted, so that an IntervalSet always contains the fewest intervals that
+// cover its values. An IntervalSet is useful for tracking ranges such as
+// allocated ports or blocks of identifiers.
+//
+// The underlying data structure is a slice of intervals in ascending order,
+// searched by binary search.
+//
+// Not thread safe, and not safe for concurrent modification.
+type IntervalSet[T any, C Compare[T]] struct {
+	comparison C
+	intervals  []Interval[T]
+}
+
+// NewIntervalSet creates a new empty IntervalSet of type T, comparing values
+// via C.
+//
+// C is an implementation of Compare[T]. For builtin types, Cmp provides a
+// convenient Compare implementation.
+func NewIntervalSet[T any, C Compare[T]](compare C) *IntervalSet[T, C] {
+	return &IntervalSet[T, C]{
+		comparison: compare,
+	}
+}
+
+// IntervalSetFrom creates a new IntervalSet containing each interval in
+// intervals, coalesced as necessary.
+//
+// C is an implementation of Compare[T]. For builtin types, Cmp provides a
+// convenient Compare implementation.
+func IntervalSetFrom[T any, C Compare[T]](intervals []Interval[T], compare C) *IntervalSet[T, C] {
+	s := NewIntervalSet[T](compare)
+	for _, interval := range intervals {
+		s.Insert(interval.Lo, interval.Hi)
+	}
+	return s
+}
+
+// search returns the index of the first interval of s for which f is true.
+func (s *IntervalSet[T, C]) search(f func(Interval[T]) bool) int {
+	return sort.Search(len(s.intervals), func(i int) bool {
+		return f(s.intervals[i])
+	})
+}
+
+// Insert the interval [lo, hi) into s, coalescing it with any intervals it
+// overlaps or is adjacent to. If lo is not less than hi, s is not modified.
+//
+// Return true if s was modified (at least one value was not already in s), false otherwise.
+func (s *IntervalSet[T, C]) Insert(lo, hi T) bool {
+	if s.comparison(lo, hi) >= 0 {
+		return false
+	}
+	// intervals i up to j overlap or touch [lo, hi)
+	i := s.search(func(v Interval[T]) bool { return s.comparison(v.Hi, lo) >= 0 })
+	j := s.search(func(v Interval[T]) bool { return s.comparison(v.Lo, hi) > 0 })
+	if i < j {
+		first, last := s.intervals[i], s.intervals[j-1]
+		if i+1 == j && s.comparison(first.Lo, lo) <= 0 && s.comparison(first.Hi, hi) >= 0 {
+			return false
+		}
+		if s.comparison(first.Lo, lo) < 0 {
+			lo = first.Lo
+		}
+		if s.comparison(last.Hi, hi) > 0 {
+			hi = last.Hi
+		}
+	}
+	s.intervals = slices.Replace(s.intervals, i, j, Interval[T]{Lo: lo, Hi: hi})
+	return true
+}
+
+// Remove the interval [lo, hi) from s, splitting any interval of s that
+// extends beyond it on both sides. If lo is not less than hi, s is not
+// modified.
+//
+// Return true if s was modified (any value was present), false otherwise.
+func (s *IntervalSet[T, C]) Remove(lo, hi T) bool {
+	if s.comparison(lo, hi) >= 0 {
+		return false
+	}
+	// intervals i up to j overlap [lo, hi)
+	i := s.search(func(v Interval[T]) bool { return s.comparison(v.Hi, lo) > 0 })
+	j := s.search(func(v Interval[T]) bool { return s.comparison(v.Lo, hi) >= 0 })
+	if i >= j {
+		return false
+	}
+	first, last := s.intervals[i], s.intervals[j-1]
+	remaining := make([]Interval[T], 0, 2)
+	if s.comparison(first.Lo, lo) < 0 {
+		remaining = append(remaining, Interval[T]{Lo: first.Lo, Hi: lo})
+	}
+	if s.comparison(last.Hi, hi) > 0 {
+		remaining = append(remaining, Interval[T]{Lo: hi, Hi: last.Hi})
+	}
+	s.intervals = slices.Replace(s.intervals, i, j, remaining...)
+	return true
+}
+
+// InsertSet will insert each interval of o into s.
+//
+// Return true if s was modified (at least one value of o was not already in s), false otherwise.
+func (s *IntervalSet[T, C]) InsertSet(o *IntervalSet[T, C]) bool {
+	modified := false
+	for _, interval := range o.intervals {
+		if s.Insert(interval.Lo, interval.Hi) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// RemoveSet will remove each interval of o from s.
+//
+// Return true if s was modified (any value of o was present in s), false otherwise.
+func (s *IntervalSet[T, C]) RemoveSet(o *IntervalSet[T, C]) bool {
+	modified := false
+	for _, interval := range o.intervals {
+		if s.Remove(interval.Lo, interval.Hi) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// Contains returns whether point is within one of the intervals of s.
+func (s *IntervalSet[T, C]) Contains(point T) bool {
+	i := s.search(func(v Interval[T]) bool { return s.comparison(v.Hi, point) > 0 })
+	return i < len(s.intervals) && s.comparison(s.intervals[i].Lo, point) <= 0
+}
+
+// ContainsRange returns whether every value of [lo, hi) is within s. An empty
+// range is always contained.
+func (s *IntervalSet[T, C]) ContainsRange(lo, hi T) bool {
+	if s.comparison(lo, hi) >= 0 {
+		return true
+	}
+	i := s.search(func(v Interval[T]) bool { return s.comparison(v.Hi, lo) > 0 })
+	return i < len(s.intervals) &&
+		s.comparison(s.intervals[i].Lo, lo) <= 0 &&
+		s.comparison(s.intervals[i].Hi, hi) >= 0
+}
+
+// Overlaps returns whether any value of [lo, hi) is within s.
+func (s *IntervalSet[T, C]) Overlaps(lo, hi T) bool {
+	if s.comparison(lo, hi) >= 0 {
+		return false
+	}
+	i := s.search(func(v Interval[T]) bool { return s.comparison(v.Hi, lo) > 0 })
+	return i < len(s.intervals) && s.comparison(s.intervals[i].Lo, hi) < 0
+}
+
+// Gaps returns the intervals within [lo, hi) that are not covered by s, in
+// ascending order.
+func (s *IntervalSet[T, C]) Gaps(lo, hi T) []Interval[T] {
+	var gaps []Interval[T]
+	if s.comparison(lo, hi) >= 0 {
+		return gaps
+	}
+	cursor := lo
+	i := s.search(func(v Interval[T]) bool { return s.comparison(v.Hi, lo) > 0 })
+	for ; i < len(s.intervals) && s.comparison(s.intervals[i].Lo, hi) < 0; i++ {
+		interval := s.intervals[i]
+		if s.comparison(interval.Lo, cursor) > 0 {
+			gaps = append(gaps, Interval[T]{Lo: cursor, Hi: interval.Lo})
+		}
+		cursor = interval.Hi
+	}
+	if s.comparison(cursor, hi) < 0 {
+		gaps = append(gaps, Interval[T]{Lo: cursor, Hi: hi})
+	}
+	return gaps
+}
+
+// Size returns the number of disjoint intervals in s.
+func (s *IntervalSet[T, C]) Size() int {
+	return len(s.intervals)
+}
+
+// Empty returns true if s contains no values, false otherwise.
+func (s *IntervalSet[T, C]) Empty() bool {
+	return len(s.intervals) == 0
+}
+
+// Union returns a set that contains all values of s and o combined.
+func (s *IntervalSet[T, C]) Union(o *IntervalSet[T, C]) *IntervalSet[T, C] {
+	result := s.Copy()
+	result.InsertSet(o)
+	return result
+}
+
+// Difference returns a set that contains values of s that are not in o.
+func (s *IntervalSet[T, C]) Difference(o *IntervalSet[T, C]) *IntervalSet[T, C] {
+	result := s.Copy()
+	result.RemoveSet(o)
+	return result
+}
+
+// Intersect returns a set that contains values that are present in both s and o.
+func (s *IntervalSet[T, C]) Intersect(o *IntervalSet[T, C]) *IntervalSet[T, C] {
+	result := NewIntervalSet[T](s.comparison)
+	i, j := 0, 0
+	for i < len(s.intervals) && j < len(o.intervals) {
+		a, b := s.intervals[i], o.intervals[j]
+		lo, hi := a.Lo, a.Hi
+		if s.comparison(b.Lo, lo) > 0 {
+			lo = b.Lo
+		}
+		if s.comparison(b.Hi, hi) < 0 {
+			hi = b.Hi
+		}
+		if s.comparison(lo, hi) < 0 {
+			// the intervals of both sets are disjoint and not adjacent, so
+			// neither are their pairwise intersections
+			result.intervals = append(result.intervals, Interval[T]{Lo: lo, Hi: hi})
+		}
+		if s.comparison(a.Hi, b.Hi) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// Equal returns whether s and o contain the same values.
+func (s *IntervalSet[T, C]) Equal(o *IntervalSet[T, C]) bool {
+	return slices.EqualFunc(s.intervals, o.intervals, func(a, b Interval[T]) bool {
+		return s.comparison(a.Lo, b.Lo) == 0 && s.comparison(a.Hi, b.Hi) == 0
+	})
+}
+
+// Copy creates a copy of s.
+func (s *IntervalSet[T, C]) Copy() *IntervalSet[T, C] {
+	return &IntervalSet[T, C]{
+		comparison: s.comparison,
+		intervals:  slices.Clone(s.intervals),
+	}
+}
+
+// Intervals creates a copy of the disjoint intervals of s, in ascending order.
+func (s *IntervalSet[T, C]) Intervals() []Interval[T] {
+	return slices.Clone(s.intervals)
+}
+
+// ForEach calls visit for each disjoint interval of s, in ascending order.
+// Iteration stops early if visit returns false.
+func (s *IntervalSet[T, C]) ForEach(visit func(interval Interval[T]) bool) {
+	for _, interval := range s.intervals {
+		if !visit(interval) {
+			return
+		}
+	}
+}
+
+// String creates a string representation of s, using "%v" printf formatting
+// to transform each bound into a string. The result contains intervals in
+// ascending order.
+func (s *IntervalSet[T, C]) String() string {
+	l := make([]string, 0, len(s.intervals))
+	for _, interval := range s.intervals {
+		l = append(l, interval.String())
+	}
+	return fmt.Sprintf("%s", l)
+}
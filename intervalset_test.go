@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func newIntervals(pairs ...int) *IntervalSet[int, Compare[int]] {
+	s := NewIntervalSet[int, Compare[int]](Cmp[int])
+	for i := 0; i < len(pairs); i += 2 {
+		s.Insert(pairs[i], pairs[i+1])
+	}
+	return s
+}
+
+// points returns the set of integers within s, between 0 and 100
+func points(s *IntervalSet[int, Compare[int]]) *Set[int] {
+	result := New[int](0)
+	for i := 0; i < 100; i++ {
+		if s.Contains(i) {
+			result.Insert(i)
+		}
+	}
+	return result
+}
+
+func TestIntervalSet_Insert(t *testing.T) {
+	s := NewIntervalSet[int, Compare[int]](Cmp[int])
+	must.True(t, s.Empty())
+
+	must.True(t, s.Insert(10, 20))
+	must.False(t, s.Insert(12, 15))
+	must.False(t, s.Insert(10, 20))
+	must.False(t, s.Insert(5, 5))
+	must.False(t, s.Insert(9, 3))
+	must.Eq(t, "[[10, 20)]", s.String())
+
+	// disjoint
+	must.True(t, s.Insert(30, 40))
+	must.True(t, s.Insert(0, 5))
+	must.Eq(t, "[[0, 5) [10, 20) [30, 40)]", s.String())
+
+	// adjacent on either side
+	must.True(t, s.Insert(20, 22))
+	must.True(t, s.Insert(8, 10))
+	must.Eq(t, "[[0, 5) [8, 22) [30, 40)]", s.String())
+
+	// overlapping several
+	must.True(t, s.Insert(3, 31))
+	must.Eq(t, "[[0, 40)]", s.String())
+	must.Eq(t, 1, s.Size())
+}
+
+func TestIntervalSet_Remove(t *testing.T) {
+	s := newIntervals(0, 10, 20, 30, 40, 50)
+
+	must.False(t, s.Remove(10, 20))
+	must.False(t, s.Remove(5, 5))
+
+	// split one interval
+	must.True(t, s.Remove(3, 6))
+	must.Eq(t, "[[0, 3) [6, 10) [20, 30) [40, 50)]", s.String())
+
+	// trim the ends of two intervals and drop the one between
+	must.True(t, s.Remove(8, 45))
+	must.Eq(t, "[[0, 3) [6, 8) [45, 50)]", s.String())
+
+	// remove exactly
+	must.True(t, s.Remove(45, 50))
+	must.True(t, s.Remove(-10, 100))
+	must.True(t, s.Empty())
+}
+
+func TestIntervalSet_Contains(t *testing.T) {
+	s := newIntervals(0, 10, 20, 30)
+	must.True(t, s.Contains(0))
+	must.True(t, s.Contains(9))
+	must.False(t, s.Contains(10))
+	must.False(t, s.Contains(-1))
+	must.True(t, s.Contains(25))
+	must.False(t, s.Contains(30))
+
+	must.True(t, s.ContainsRange(2, 8))
+	must.True(t, s.ContainsRange(20, 30))
+	must.False(t, s.ContainsRange(5, 25))
+	must.True(t, s.ContainsRange(15, 15))
+
+	must.True(t, s.Overlaps(5, 25))
+	must.True(t, s.Overlaps(29, 40))
+	must.False(t, s.Overlaps(10, 20))
+	must.False(t, s.Overlaps(30, 40))
+	must.False(t, s.Overlaps(5, 5))
+}
+
+func TestIntervalSet_Gaps(t *testing.T) {
+	s := newIntervals(10, 20, 30, 40)
+
+	must.Eq(t, []Interval[int]{{0, 10}, {20, 30}, {40, 50}}, s.Gaps(0, 50))
+	must.Eq(t, []Interval[int]{{20, 30}}, s.Gaps(15, 35))
+	must.Eq(t, []Interval[int]{{22, 25}}, s.Gaps(22, 25))
+	must.SliceEmpty(t, s.Gaps(12, 18))
+	must.SliceEmpty(t, s.Gaps(30, 30))
+	must.Eq(t, []Interval[int]{{0, 100}}, NewIntervalSet[int, Compare[int]](Cmp[int]).Gaps(0, 100))
+}
+
+func TestIntervalSet_Algebra(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	random := func() *IntervalSet[int, Compare[int]] {
+		s := NewIntervalSet[int, Compare[int]](Cmp[int])
+		for i := 0; i < 8; i++ {
+			lo := rng.Intn(100)
+			hi := lo + rng.Intn(15)
+			if rng.Intn(3) == 0 {
+				s.Remove(lo, hi)
+			} else {
+				s.Insert(lo, hi)
+			}
+		}
+		return s
+	}
+
+	for i := 0; i < 200; i++ {
+		a, b := random(), random()
+		pa, pb := points(a), points(b)
+
+		must.True(t, points(a.Union(b)).Equal(pa.Union(pb)))
+		must.True(t, points(a.Intersect(b)).Equal(pa.Intersect(pb)))
+		must.True(t, points(a.Difference(b)).Equal(pa.Difference(pb)))
+
+		// results are always fully coalesced
+		for _, s := range []*IntervalSet[int, Compare[int]]{a.Union(b), a.Intersect(b), a.Difference(b)} {
+			intervals := s.Intervals()
+			for k := 1; k < len(intervals); k++ {
+				must.Less(t, intervals[k].Lo, intervals[k-1].Hi)
+			}
+		}
+	}
+}
+
+func TestIntervalSet_Equal(t *testing.T) {
+	a := newIntervals(0, 5, 5, 10)
+	must.True(t, a.Equal(newIntervals(0, 10)))
+	must.False(t, a.Equal(newIntervals(0, 9)))
+
+	b := a.Copy()
+	b.Remove(3, 4)
+	must.False(t, a.Equal(b))
+	must.True(t, a.Contains(3))
+}
+
+func TestIntervalSet_ForEach(t *testing.T) {
+	s := IntervalSetFrom[int, Compare[int]]([]Interval[int]{{20, 30}, {0, 5}, {40, 45}}, Cmp[int])
+	var visited []Interval[int]
+	s.ForEach(func(interval Interval[int]) bool {
+		visited = append(visited, interval)
+		return len(visited) < 2
+	})
+	must.Eq(t, []Interval[int]{{0, 5}, {20, 30}}, visited)
+}
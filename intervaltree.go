@@ -0,0 +1,328 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+)
+
+// IntervalTree is a set of half-open intervals of values of type T which,
+// unlike an IntervalSet, are stored as they are inserted rather than being
+// coalesced, so that intervals may overlap one another. An IntervalTree
+// answers which intervals contain a point (Stab) and which intervals overlap
+// a range (Overlapping), such as which maintenance windows cover a moment, or
+// which reservations conflict with a new one.
+//
+// The underlying data structure is an AVL tree ordered by the lower then
+// upper bound of each interval, in which each node is augmented with the
+// greatest upper bound in its subtree. Insert and Remove take O(log n) time,
+// and queries take O(log n + m) time to find m intervals.
+//
+// https://en.wikipedia.org/wiki/Interval_tree#Augmented_tree
+//
+// Not thread safe, and not safe for concurrent modification.
+type IntervalTree[T any, C Compare[T]] struct {
+	comparison C
+	root       *intervalNode[T]
+	size       int
+}
+
+type intervalNode[T any] struct {
+	interval    Interval[T]
+	hi          T // the greatest upper bound of the subtree
+	height      int
+	left, right *intervalNode[T]
+}
+
+// NewIntervalTree creates a new empty IntervalTree of type T, comparing values
+// via C.
+//
+// C is an implementation of Compare[T]. For builtin types, Cmp provides a
+// convenient Compare implementation.
+func NewIntervalTree[T any, C Compare[T]](compare C) *IntervalTree[T, C] {
+	return &IntervalTree[T, C]{
+		comparison: compare,
+	}
+}
+
+// IntervalTreeFrom creates a new IntervalTree containing each interval in
+// intervals.
+//
+// C is an implementation of Compare[T]. For builtin types, Cmp provides a
+// convenient Compare implementation.
+func IntervalTreeFrom[T any, C Compare[T]](intervals []Interval[T], compare C) *IntervalTree[T, C] {
+	t := NewIntervalTree[T](compare)
+	for _, interval := range intervals {
+		t.Insert(interval.Lo, interval.Hi)
+	}
+	return t
+}
+
+// compareIntervals orders intervals by their lower then upper bounds.
+func (t *IntervalTree[T, C]) compareIntervals(a, b Interval[T]) int {
+	if c := t.comparison(a.Lo, b.Lo); c != 0 {
+		return c
+	}
+	return t.comparison(a.Hi, b.Hi)
+}
+
+// Insert the interval [lo, hi) into t. If lo is not less than hi, t is not
+// modified.
+//
+// Return true if t was modified (the interval was not already in t), false otherwise.
+func (t *IntervalTree[T, C]) Insert(lo, hi T) bool {
+	if t.comparison(lo, hi) >= 0 {
+		return false
+	}
+	inserted := false
+	t.root = t.insert(t.root, Interval[T]{Lo: lo, Hi: hi}, &inserted)
+	if inserted {
+		t.size++
+	}
+	return inserted
+}
+
+func (t *IntervalTree[T, C]) insert(n *intervalNode[T], interval Interval[T], inserted *bool) *intervalNode[T] {
+	if n == nil {
+		*inserted = true
+		return &intervalNode[T]{interval: interval, hi: interval.Hi, height: 1}
+	}
+	switch c := t.compareIntervals(interval, n.interval); {
+	case c < 0:
+		n.left = t.insert(n.left, interval, inserted)
+	case c > 0:
+		n.right = t.insert(n.right, interval, inserted)
+	default:
+		return n
+	}
+	return t.rebalance(n)
+}
+
+// Remove the interval [lo, hi) from t. Only an interval with exactly these
+// bounds is removed; use RemoveOverlapping to remove every interval
+// overlapping a range.
+//
+// Return true if t was modified (the interval was in t), false otherwise.
+func (t *IntervalTree[T, C]) Remove(lo, hi T) bool {
+	removed := false
+	t.root = t.remove(t.root, Interval[T]{Lo: lo, Hi: hi}, &removed)
+	if removed {
+		t.size--
+	}
+	return removed
+}
+
+func (t *IntervalTree[T, C]) remove(n *intervalNode[T], interval Interval[T], removed *bool) *intervalNode[T] {
+	if n == nil {
+		return nil
+	}
+	switch c := t.compareIntervals(interval, n.interval); {
+	case c < 0:
+		n.left = t.remove(n.left, interval, removed)
+	case c > 0:
+		n.right = t.remove(n.right, interval, removed)
+	default:
+		*removed = true
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		// replace n with its successor, the minimum of its right subtree
+		successor := n.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		n.interval = successor.interval
+		n.right = t.remove(n.right, successor.interval, new(bool))
+	}
+	return t.rebalance(n)
+}
+
+// RemoveOverlapping removes every interval of t that overlaps [lo, hi).
+//
+// Return true if t was modified (any interval overlapped [lo, hi)), false otherwise.
+func (t *IntervalTree[T, C]) RemoveOverlapping(lo, hi T) bool {
+	overlapping := t.Overlapping(lo, hi)
+	for _, interval := range overlapping {
+		t.Remove(interval.Lo, interval.Hi)
+	}
+	return len(overlapping) > 0
+}
+
+// Contains returns whether the interval [lo, hi) is in t.
+func (t *IntervalTree[T, C]) Contains(lo, hi T) bool {
+	interval := Interval[T]{Lo: lo, Hi: hi}
+	n := t.root
+	for n != nil {
+		switch c := t.compareIntervals(interval, n.interval); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Stab returns the intervals of t that contain point, in ascending order.
+func (t *IntervalTree[T, C]) Stab(point T) []Interval[T] {
+	var result []Interval[T]
+	t.each(t.root, func(lo T) bool {
+		return t.comparison(lo, point) <= 0
+	}, func(hi T) bool {
+		return t.comparison(hi, point) > 0
+	}, func(interval Interval[T]) bool {
+		result = append(result, interval)
+		return true
+	})
+	return result
+}
+
+// Overlapping returns the intervals of t that overlap [lo, hi), in ascending
+// order.
+func (t *IntervalTree[T, C]) Overlapping(lo, hi T) []Interval[T] {
+	var result []Interval[T]
+	t.overlapping(lo, hi, func(interval Interval[T]) bool {
+		result = append(result, interval)
+		return true
+	})
+	return result
+}
+
+// Overlaps returns whether any interval of t overlaps [lo, hi).
+func (t *IntervalTree[T, C]) Overlaps(lo, hi T) bool {
+	found := false
+	t.overlapping(lo, hi, func(Interval[T]) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+func (t *IntervalTree[T, C]) overlapping(lo, hi T, visit func(Interval[T]) bool) {
+	if t.comparison(lo, hi) >= 0 {
+		return
+	}
+	t.each(t.root, func(start T) bool {
+		return t.comparison(start, hi) < 0
+	}, func(end T) bool {
+		return t.comparison(end, lo) > 0
+	}, visit)
+}
+
+// each calls visit in order for each interval in the subtree of n whose lower
+// bound satisfies begins and whose upper bound satisfies ends. Since intervals
+// are ordered by their lower bounds, once one lower bound fails begins every
+// later one does too; and a subtree whose greatest upper bound fails ends
+// contains no interval satisfying it.
+func (t *IntervalTree[T, C]) each(n *intervalNode[T], begins, ends func(T) bool, visit func(Interval[T]) bool) bool {
+	if n == nil || !ends(n.hi) {
+		return true
+	}
+	if !t.each(n.left, begins, ends, visit) {
+		return false
+	}
+	if !begins(n.interval.Lo) {
+		return false
+	}
+	if ends(n.interval.Hi) && !visit(n.interval) {
+		return false
+	}
+	return t.each(n.right, begins, ends, visit)
+}
+
+// Size returns the number of intervals in t.
+func (t *IntervalTree[T, C]) Size() int {
+	return t.size
+}
+
+// Empty returns true if t contains no intervals, false otherwise.
+func (t *IntervalTree[T, C]) Empty() bool {
+	return t.size == 0
+}
+
+// Intervals returns the intervals of t in ascending order of their lower, then
+// upper bounds.
+func (t *IntervalTree[T, C]) Intervals() []Interval[T] {
+	result := make([]Interval[T], 0, t.size)
+	t.ForEach(func(interval Interval[T]) bool {
+		result = append(result, interval)
+		return true
+	})
+	return result
+}
+
+// ForEach calls visit for each interval of t in ascending order. Iteration
+// stops early if visit returns false.
+func (t *IntervalTree[T, C]) ForEach(visit func(interval Interval[T]) bool) {
+	all := func(T) bool { return true }
+	t.each(t.root, all, all, visit)
+}
+
+// String creates a string representation of t, using "%v" printf formatting to
+// transform each bound into a string. The result contains intervals in
+// ascending order.
+func (t *IntervalTree[T, C]) String() string {
+	return fmt.Sprintf("%v", t.Intervals())
+}
+
+func intervalHeight[T any](n *intervalNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// update recomputes the height and greatest upper bound of n from its children.
+func (t *IntervalTree[T, C]) update(n *intervalNode[T]) {
+	n.height = 1 + max(intervalHeight(n.left), intervalHeight(n.right))
+	n.hi = n.interval.Hi
+	if n.left != nil && t.comparison(n.left.hi, n.hi) > 0 {
+		n.hi = n.left.hi
+	}
+	if n.right != nil && t.comparison(n.right.hi, n.hi) > 0 {
+		n.hi = n.right.hi
+	}
+}
+
+func (t *IntervalTree[T, C]) rotateLeft(n *intervalNode[T]) *intervalNode[T] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	t.update(n)
+	t.update(r)
+	return r
+}
+
+func (t *IntervalTree[T, C]) rotateRight(n *intervalNode[T]) *intervalNode[T] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	t.update(n)
+	t.update(l)
+	return l
+}
+
+// rebalance restores the AVL property at n after one of its subtrees changed
+// height by at most one, returning the new root of the subtree.
+func (t *IntervalTree[T, C]) rebalance(n *intervalNode[T]) *intervalNode[T] {
+	t.update(n)
+	switch balance := intervalHeight(n.left) - intervalHeight(n.right); {
+	case balance > 1:
+		if intervalHeight(n.left.left) < intervalHeight(n.left.right) {
+			n.left = t.rotateLeft(n.left)
+		}
+		return t.rotateRight(n)
+	case balance < -1:
+		if intervalHeight(n.right.right) < intervalHeight(n.right.left) {
+			n.right = t.rotateRight(n.right)
+		}
+		return t.rotateLeft(n)
+	}
+	return n
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestIntervalTree_Insert(t *testing.T) {
+	tree := NewIntervalTree[int, Compare[int]](Cmp[int])
+	must.True(t, tree.Empty())
+
+	must.True(t, tree.Insert(1, 5))
+	must.True(t, tree.Insert(3, 8))
+	must.True(t, tree.Insert(1, 3))
+	must.False(t, tree.Insert(1, 5))
+	must.False(t, tree.Insert(4, 4))
+	must.False(t, tree.Insert(5, 4))
+	must.Eq(t, 3, tree.Size())
+
+	must.True(t, tree.Contains(3, 8))
+	must.False(t, tree.Contains(3, 7))
+	must.Eq(t, "[[1, 3) [1, 5) [3, 8)]", tree.String())
+}
+
+func TestIntervalTree_Remove(t *testing.T) {
+	tree := IntervalTreeFrom[int, Compare[int]]([]Interval[int]{{1, 5}, {3, 8}, {10, 12}, {11, 20}}, Cmp[int])
+	must.False(t, tree.Remove(1, 4))
+	must.True(t, tree.Remove(3, 8))
+	must.False(t, tree.Remove(3, 8))
+	must.Eq(t, []Interval[int]{{1, 5}, {10, 12}, {11, 20}}, tree.Intervals())
+
+	must.True(t, tree.RemoveOverlapping(4, 11))
+	must.False(t, tree.RemoveOverlapping(4, 11))
+	must.Eq(t, []Interval[int]{{11, 20}}, tree.Intervals())
+}
+
+func TestIntervalTree_Stab(t *testing.T) {
+	windows := IntervalTreeFrom[int, Compare[int]]([]Interval[int]{{0, 10}, {5, 15}, {12, 20}, {30, 40}}, Cmp[int])
+	must.Eq(t, []Interval[int]{{0, 10}}, windows.Stab(0))
+	must.Eq(t, []Interval[int]{{0, 10}, {5, 15}}, windows.Stab(9))
+	must.Eq(t, []Interval[int]{{5, 15}}, windows.Stab(10))
+	must.Eq(t, []Interval[int]{{5, 15}, {12, 20}}, windows.Stab(14))
+	must.SliceEmpty(t, windows.Stab(20))
+	must.SliceEmpty(t, windows.Stab(-1))
+}
+
+func TestIntervalTree_Overlapping(t *testing.T) {
+	reservations := IntervalTreeFrom[int, Compare[int]]([]Interval[int]{{0, 10}, {5, 15}, {12, 20}, {30, 40}}, Cmp[int])
+	must.Eq(t, []Interval[int]{{5, 15}, {12, 20}}, reservations.Overlapping(10, 13))
+	must.Eq(t, []Interval[int]{{12, 20}}, reservations.Overlapping(15, 30))
+	must.SliceEmpty(t, reservations.Overlapping(20, 30))
+	must.SliceEmpty(t, reservations.Overlapping(13, 13))
+
+	must.True(t, reservations.Overlaps(39, 50))
+	must.False(t, reservations.Overlaps(40, 50))
+}
+
+func TestIntervalTree_random(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	tree := NewIntervalTree[int, Compare[int]](Cmp[int])
+	var expect []Interval[int]
+
+	for range 3_000 {
+		lo := rng.Intn(1000)
+		interval := Interval[int]{Lo: lo, Hi: lo + 1 + rng.Intn(50)}
+		i := slices.Index(expect, interval)
+		if rng.Intn(3) == 0 {
+			must.Eq(t, i >= 0, tree.Remove(interval.Lo, interval.Hi))
+			if i >= 0 {
+				expect = slices.Delete(expect, i, i+1)
+			}
+		} else {
+			must.Eq(t, i < 0, tree.Insert(interval.Lo, interval.Hi))
+			if i < 0 {
+				expect = append(expect, interval)
+			}
+		}
+	}
+	slices.SortFunc(expect, tree.compareIntervals)
+	must.Eq(t, expect, tree.Intervals())
+	must.Eq(t, len(expect), tree.Size())
+
+	// the tree is balanced
+	must.LessEq(t, 15, intervalHeight(tree.root))
+
+	for range 500 {
+		lo := rng.Intn(1100)
+		hi := lo + 1 + rng.Intn(30)
+		var overlapping, stabbed []Interval[int]
+		for _, interval := range expect {
+			if interval.Lo < hi && lo < interval.Hi {
+				overlapping = append(overlapping, interval)
+			}
+			if interval.Lo <= lo && lo < interval.Hi {
+				stabbed = append(stabbed, interval)
+			}
+		}
+		must.Eq(t, overlapping, tree.Overlapping(lo, hi))
+		must.Eq(t, stabbed, tree.Stab(lo))
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// IPSet is a set of IP addresses, built from individual addresses, CIDR
+// prefixes, and inclusive ranges of addresses. It is stored as an IntervalSet
+// of address ranges, so that adjacent and overlapping prefixes are aggregated
+// as they are inserted, and set operations are performed range by range.
+//
+// IPv4 and IPv6 addresses may be mixed in one IPSet, and never aggregate with
+// one another. IPv4-mapped IPv6 addresses are treated as IPv4 addresses, and
+// the zones of IPv6 addresses are ignored.
+//
+// Not thread safe, and not safe for concurrent modification.
+type IPSet struct {
+	ranges *IntervalSet[ipBound, Compare[ipBound]]
+}
+
+// ipBound is a bound of an interval of addresses. It is either an address, or
+// the position just past the last address of the IPv4 or IPv6 address space,
+// which has no next address.
+type ipBound struct {
+	addr netip.Addr
+	past bool
+}
+
+// compareIPBounds orders bounds by address, with the position just past an
+// address after the address itself.
+func compareIPBounds(a, b ipBound) int {
+	if c := a.addr.Compare(b.addr); c != 0 {
+		return c
+	}
+	switch {
+	case a.past == b.past:
+		return 0
+	case a.past:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// boundAfter returns the exclusive upper bound of an interval whose last
+// address is addr.
+func boundAfter(addr netip.Addr) ipBound {
+	if next := addr.Next(); next.IsValid() {
+		return ipBound{addr: next}
+	}
+	return ipBound{addr: addr, past: true}
+}
+
+// last returns the last address of an interval whose exclusive upper bound
+// is b.
+func (b ipBound) last() netip.Addr {
+	if b.past {
+		return b.addr
+	}
+	return b.addr.Prev()
+}
+
+// NewIPSet creates a new empty IPSet.
+func NewIPSet() *IPSet {
+	return &IPSet{
+		ranges: NewIntervalSet[ipBound, Compare[ipBound]](compareIPBounds),
+	}
+}
+
+// IPSetFrom creates a new IPSet containing each prefix in prefixes. Invalid
+// prefixes are ignored.
+func IPSetFrom(prefixes []netip.Prefix) *IPSet {
+	s := NewIPSet()
+	for _, prefix := range prefixes {
+		s.InsertPrefix(prefix)
+	}
+	return s
+}
+
+// IPSetOf creates a new IPSet containing each prefix passed as an argument.
+// Invalid prefixes are ignored.
+func IPSetOf(prefixes ...netip.Prefix) *IPSet {
+	return IPSetFrom(prefixes)
+}
+
+// prefixRange returns the first and last addresses of prefix, and whether
+// prefix is valid.
+func prefixRange(prefix netip.Prefix) (netip.Addr, netip.Addr, bool) {
+	if !prefix.IsValid() {
+		return netip.Addr{}, netip.Addr{}, false
+	}
+	if addr := prefix.Addr(); addr.Is4In6() && prefix.Bits() >= 96 {
+		prefix = netip.PrefixFrom(addr.Unmap(), prefix.Bits()-96)
+	}
+	prefix = prefix.Masked()
+	first := prefix.Addr()
+	bytes := first.AsSlice()
+	for i := prefix.Bits(); i < len(bytes)*8; i++ {
+		bytes[i/8] |= 0x80 >> (i % 8)
+	}
+	last, _ := netip.AddrFromSlice(bytes)
+	return first, last, true
+}
+
+// ipInterval returns the interval of addresses from first to last inclusive,
+// and whether that interval is valid and non-empty.
+func ipInterval(first, last netip.Addr) (ipBound, ipBound, bool) {
+	first, last = first.Unmap().WithZone(""), last.Unmap().WithZone("")
+	if !first.IsValid() || first.BitLen() != last.BitLen() || first.Compare(last) > 0 {
+		return ipBound{}, ipBound{}, false
+	}
+	return ipBound{addr: first}, boundAfter(last), true
+}
+
+// InsertAddr will insert addr into s.
+//
+// Return true if s was modified (addr was not already in s), false otherwise.
+func (s *IPSet) InsertAddr(addr netip.Addr) bool {
+	return s.InsertRange(addr, addr)
+}
+
+// InsertPrefix will insert each address of prefix into s. An invalid prefix
+// is ignored.
+//
+// Return true if s was modified (at least one address was not already in s), false otherwise.
+func (s *IPSet) InsertPrefix(prefix netip.Prefix) bool {
+	first, last, ok := prefixRange(prefix)
+	return ok && s.InsertRange(first, last)
+}
+
+// InsertRange will insert each address from first to last inclusive into s.
+// If first and last are of different address families, or first is after
+// last, s is not modified.
+//
+// Return true if s was modified (at least one address was not already in s), false otherwise.
+func (s *IPSet) InsertRange(first, last netip.Addr) bool {
+	lo, hi, ok := ipInterval(first, last)
+	return ok && s.ranges.Insert(lo, hi)
+}
+
+// InsertSet will insert each address of o into s.
+//
+// Return true if s was modified (at least one address of o was not already in s), false otherwise.
+func (s *IPSet) InsertSet(o *IPSet) bool {
+	return s.ranges.InsertSet(o.ranges)
+}
+
+// RemoveAddr will remove addr from s.
+//
+// Return true if s was modified (addr was present), false otherwise.
+func (s *IPSet) RemoveAddr(addr netip.Addr) bool {
+	return s.RemoveRange(addr, addr)
+}
+
+// RemovePrefix will remove each address of prefix from s. An invalid prefix
+// is ignored.
+//
+// Return true if s was modified (any address was present), false otherwise.
+func (s *IPSet) RemovePrefix(prefix netip.Prefix) bool {
+	first, last, ok := prefixRange(prefix)
+	return ok && s.RemoveRange(first, last)
+}
+
+// RemoveRange will remove each address from first to last inclusive from s.
+// If first and last are of different address families, or first is after
+// last, s is not modified.
+//
+// Return true if s was modified (any address was present), false otherwise.
+func (s *IPSet) RemoveRange(first, last netip.Addr) bool {
+	lo, hi, ok := ipInterval(first, last)
+	return ok && s.ranges.Remove(lo, hi)
+}
+
+// RemoveSet will remove each address of o from s.
+//
+// Return true if s was modified (any address of o was present in s), false otherwise.
+func (s *IPSet) RemoveSet(o *IPSet) bool {
+	return s.ranges.RemoveSet(o.ranges)
+}
+
+// Contains returns whether addr is present in s.
+func (s *IPSet) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap().WithZone("")
+	return addr.IsValid() && s.ranges.Contains(ipBound{addr: addr})
+}
+
+// ContainsPrefix returns whether every address of prefix is present in s.
+func (s *IPSet) ContainsPrefix(prefix netip.Prefix) bool {
+	first, last, ok := prefixRange(prefix)
+	if !ok {
+		return false
+	}
+	lo, hi, _ := ipInterval(first, last)
+	return s.ranges.ContainsRange(lo, hi)
+}
+
+// OverlapsPrefix returns whether any address of prefix is present in s.
+func (s *IPSet) OverlapsPrefix(prefix netip.Prefix) bool {
+	first, last, ok := prefixRange(prefix)
+	if !ok {
+		return false
+	}
+	lo, hi, _ := ipInterval(first, last)
+	return s.ranges.Overlaps(lo, hi)
+}
+
+// Empty returns true if s contains no addresses, false otherwise.
+func (s *IPSet) Empty() bool {
+	return s.ranges.Empty()
+}
+
+// Union returns a set that contains all addresses of s and o combined.
+func (s *IPSet) Union(o *IPSet) *IPSet {
+	return &IPSet{ranges: s.ranges.Union(o.ranges)}
+}
+
+// Difference returns a set that contains addresses of s that are not in o.
+func (s *IPSet) Difference(o *IPSet) *IPSet {
+	return &IPSet{ranges: s.ranges.Difference(o.ranges)}
+}
+
+// Intersect returns a set that contains addresses that are present in both s and o.
+func (s *IPSet) Intersect(o *IPSet) *IPSet {
+	return &IPSet{ranges: s.ranges.Intersect(o.ranges)}
+}
+
+// Equal returns whether s and o contain the same addresses.
+func (s *IPSet) Equal(o *IPSet) bool {
+	return s.ranges.Equal(o.ranges)
+}
+
+// Copy creates a copy of s.
+func (s *IPSet) Copy() *IPSet {
+	return &IPSet{ranges: s.ranges.Copy()}
+}
+
+// Ranges returns the first and last addresses of each maximal range of
+// consecutive addresses in s, in ascending order, with IPv4 ranges first.
+func (s *IPSet) Ranges() [][2]netip.Addr {
+	result := make([][2]netip.Addr, 0, s.ranges.Size())
+	s.ranges.ForEach(func(interval Interval[ipBound]) bool {
+		result = append(result, [2]netip.Addr{interval.Lo.addr, interval.Hi.last()})
+		return true
+	})
+	return result
+}
+
+// Prefixes returns the fewest CIDR prefixes that together cover exactly the
+// addresses in s, in ascending order, with IPv4 prefixes first.
+func (s *IPSet) Prefixes() []netip.Prefix {
+	var result []netip.Prefix
+	for _, r := range s.Ranges() {
+		result = appendPrefixes(result, r[0], r[1])
+	}
+	return result
+}
+
+// appendPrefixes appends the fewest prefixes covering first through last to
+// prefixes, by repeatedly taking the largest prefix that starts at first and
+// does not extend past last.
+func appendPrefixes(prefixes []netip.Prefix, first, last netip.Addr) []netip.Prefix {
+	for {
+		for bits := 0; bits <= first.BitLen(); bits++ {
+			prefix := netip.PrefixFrom(first, bits)
+			lo, hi, _ := prefixRange(prefix)
+			if lo != first || hi.Compare(last) > 0 {
+				continue
+			}
+			prefixes = append(prefixes, prefix)
+			if hi == last {
+				return prefixes
+			}
+			first = hi.Next()
+			break
+		}
+	}
+}
+
+// String creates a string representation of s, as the CIDR prefixes that
+// together cover exactly the addresses in s.
+func (s *IPSet) String() string {
+	prefixes := s.Prefixes()
+	l := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		l = append(l, prefix.String())
+	}
+	return fmt.Sprintf("%s", l)
+}
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func prefixes(cidrs ...string) []netip.Prefix {
+	result := make([]netip.Prefix, len(cidrs))
+	for i, cidr := range cidrs {
+		result[i] = netip.MustParsePrefix(cidr)
+	}
+	return result
+}
+
+func ipSet(cidrs ...string) *IPSet {
+	return IPSetFrom(prefixes(cidrs...))
+}
+
+func TestIPSet_InsertPrefix(t *testing.T) {
+	s := NewIPSet()
+	must.True(t, s.Empty())
+	must.True(t, s.InsertPrefix(netip.MustParsePrefix("10.0.0.0/25")))
+	must.False(t, s.InsertPrefix(netip.MustParsePrefix("10.0.0.64/26")))
+	must.True(t, s.InsertPrefix(netip.MustParsePrefix("10.0.0.128/25")))
+	must.False(t, s.InsertPrefix(netip.Prefix{}))
+
+	// aggregated into one prefix
+	must.Eq(t, prefixes("10.0.0.0/24"), s.Prefixes())
+
+	// host bits are masked
+	must.True(t, s.InsertPrefix(netip.MustParsePrefix("192.168.1.77/24")))
+	must.Eq(t, "[10.0.0.0/24 192.168.1.0/24]", s.String())
+}
+
+func TestIPSet_InsertAddr(t *testing.T) {
+	s := NewIPSet()
+	for _, addr := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5", "10.0.0.6"} {
+		must.True(t, s.InsertAddr(netip.MustParseAddr(addr)))
+	}
+	must.False(t, s.InsertAddr(netip.MustParseAddr("10.0.0.3")))
+	must.Eq(t, prefixes("10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/31", "10.0.0.6/32"), s.Prefixes())
+	must.Eq(t, [][2]netip.Addr{{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.6")}}, s.Ranges())
+}
+
+func TestIPSet_InsertRange(t *testing.T) {
+	s := NewIPSet()
+	a, b := netip.MustParseAddr("10.0.0.10"), netip.MustParseAddr("10.0.0.20")
+	must.False(t, s.InsertRange(b, a))
+	must.False(t, s.InsertRange(a, netip.MustParseAddr("::1")))
+	must.True(t, s.InsertRange(a, b))
+	must.True(t, s.Contains(a))
+	must.True(t, s.Contains(b))
+	must.False(t, s.Contains(b.Next()))
+	must.Eq(t, prefixes("10.0.0.10/31", "10.0.0.12/30", "10.0.0.16/30", "10.0.0.20/32"), s.Prefixes())
+}
+
+func TestIPSet_edges(t *testing.T) {
+	t.Run("whole space", func(t *testing.T) {
+		s := ipSet("0.0.0.0/0", "::/0")
+		must.True(t, s.Contains(netip.MustParseAddr("255.255.255.255")))
+		must.True(t, s.Contains(netip.MustParseAddr("0.0.0.0")))
+		must.True(t, s.Contains(netip.MustParseAddr("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")))
+		must.Eq(t, prefixes("0.0.0.0/0", "::/0"), s.Prefixes())
+	})
+
+	t.Run("last address", func(t *testing.T) {
+		s := ipSet("255.255.255.254/32")
+		must.True(t, s.InsertAddr(netip.MustParseAddr("255.255.255.255")))
+		must.Eq(t, prefixes("255.255.255.254/31"), s.Prefixes())
+		must.True(t, s.RemoveAddr(netip.MustParseAddr("255.255.255.255")))
+		must.Eq(t, prefixes("255.255.255.254/32"), s.Prefixes())
+	})
+
+	t.Run("families do not aggregate", func(t *testing.T) {
+		s := ipSet("255.255.255.255/32", "::/128")
+		must.Len(t, 2, s.Ranges())
+	})
+
+	t.Run("mapped", func(t *testing.T) {
+		s := ipSet("::ffff:10.0.0.0/104")
+		must.Eq(t, prefixes("10.0.0.0/8"), s.Prefixes())
+		must.True(t, s.Contains(netip.MustParseAddr("10.1.2.3")))
+		must.True(t, s.Contains(netip.MustParseAddr("::ffff:10.1.2.3")))
+	})
+
+	t.Run("zone", func(t *testing.T) {
+		s := ipSet("fe80::/64")
+		must.True(t, s.Contains(netip.MustParseAddr("fe80::1%eth0")))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		s := ipSet("10.0.0.0/8")
+		must.False(t, s.Contains(netip.Addr{}))
+		must.False(t, s.ContainsPrefix(netip.Prefix{}))
+		must.False(t, s.OverlapsPrefix(netip.Prefix{}))
+	})
+}
+
+func TestIPSet_Remove(t *testing.T) {
+	s := ipSet("10.0.0.0/8")
+	must.True(t, s.RemovePrefix(netip.MustParsePrefix("10.128.0.0/9")))
+	must.False(t, s.RemovePrefix(netip.MustParsePrefix("10.128.0.0/16")))
+	must.True(t, s.RemoveAddr(netip.MustParseAddr("10.0.0.0")))
+	must.Eq(t, prefixes(
+		"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/30", "10.0.0.8/29", "10.0.0.16/28",
+		"10.0.0.32/27", "10.0.0.64/26", "10.0.0.128/25", "10.0.1.0/24", "10.0.2.0/23",
+		"10.0.4.0/22", "10.0.8.0/21", "10.0.16.0/20", "10.0.32.0/19", "10.0.64.0/18",
+		"10.0.128.0/17", "10.1.0.0/16", "10.2.0.0/15", "10.4.0.0/14", "10.8.0.0/13",
+		"10.16.0.0/12", "10.32.0.0/11", "10.64.0.0/10",
+	), s.Prefixes())
+	must.True(t, s.RemoveRange(netip.MustParseAddr("10.0.0.0"), netip.MustParseAddr("10.127.255.255")))
+	must.True(t, s.Empty())
+}
+
+func TestIPSet_Contains(t *testing.T) {
+	s := ipSet("10.0.0.0/16", "2001:db8::/32")
+	must.True(t, s.Contains(netip.MustParseAddr("10.0.255.255")))
+	must.False(t, s.Contains(netip.MustParseAddr("10.1.0.0")))
+	must.True(t, s.Contains(netip.MustParseAddr("2001:db8::1")))
+	must.False(t, s.Contains(netip.MustParseAddr("2001:db9::")))
+
+	must.True(t, s.ContainsPrefix(netip.MustParsePrefix("10.0.4.0/24")))
+	must.False(t, s.ContainsPrefix(netip.MustParsePrefix("10.0.0.0/15")))
+	must.True(t, s.OverlapsPrefix(netip.MustParsePrefix("10.0.0.0/15")))
+	must.False(t, s.OverlapsPrefix(netip.MustParsePrefix("10.2.0.0/16")))
+}
+
+func TestIPSet_Algebra(t *testing.T) {
+	a := ipSet("10.0.0.0/8", "192.168.0.0/16", "2001:db8::/32")
+	b := ipSet("10.1.0.0/16", "172.16.0.0/12", "2001:db8:1::/48")
+
+	must.Eq(t, "[10.0.0.0/8 172.16.0.0/12 192.168.0.0/16 2001:db8::/32]", a.Union(b).String())
+	must.Eq(t, "[10.1.0.0/16 2001:db8:1::/48]", a.Intersect(b).String())
+	must.Eq(t, prefixes(
+		"10.0.0.0/16", "10.2.0.0/15", "10.4.0.0/14", "10.8.0.0/13", "10.16.0.0/12",
+		"10.32.0.0/11", "10.64.0.0/10", "10.128.0.0/9", "192.168.0.0/16",
+		"2001:db8::/48", "2001:db8:2::/47", "2001:db8:4::/46", "2001:db8:8::/45",
+		"2001:db8:10::/44", "2001:db8:20::/43", "2001:db8:40::/42", "2001:db8:80::/41",
+		"2001:db8:100::/40", "2001:db8:200::/39", "2001:db8:400::/38", "2001:db8:800::/37",
+		"2001:db8:1000::/36", "2001:db8:2000::/35", "2001:db8:4000::/34", "2001:db8:8000::/33",
+	), a.Difference(b).Prefixes())
+
+	c := a.Copy()
+	must.True(t, c.InsertSet(b))
+	must.True(t, c.Equal(a.Union(b)))
+	must.True(t, c.RemoveSet(a))
+	must.True(t, c.Equal(b.Difference(a)))
+	must.False(t, a.Equal(c))
+}
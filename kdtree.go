@@ -0,0 +1,396 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+)
+
+// KDTree is a set of elements located at points in k-dimensional space, which
+// answers nearest neighbor queries and bounding box range queries without
+// scanning every element. The coordinates of an element are provided by a
+// coordinate function, so elements may be points such as [2]float64, or values
+// such as nodes that carry a location.
+//
+// The underlying data structure is a k-d tree, in which each level of the tree
+// splits the elements below it along the next axis. Removed elements are
+// marked rather than unlinked, and the tree is rebuilt in balance once too
+// many elements are marked. Subtrees made unbalanced by insertions are rebuilt
+// as in a scapegoat tree, so Insert and Remove take amortized O(log n) time,
+// and queries take O(log n) time on average.
+//
+// https://en.wikipedia.org/wiki/K-d_tree
+//
+// Not thread safe, and not safe for concurrent modification.
+type KDTree[T comparable] struct {
+	dims       int
+	coordinate func(item T, axis int) float64
+	root       *kdNode[T]
+	index      map[T]*kdNode[T]
+	removed    int // number of nodes marked as removed
+}
+
+// kdBalance is the greatest fraction of the nodes of a subtree of a KDTree
+// that one child of the subtree may hold before an insertion rebalances it.
+const kdBalance = 2.0 / 3.0
+
+type kdNode[T comparable] struct {
+	item        T
+	removed     bool
+	left, right *kdNode[T]
+}
+
+// NewKDTree creates a new empty KDTree of elements in dims dimensions, where
+// coordinate returns the coordinate of an element along an axis from 0 up to
+// dims.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func NewKDTree[T comparable](dims int, coordinate func(item T, axis int) float64) *KDTree[T] {
+	return &KDTree[T]{
+		dims:       max(1, dims),
+		coordinate: coordinate,
+		index:      make(map[T]*kdNode[T]),
+	}
+}
+
+// KDTreeFrom creates a new balanced KDTree of elements in dims dimensions
+// containing each item in items.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func KDTreeFrom[T comparable](items []T, dims int, coordinate func(item T, axis int) float64) *KDTree[T] {
+	t := NewKDTree(dims, coordinate)
+	t.index = make(map[T]*kdNode[T], len(items))
+	for _, item := range items {
+		t.index[item] = nil
+	}
+	t.rebuild()
+	return t
+}
+
+// Insert item into t.
+//
+// Return true if t was modified (item was not already in t), false otherwise.
+func (t *KDTree[T]) Insert(item T) bool {
+	if _, exists := t.index[item]; exists {
+		return false
+	}
+	node := &kdNode[T]{item: item}
+	t.index[item] = node
+
+	// path holds the link to each node from the root down to the new node
+	path := []**kdNode[T]{&t.root}
+	for n := t.root; n != nil; {
+		axis := (len(path) - 1) % t.dims
+		link := &n.right
+		if t.coordinate(item, axis) < t.coordinate(n.item, axis) {
+			link = &n.left
+		}
+		path = append(path, link)
+		n = *link
+	}
+	*path[len(path)-1] = node
+
+	// a path far deeper than that of a balanced tree is rebalanced by
+	// rebuilding the subtree of the scapegoat, the lowest ancestor on the path
+	// with one child much larger than the other
+	depth := len(path) - 1
+	nodes := len(t.index) + t.removed
+	if float64(depth) > math.Log(float64(nodes))/math.Log(1/kdBalance) {
+		size := 1
+		for d := depth - 1; d >= 0; d-- {
+			parent, child := *path[d], *path[d+1]
+			sibling := parent.left
+			if child == parent.left {
+				sibling = parent.right
+			}
+			total := size + sibling.count() + 1
+			if float64(size) > kdBalance*float64(total) {
+				t.rebuildAt(path[d], d)
+				break
+			}
+			size = total
+		}
+	}
+	return true
+}
+
+// InsertSlice will insert each item in items into t.
+//
+// Return true if t was modified (at least one item was not already in t), false otherwise.
+func (t *KDTree[T]) InsertSlice(items []T) bool {
+	modified := false
+	for _, item := range items {
+		if t.Insert(item) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// Remove item from t.
+//
+// Return true if t was modified (item was in t), false otherwise.
+func (t *KDTree[T]) Remove(item T) bool {
+	node, exists := t.index[item]
+	if !exists {
+		return false
+	}
+	delete(t.index, item)
+	node.removed = true
+	t.removed++
+
+	// a tree of mostly removed nodes is rebuilt
+	if t.removed > len(t.index) {
+		t.rebuild()
+	}
+	return true
+}
+
+// rebuild replaces the tree of t with a balanced tree of its elements.
+func (t *KDTree[T]) rebuild() {
+	items := make([]T, 0, len(t.index))
+	for item := range t.index {
+		items = append(items, item)
+	}
+	t.root = t.build(items, 0)
+	t.removed = 0
+}
+
+// rebuildAt replaces the subtree at link, whose root is at depth, with a
+// balanced tree of its elements, dropping any removed nodes.
+func (t *KDTree[T]) rebuildAt(link **kdNode[T], depth int) {
+	var items []T
+	var collect func(n *kdNode[T])
+	collect = func(n *kdNode[T]) {
+		if n == nil {
+			return
+		}
+		if n.removed {
+			t.removed--
+		} else {
+			items = append(items, n.item)
+		}
+		collect(n.left)
+		collect(n.right)
+	}
+	collect(*link)
+	*link = t.build(items, depth)
+}
+
+// count returns the number of nodes in the subtree of n, including removed
+// nodes.
+func (n *kdNode[T]) count() int {
+	if n == nil {
+		return 0
+	}
+	return 1 + n.left.count() + n.right.count()
+}
+
+// build returns a balanced tree of items, splitting them at the median along
+// the axis of depth.
+func (t *KDTree[T]) build(items []T, depth int) *kdNode[T] {
+	if len(items) == 0 {
+		return nil
+	}
+	axis := depth % t.dims
+	slices.SortFunc(items, func(a, b T) int {
+		return Cmp(t.coordinate(a, axis), t.coordinate(b, axis))
+	})
+	// elements equal to the median along the axis go to the right
+	m := len(items) / 2
+	x := t.coordinate(items[m], axis)
+	m = sort.Search(m, func(i int) bool {
+		return t.coordinate(items[i], axis) >= x
+	})
+
+	n := &kdNode[T]{item: items[m]}
+	t.index[n.item] = n
+	n.left = t.build(items[:m], depth+1)
+	n.right = t.build(items[m+1:], depth+1)
+	return n
+}
+
+// Contains returns whether item is present in t.
+func (t *KDTree[T]) Contains(item T) bool {
+	_, exists := t.index[item]
+	return exists
+}
+
+// Size returns the number of elements in t.
+func (t *KDTree[T]) Size() int {
+	return len(t.index)
+}
+
+// Empty returns true if t contains no elements, false otherwise.
+func (t *KDTree[T]) Empty() bool {
+	return len(t.index) == 0
+}
+
+// Nearest returns the element of t nearest to point by Euclidean distance,
+// and true. If t is empty, the zero value and false are returned. Of elements
+// at the same distance, which is returned is unspecified.
+//
+// Nearest panics if point does not have one coordinate per dimension of t.
+func (t *KDTree[T]) Nearest(point []float64) (T, bool) {
+	nearest := t.NearestN(point, 1)
+	if len(nearest) == 0 {
+		var zero T
+		return zero, false
+	}
+	return nearest[0], true
+}
+
+// NearestN returns the n elements of t nearest to point by Euclidean
+// distance, nearest first. If t has fewer than n elements, every element is
+// returned.
+//
+// NearestN panics if point does not have one coordinate per dimension of t.
+func (t *KDTree[T]) NearestN(point []float64, n int) []T {
+	t.check(point)
+	if n <= 0 {
+		return nil
+	}
+	type candidate struct {
+		item     T
+		distance float64
+	}
+	// the best candidates so far, nearest first
+	best := make([]candidate, 0, min(n, len(t.index)))
+	worst := func() float64 {
+		if len(best) < n {
+			return math.Inf(1)
+		}
+		return best[len(best)-1].distance
+	}
+
+	var search func(node *kdNode[T], depth int)
+	search = func(node *kdNode[T], depth int) {
+		if node == nil {
+			return
+		}
+		if !node.removed {
+			if d := t.distance(node.item, point); d < worst() {
+				i := sort.Search(len(best), func(i int) bool {
+					return best[i].distance > d
+				})
+				if len(best) == n {
+					best = best[:n-1]
+				}
+				best = slices.Insert(best, i, candidate{item: node.item, distance: d})
+			}
+		}
+
+		// search the side of the split containing point first, then the other
+		// side only if it may hold something nearer than the worst candidate
+		axis := depth % t.dims
+		delta := point[axis] - t.coordinate(node.item, axis)
+		near, far := node.left, node.right
+		if delta >= 0 {
+			near, far = far, near
+		}
+		search(near, depth+1)
+		if delta*delta < worst() {
+			search(far, depth+1)
+		}
+	}
+	search(t.root, 0)
+
+	result := make([]T, len(best))
+	for i, c := range best {
+		result[i] = c.item
+	}
+	return result
+}
+
+// distance returns the square of the Euclidean distance between item and point.
+func (t *KDTree[T]) distance(item T, point []float64) float64 {
+	sum := 0.0
+	for axis, x := range point {
+		d := t.coordinate(item, axis) - x
+		sum += d * d
+	}
+	return sum
+}
+
+// Range returns the elements of t within the bounding box from lo to hi,
+// inclusive of each bound, in no particular order.
+//
+// Range panics if lo or hi do not have one coordinate per dimension of t.
+func (t *KDTree[T]) Range(lo, hi []float64) []T {
+	t.check(lo)
+	t.check(hi)
+	result := make([]T, 0)
+
+	var search func(node *kdNode[T], depth int)
+	search = func(node *kdNode[T], depth int) {
+		if node == nil {
+			return
+		}
+		if !node.removed && t.within(node.item, lo, hi) {
+			result = append(result, node.item)
+		}
+		axis := depth % t.dims
+		x := t.coordinate(node.item, axis)
+		if lo[axis] < x {
+			search(node.left, depth+1)
+		}
+		if hi[axis] >= x {
+			search(node.right, depth+1)
+		}
+	}
+	search(t.root, 0)
+	return result
+}
+
+// within returns whether item is within the bounding box from lo to hi.
+func (t *KDTree[T]) within(item T, lo, hi []float64) bool {
+	for axis := range lo {
+		if x := t.coordinate(item, axis); x < lo[axis] || x > hi[axis] {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *KDTree[T]) check(point []float64) {
+	if len(point) != t.dims {
+		panic(fmt.Sprintf("kdtree: point has %d coordinates, want %d", len(point), t.dims))
+	}
+}
+
+// Slice creates a copy of t as a slice. Elements are in no particular order.
+func (t *KDTree[T]) Slice() []T {
+	result := make([]T, 0, len(t.index))
+	for item := range t.index {
+		result = append(result, item)
+	}
+	return result
+}
+
+// ForEach calls visit for each element of t, in no particular order.
+// Iteration stops early if visit returns false.
+func (t *KDTree[T]) ForEach(visit func(item T) bool) {
+	for item := range t.index {
+		if !visit(item) {
+			return
+		}
+	}
+}
+
+// String creates a string representation of t, using "%v" printf formatting to transform
+// each element into a string. The result contains elements sorted by their lexical
+// string order.
+func (t *KDTree[T]) String() string {
+	l := make([]string, 0, len(t.index))
+	for item := range t.index {
+		l = append(l, fmt.Sprintf("%v", item))
+	}
+	sort.Strings(l)
+	return fmt.Sprintf("%s", l)
+}
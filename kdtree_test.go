@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+type point [2]float64
+
+func pointCoordinate(p point, axis int) float64 {
+	return p[axis]
+}
+
+func TestKDTree_Insert(t *testing.T) {
+	tree := NewKDTree(2, pointCoordinate)
+	must.True(t, tree.Empty())
+
+	must.True(t, tree.Insert(point{1, 2}))
+	must.True(t, tree.Insert(point{3, 4}))
+	must.False(t, tree.Insert(point{1, 2}))
+	must.True(t, tree.InsertSlice([]point{{0, 0}, {3, 4}}))
+	must.False(t, tree.InsertSlice([]point{{0, 0}, {3, 4}}))
+	must.Eq(t, 3, tree.Size())
+
+	must.True(t, tree.Contains(point{3, 4}))
+	must.False(t, tree.Contains(point{4, 3}))
+	must.Eq(t, "[[0 0] [1 2] [3 4]]", tree.String())
+}
+
+func TestKDTree_Remove(t *testing.T) {
+	tree := KDTreeFrom([]point{{0, 0}, {1, 1}, {2, 2}, {3, 3}}, 2, pointCoordinate)
+	must.True(t, tree.Remove(point{1, 1}))
+	must.False(t, tree.Remove(point{1, 1}))
+	must.False(t, tree.Contains(point{1, 1}))
+
+	nearest, ok := tree.Nearest([]float64{1.2, 1.2})
+	must.True(t, ok)
+	must.Eq(t, point{2, 2}, nearest)
+
+	for _, p := range tree.Slice() {
+		must.True(t, tree.Remove(p))
+	}
+	must.True(t, tree.Empty())
+	_, ok = tree.Nearest([]float64{0, 0})
+	must.False(t, ok)
+}
+
+func TestKDTree_Nearest(t *testing.T) {
+	tree := KDTreeFrom([]point{{0, 0}, {10, 10}, {10, 0}, {0, 10}, {5, 5}}, 2, pointCoordinate)
+	nearest, ok := tree.Nearest([]float64{9, 1})
+	must.True(t, ok)
+	must.Eq(t, point{10, 0}, nearest)
+
+	must.Eq(t, []point{{5, 5}, {10, 10}}, tree.NearestN([]float64{7, 8}, 2))
+	must.SliceLen(t, 5, tree.NearestN([]float64{7, 8}, 10))
+	must.SliceEmpty(t, tree.NearestN([]float64{7, 8}, 0))
+
+	defer func() {
+		must.NotNil(t, recover())
+	}()
+	tree.Nearest([]float64{1, 2, 3})
+}
+
+func TestKDTree_Range(t *testing.T) {
+	tree := KDTreeFrom([]point{{0, 0}, {1, 5}, {2, 2}, {5, 1}, {5, 5}}, 2, pointCoordinate)
+	found := tree.Range([]float64{1, 1}, []float64{5, 2})
+	must.SliceContainsAll(t, []point{{2, 2}, {5, 1}}, found)
+	must.SliceEmpty(t, tree.Range([]float64{3, 3}, []float64{4, 4}))
+}
+
+func TestKDTree_random(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	random := func() point {
+		// a coarse grid, so that many points share coordinates
+		return point{float64(rng.Intn(100)), float64(rng.Intn(100))}
+	}
+
+	tree := NewKDTree(2, pointCoordinate)
+	expect := New[point](0)
+	for range 4000 {
+		p := random()
+		if rng.Intn(3) == 0 {
+			must.Eq(t, expect.Remove(p), tree.Remove(p))
+		} else {
+			must.Eq(t, expect.Insert(p), tree.Insert(p))
+		}
+	}
+	must.Eq(t, expect.Size(), tree.Size())
+	must.True(t, expect.EqualSlice(tree.Slice()))
+
+	distance := func(p point, q []float64) float64 {
+		return tree.distance(p, q)
+	}
+	for range 300 {
+		q := []float64{rng.Float64() * 110, rng.Float64() * 110}
+
+		// compare distances, since equidistant points may be returned in any order
+		nearest := tree.NearestN(q, 5)
+		sorted := expect.Slice()
+		slices.SortFunc(sorted, func(a, b point) int {
+			return Cmp(distance(a, q), distance(b, q))
+		})
+		must.SliceLen(t, 5, nearest)
+		for i := range nearest {
+			must.Eq(t, distance(sorted[i], q), distance(nearest[i], q))
+		}
+
+		lo := []float64{q[0] - 10, q[1] - 5}
+		hi := []float64{q[0] + 5, q[1] + 10}
+		within := expect.Filter(func(p point) bool {
+			return p[0] >= lo[0] && p[0] <= hi[0] && p[1] >= lo[1] && p[1] <= hi[1]
+		})
+		must.True(t, within.EqualSlice(tree.Range(lo, hi)))
+	}
+}
+
+func TestKDTree_sorted(t *testing.T) {
+	// inserting in sorted order would degrade into a list without rebuilding
+	tree := NewKDTree(1, func(x int, _ int) float64 { return float64(x) })
+	for i := range 10_000 {
+		tree.Insert(i)
+	}
+	nearest, _ := tree.Nearest([]float64{1234.4})
+	must.Eq(t, 1234, nearest)
+	found := tree.Range([]float64{5}, []float64{7})
+	slices.Sort(found)
+	must.Eq(t, []int{5, 6, 7}, found)
+}
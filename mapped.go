@@ -0,0 +1,230 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// MappedSet is a read-only set of strings stored in a file written by
+// WriteMappedSet, which is memory-mapped rather than read onto the heap. A
+// MappedSet is ideal for large static sets such as denylists shipped
+// alongside a program; opening one takes constant time and memory, and only
+// the pages of the file touched by Contains are ever read.
+//
+// The file holds a PerfectSet of the strings, so Contains takes one hash of
+// the string and a single comparison. On platforms without mmap support the
+// file is read into memory instead.
+//
+// A MappedSet must be closed with Close once it is no longer needed, after
+// which it is empty. It is safe for concurrent use until closed.
+type MappedSet struct {
+	data    []byte // the whole file
+	count   int
+	buckets int
+	seeds   []byte // buckets uint32 seeds
+	offsets []byte // count+1 uint64 offsets into strings
+	strings []byte
+}
+
+// The file format of a MappedSet, as produced by WriteMappedSet, is
+//
+//	magic   [8]byte               (mappedMagic)
+//	version uint32                (mappedVersion)
+//	buckets uint32                (number of buckets of the perfect hash)
+//	count   uint64                (number of elements)
+//	seeds   [buckets]uint32       (seed of each bucket)
+//	offsets [count + 1]uint64     (offset of each element in strings, by slot)
+//	strings [offsets[count]]byte  (the elements, concatenated by slot)
+//
+// All integers are little endian. Elements are hashed with 64 bit FNV-1a, so
+// a file may be opened by a program other than the one that wrote it.
+const (
+	mappedMagic      = "gosetmph"
+	mappedVersion    = 1
+	mappedHeaderSize = 24
+)
+
+var errMappedCorrupt = errors.New("set: corrupt mapped set file")
+
+// mappedHash returns the 64 bit FNV-1a hash of s.
+func mappedHash(s string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	return h
+}
+
+// WriteMappedSet writes the elements of s to w in the file format of a
+// MappedSet, to be opened with OpenMappedSet.
+//
+// Returns an error if two elements of s have the same hash, which is
+// vanishingly unlikely for any realistic set.
+func WriteMappedSet(w io.Writer, s ReadOnly[string]) error {
+	p, err := BuildPerfectSet(s, mappedHash)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	header := make([]byte, 0, mappedHeaderSize)
+	header = append(header, mappedMagic...)
+	header = binary.LittleEndian.AppendUint32(header, mappedVersion)
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(p.seeds)))
+	header = binary.LittleEndian.AppendUint64(header, uint64(len(p.items)))
+	_, _ = bw.Write(header)
+
+	var buf [8]byte
+	for _, seed := range p.seeds {
+		binary.LittleEndian.PutUint32(buf[:4], seed)
+		_, _ = bw.Write(buf[:4])
+	}
+	offset := uint64(0)
+	for _, item := range p.items {
+		binary.LittleEndian.PutUint64(buf[:], offset)
+		_, _ = bw.Write(buf[:])
+		offset += uint64(len(item))
+	}
+	binary.LittleEndian.PutUint64(buf[:], offset)
+	_, _ = bw.Write(buf[:])
+	for _, item := range p.items {
+		_, _ = bw.WriteString(item)
+	}
+	// a bufio.Writer reports the first error of any write when flushed
+	return bw.Flush()
+}
+
+// OpenMappedSet opens the file at path, written by WriteMappedSet, as a
+// MappedSet.
+func OpenMappedSet(path string) (*MappedSet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := mapFile(f)
+	if err != nil {
+		return nil, err
+	}
+	m, err := parseMappedSet(data)
+	if err != nil {
+		_ = unmapFile(data)
+		return nil, err
+	}
+	return m, nil
+}
+
+// parseMappedSet checks the header of data and returns a MappedSet of it. The
+// offsets of elements are checked as they are read, so that opening a file
+// does not read every page of it.
+func parseMappedSet(data []byte) (*MappedSet, error) {
+	if len(data) < mappedHeaderSize || string(data[:len(mappedMagic)]) != mappedMagic {
+		return nil, errMappedCorrupt
+	}
+	if version := binary.LittleEndian.Uint32(data[8:]); version != mappedVersion {
+		return nil, fmt.Errorf("set: unsupported mapped set format version %d", version)
+	}
+	buckets := uint64(binary.LittleEndian.Uint32(data[12:]))
+	count := binary.LittleEndian.Uint64(data[16:])
+
+	rest := uint64(len(data) - mappedHeaderSize)
+	if buckets == 0 || 4*buckets > rest || count >= (rest-4*buckets)/8 {
+		return nil, errMappedCorrupt
+	}
+	m := &MappedSet{
+		data:    data,
+		count:   int(count),
+		buckets: int(buckets),
+	}
+	data = data[mappedHeaderSize:]
+	m.seeds, data = data[:4*buckets], data[4*buckets:]
+	m.offsets, m.strings = data[:8*(count+1)], data[8*(count+1):]
+	if binary.LittleEndian.Uint64(m.offsets[8*count:]) != uint64(len(m.strings)) {
+		return nil, errMappedCorrupt
+	}
+	return m, nil
+}
+
+// at returns the element in slot, and false if the file is corrupt.
+func (m *MappedSet) at(slot int) ([]byte, bool) {
+	lo := binary.LittleEndian.Uint64(m.offsets[8*slot:])
+	hi := binary.LittleEndian.Uint64(m.offsets[8*slot+8:])
+	if lo > hi || hi > uint64(len(m.strings)) {
+		return nil, false
+	}
+	return m.strings[lo:hi], true
+}
+
+// Contains returns whether item is present in m.
+func (m *MappedSet) Contains(item string) bool {
+	if m.count == 0 {
+		return false
+	}
+	h := mix(mappedHash(item))
+	seed := binary.LittleEndian.Uint32(m.seeds[4*perfectBucket(h, m.buckets):])
+	slot := perfectSlot(h, seed, m.count)
+	if slot >= m.count {
+		return false
+	}
+	b, ok := m.at(slot)
+	return ok && string(b) == item
+}
+
+// Size returns the cardinality of m.
+func (m *MappedSet) Size() int {
+	return m.count
+}
+
+// Empty returns true if m contains no elements, false otherwise.
+func (m *MappedSet) Empty() bool {
+	return m.count == 0
+}
+
+// Slice creates a copy of m as a slice. Elements are in no particular order.
+func (m *MappedSet) Slice() []string {
+	result := make([]string, 0, m.count)
+	m.ForEach(func(item string) bool {
+		result = append(result, item)
+		return true
+	})
+	return result
+}
+
+// ForEach calls visit for each element of m, in no particular order.
+// Iteration stops early if visit returns false, or at an element that is
+// corrupt in the file.
+//
+// Each element is copied from the file onto the heap as it is visited.
+func (m *MappedSet) ForEach(visit func(item string) bool) {
+	for slot := 0; slot < m.count; slot++ {
+		b, ok := m.at(slot)
+		if !ok || !visit(string(b)) {
+			return
+		}
+	}
+}
+
+// String creates a string representation of m. The result contains elements
+// sorted by their lexical string order.
+func (m *MappedSet) String() string {
+	l := m.Slice()
+	sort.Strings(l)
+	return fmt.Sprintf("%s", l)
+}
+
+// Close unmaps the file of m, after which m is empty.
+func (m *MappedSet) Close() error {
+	data := m.data
+	*m = MappedSet{}
+	return unmapFile(data)
+}
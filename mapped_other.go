@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package set
+
+import (
+	"io"
+	"os"
+)
+
+// mapFile reads the contents of f into memory, on platforms without mmap.
+func mapFile(f *os.File) ([]byte, error) {
+	return io.ReadAll(f)
+}
+
+// unmapFile releases data read by mapFile.
+func unmapFile([]byte) error {
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func writeMapped(t *testing.T, s ReadOnly[string]) string {
+	t.Helper()
+	var buf bytes.Buffer
+	must.NoError(t, WriteMappedSet(&buf, s))
+	path := filepath.Join(t.TempDir(), "set.mph")
+	must.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+	return path
+}
+
+func TestMappedSet_Contains(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 17, 5_000} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			s := New[string](n)
+			for i := range n {
+				s.Insert("host-" + strconv.Itoa(i) + ".example")
+			}
+			m, err := OpenMappedSet(writeMapped(t, s))
+			must.NoError(t, err)
+			t.Cleanup(func() { _ = m.Close() })
+
+			must.Eq(t, n, m.Size())
+			must.Eq(t, n == 0, m.Empty())
+			for i := range n {
+				must.True(t, m.Contains("host-"+strconv.Itoa(i)+".example"))
+				must.False(t, m.Contains("host-"+strconv.Itoa(i)))
+			}
+			must.False(t, m.Contains(""))
+			must.Eq(t, s, From(m.Slice()))
+		})
+	}
+}
+
+func TestMappedSet_emptyString(t *testing.T) {
+	m, err := OpenMappedSet(writeMapped(t, From([]string{"", "a"})))
+	must.NoError(t, err)
+	must.True(t, m.Contains(""))
+	must.True(t, m.Contains("a"))
+	must.Eq(t, `[ a]`, m.String())
+	must.NoError(t, m.Close())
+}
+
+func TestMappedSet_Close(t *testing.T) {
+	m, err := OpenMappedSet(writeMapped(t, From([]string{"a", "b", "c"})))
+	must.NoError(t, err)
+	must.Eq(t, "[a b c]", m.String())
+
+	must.NoError(t, m.Close())
+	must.True(t, m.Empty())
+	must.False(t, m.Contains("a"))
+	must.Eq(t, "[]", m.String())
+}
+
+func TestOpenMappedSet_corrupt(t *testing.T) {
+	var buf bytes.Buffer
+	must.NoError(t, WriteMappedSet(&buf, From([]string{"a", "b", "c", "d", "e"})))
+	valid := buf.Bytes()
+
+	open := func(data []byte) error {
+		path := filepath.Join(t.TempDir(), "set.mph")
+		must.NoError(t, os.WriteFile(path, data, 0o644))
+		m, err := OpenMappedSet(path)
+		if err == nil {
+			_ = m.Close()
+		}
+		return err
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := OpenMappedSet(filepath.Join(t.TempDir(), "missing.mph"))
+		must.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		must.ErrorIs(t, open(nil), errMappedCorrupt)
+	})
+
+	t.Run("magic", func(t *testing.T) {
+		data := bytes.Clone(valid)
+		data[0] = 'x'
+		must.ErrorIs(t, open(data), errMappedCorrupt)
+	})
+
+	t.Run("version", func(t *testing.T) {
+		data := bytes.Clone(valid)
+		data[8] = 9
+		must.ErrorContains(t, open(data), "unsupported mapped set format version 9")
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		for i := range len(valid) {
+			must.Error(t, open(valid[:i]))
+		}
+	})
+
+	t.Run("count", func(t *testing.T) {
+		data := bytes.Clone(valid)
+		data[23] = 0xff
+		must.ErrorIs(t, open(data), errMappedCorrupt)
+	})
+}
+
+func BenchmarkMappedSet_Contains(b *testing.B) {
+	s := New[string](100_000)
+	for i := range 100_000 {
+		s.Insert("item-" + strconv.Itoa(i))
+	}
+	var buf bytes.Buffer
+	must.NoError(b, WriteMappedSet(&buf, s))
+	path := filepath.Join(b.TempDir(), "set.mph")
+	must.NoError(b, os.WriteFile(path, buf.Bytes(), 0o644))
+	m, err := OpenMappedSet(path)
+	must.NoError(b, err)
+	defer m.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Contains("item-" + strconv.Itoa(i%200_000))
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package set
+
+import (
+	"math"
+	"os"
+	"syscall"
+)
+
+// mapFile maps the contents of f into memory read-only.
+func mapFile(f *os.File) ([]byte, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+	switch {
+	case size == 0:
+		// an empty mapping is an error, and an empty file is no set anyway
+		return nil, nil
+	case size > math.MaxInt:
+		return nil, errMappedCorrupt
+	}
+	return syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+// unmapFile releases data mapped by mapFile.
+func unmapFile(data []byte) error {
+	if data == nil {
+		return nil
+	}
+	return syscall.Munmap(data)
+}
@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"hash/maphash"
+	"math"
+	"slices"
+)
+
+// Jaccard returns the Jaccard similarity of a and b, the size of their
+// intersection divided by the size of their union. Two empty sets have a
+// similarity of 1.
+//
+// The similarity is computed exactly, in time proportional to the size of the
+// smaller set. To compare many large sets with one another, compute a MinHash
+// of each set once and compare those instead.
+func Jaccard[T comparable](a, b *Set[T]) float64 {
+	small, big := a, b
+	if a.Size() > b.Size() {
+		small, big = b, a
+	}
+	common := 0
+	for item := range small.items {
+		if big.Contains(item) {
+			common++
+		}
+	}
+	union := a.Size() + b.Size() - common
+	if union == 0 {
+		return 1
+	}
+	return float64(common) / float64(union)
+}
+
+// MinHash is a fixed size sketch of a set, from which the Jaccard similarity
+// of two sets can be estimated without the sets themselves. The sketch keeps,
+// for each of k independent hash functions, the smallest hash of any element.
+// The fraction of hash functions for which two sketches agree estimates the
+// similarity of their sets, with a standard error of about 1/sqrt(k).
+//
+// Sketches may only be compared with sketches of the same size created with
+// the same hash function.
+//
+// https://en.wikipedia.org/wiki/MinHash
+type MinHash[T any] struct {
+	hash func(T) uint64
+	mins []uint64
+}
+
+// NewMinHash creates an empty MinHash of k hash functions, for elements of a
+// comparable type T.
+//
+// Elements are hashed with a seed that is random per process, so sketches are
+// only comparable within a single process. Use NewMinHashFunc with a stable
+// hash function for sketches that are stored or shared between processes.
+func NewMinHash[T comparable](k int) *MinHash[T] {
+	return NewMinHashFunc(k, func(item T) uint64 {
+		return maphash.Comparable(fingerprintSeed, item)
+	})
+}
+
+// NewMinHashFunc creates an empty MinHash of k hash functions, which are
+// derived from the given hash of each element.
+func NewMinHashFunc[T any](k int, hash func(T) uint64) *MinHash[T] {
+	mins := make([]uint64, max(1, k))
+	for i := range mins {
+		mins[i] = math.MaxUint64
+	}
+	return &MinHash[T]{
+		hash: hash,
+		mins: mins,
+	}
+}
+
+// MinHashOf creates a MinHash of k hash functions containing each element of s.
+//
+// See NewMinHash for the comparability of the result.
+func MinHashOf[T comparable](s ReadOnly[T], k int) *MinHash[T] {
+	m := NewMinHash[T](k)
+	s.ForEach(func(item T) bool {
+		m.Insert(item)
+		return true
+	})
+	return m
+}
+
+// Insert item into the set sketched by m.
+func (m *MinHash[T]) Insert(item T) {
+	h := m.hash(item)
+	for i := range m.mins {
+		// each hash function permutes the hash of the element differently
+		if v := mix(h ^ mix(uint64(i)+1)); v < m.mins[i] {
+			m.mins[i] = v
+		}
+	}
+}
+
+// InsertSlice will insert each item in items into the set sketched by m.
+func (m *MinHash[T]) InsertSlice(items []T) {
+	for _, item := range items {
+		m.Insert(item)
+	}
+}
+
+// Merge updates m to be the sketch of the union of the sets sketched by m
+// and o.
+//
+// Merge panics if m and o are of different sizes.
+func (m *MinHash[T]) Merge(o *MinHash[T]) {
+	m.check(o)
+	for i, v := range o.mins {
+		m.mins[i] = min(m.mins[i], v)
+	}
+}
+
+// Similarity returns the estimated Jaccard similarity of the sets sketched by
+// m and o.
+//
+// Similarity panics if m and o are of different sizes.
+func (m *MinHash[T]) Similarity(o *MinHash[T]) float64 {
+	m.check(o)
+	same := 0
+	for i, v := range m.mins {
+		if o.mins[i] == v {
+			same++
+		}
+	}
+	return float64(same) / float64(len(m.mins))
+}
+
+func (m *MinHash[T]) check(o *MinHash[T]) {
+	if len(m.mins) != len(o.mins) {
+		panic("minhash: sketches are of different sizes")
+	}
+}
+
+// Size returns the number of hash functions of m.
+func (m *MinHash[T]) Size() int {
+	return len(m.mins)
+}
+
+// Signature creates a copy of the minimum hash values of m, which may be
+// stored and compared with the signatures of other sketches.
+func (m *MinHash[T]) Signature() []uint64 {
+	return slices.Clone(m.mins)
+}
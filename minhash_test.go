@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestJaccard(t *testing.T) {
+	must.Eq(t, 1, Jaccard(New[int](0), New[int](0)))
+	must.Eq(t, 0, Jaccard(Of(1, 2), New[int](0)))
+	must.Eq(t, 0, Jaccard(Of(1, 2), Of(3, 4)))
+	must.Eq(t, 1, Jaccard(Of(1, 2), Of(2, 1)))
+	must.Eq(t, 0.5, Jaccard(Of(1, 2, 3), Of(2, 3, 4, 1, 5, 6)))
+	must.Eq(t, 0.25, Jaccard(Of("a", "b"), Of("b", "c", "d")))
+}
+
+func TestMinHash_Similarity(t *testing.T) {
+	cases := []struct {
+		name   string
+		a, b   *Set[int]
+		approx float64
+	}{
+		{"identical", From(ints(1000)), From(ints(1000)), 1},
+		{"disjoint", FromRange(0, 1000, 1), FromRange(1000, 2000, 1), 0},
+		{"half", FromRange(0, 1500, 1), FromRange(500, 2000, 1), 0.5},
+		{"tenth", FromRange(0, 1000, 1), FromRange(900, 1900, 1), 100.0 / 1900},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			exact := Jaccard(tc.a, tc.b)
+			must.Less(t, 1e-9, math.Abs(exact-tc.approx))
+
+			a, b := MinHashOf[int](tc.a, 512), MinHashOf[int](tc.b, 512)
+			// within about four standard errors
+			must.Less(t, 0.1, math.Abs(a.Similarity(b)-exact))
+		})
+	}
+}
+
+func TestMinHash_Merge(t *testing.T) {
+	a := MinHashOf[int](FromRange(0, 100, 1), 64)
+	b := MinHashOf[int](FromRange(100, 200, 1), 64)
+	a.Merge(b)
+	must.Eq(t, MinHashOf[int](FromRange(0, 200, 1), 64).Signature(), a.Signature())
+}
+
+func TestMinHash_Insert(t *testing.T) {
+	a := NewMinHash[string](16)
+	must.Eq(t, 16, a.Size())
+	a.InsertSlice([]string{"x", "y", "x"})
+
+	b := NewMinHash[string](16)
+	b.Insert("y")
+	b.Insert("x")
+	must.Eq(t, a.Signature(), b.Signature())
+	must.Eq(t, 1, a.Similarity(b))
+
+	// the signature is a copy
+	a.Signature()[0] = 0
+	must.Eq(t, 1, a.Similarity(b))
+}
+
+func TestMinHash_Func(t *testing.T) {
+	a := NewMinHashFunc(128, fnvString)
+	b := NewMinHashFunc(128, fnvString)
+	a.InsertSlice([]string{"env=prod", "team=infra", "region=us"})
+	b.InsertSlice([]string{"region=us", "team=infra", "env=prod"})
+	must.Eq(t, a.Signature(), b.Signature())
+}
+
+func TestMinHash_sizes(t *testing.T) {
+	defer func() {
+		must.NotNil(t, recover())
+	}()
+	NewMinHash[int](8).Similarity(NewMinHash[int](16))
+}
@@ -0,0 +1,307 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Multiset is a generic implementation of a multiset (or bag), which is like a
+// set except that each element may be present more than once. A Multiset
+// tracks the number of occurrences of each distinct element.
+//
+// Size reports the total number of occurrences, while Distinct reports the
+// set of distinct elements. The set algebra of a Multiset respects
+// multiplicities; e.g. the Union of two multisets contains each element as
+// many times as the larger of its two counts.
+//
+// The underlying data structure is a map of elements to their counts.
+type Multiset[T comparable] struct {
+	counts map[T]int
+	size   int
+}
+
+// NewMultiset creates a new Multiset with initial underlying capacity of size
+// distinct elements.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func NewMultiset[T comparable](size int) *Multiset[T] {
+	return &Multiset[T]{
+		counts: make(map[T]int, max(0, size)),
+	}
+}
+
+// MultisetFrom creates a new Multiset containing each item in items. An item
+// that appears in items more than once is counted once per appearance.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func MultisetFrom[T comparable](items []T) *Multiset[T] {
+	m := NewMultiset[T](len(items))
+	m.AddSlice(items)
+	return m
+}
+
+// MultisetOf creates a new Multiset containing each item passed as an argument.
+// An item passed more than once is counted once per appearance.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func MultisetOf[T comparable](items ...T) *Multiset[T] {
+	return MultisetFrom(items)
+}
+
+// Add one occurrence of item to m.
+//
+// Returns the number of occurrences of item in m after the call.
+func (m *Multiset[T]) Add(item T) int {
+	return m.AddN(item, 1)
+}
+
+// AddN adds n occurrences of item to m. If n is zero or less, m is not
+// modified.
+//
+// Returns the number of occurrences of item in m after the call.
+func (m *Multiset[T]) AddN(item T, n int) int {
+	if n <= 0 {
+		return m.counts[item]
+	}
+	m.counts[item] += n
+	m.size += n
+	return m.counts[item]
+}
+
+// AddSlice adds one occurrence of item to m for each item in items.
+func (m *Multiset[T]) AddSlice(items []T) {
+	for _, item := range items {
+		m.AddN(item, 1)
+	}
+}
+
+// AddMultiset adds every occurrence of each element of o to m, so that the
+// count of each element in m becomes the sum of its counts in m and o.
+func (m *Multiset[T]) AddMultiset(o *Multiset[T]) {
+	for item, count := range o.counts {
+		m.AddN(item, count)
+	}
+}
+
+// Remove one occurrence of item from m.
+//
+// Return true if m was modified (item was present), false otherwise.
+func (m *Multiset[T]) Remove(item T) bool {
+	return m.RemoveN(item, 1) > 0
+}
+
+// RemoveN removes up to n occurrences of item from m. If n is zero or less, m
+// is not modified.
+//
+// Returns the number of occurrences actually removed.
+func (m *Multiset[T]) RemoveN(item T, n int) int {
+	count, exists := m.counts[item]
+	if !exists || n <= 0 {
+		return 0
+	}
+	if n >= count {
+		delete(m.counts, item)
+		m.size -= count
+		return count
+	}
+	m.counts[item] = count - n
+	m.size -= n
+	return n
+}
+
+// RemoveAll removes every occurrence of item from m.
+//
+// Returns the number of occurrences removed.
+func (m *Multiset[T]) RemoveAll(item T) int {
+	count := m.counts[item]
+	delete(m.counts, item)
+	m.size -= count
+	return count
+}
+
+// SetCount sets the number of occurrences of item in m to n. If n is zero or
+// less, item is removed from m entirely.
+//
+// Returns the number of occurrences of item in m before the call.
+func (m *Multiset[T]) SetCount(item T, n int) int {
+	previous := m.RemoveAll(item)
+	m.AddN(item, n)
+	return previous
+}
+
+// Count returns the number of occurrences of item in m.
+func (m *Multiset[T]) Count(item T) int {
+	return m.counts[item]
+}
+
+// Contains returns whether at least one occurrence of item is present in m.
+func (m *Multiset[T]) Contains(item T) bool {
+	_, exists := m.counts[item]
+	return exists
+}
+
+// Size returns the total number of occurrences of all elements in m.
+func (m *Multiset[T]) Size() int {
+	return m.size
+}
+
+// Empty returns true if m contains no elements, false otherwise.
+func (m *Multiset[T]) Empty() bool {
+	return m.size == 0
+}
+
+// Distinct returns a Set containing each distinct element of m.
+func (m *Multiset[T]) Distinct() *Set[T] {
+	result := New[T](len(m.counts))
+	for item := range m.counts {
+		result.Insert(item)
+	}
+	return result
+}
+
+// DistinctSize returns the number of distinct elements in m.
+func (m *Multiset[T]) DistinctSize() int {
+	return len(m.counts)
+}
+
+// Union returns a multiset containing each element of m and o, with a count
+// equal to the larger of its counts in m and o.
+func (m *Multiset[T]) Union(o *Multiset[T]) *Multiset[T] {
+	result := m.Copy()
+	for item, count := range o.counts {
+		if count > result.counts[item] {
+			result.SetCount(item, count)
+		}
+	}
+	return result
+}
+
+// Sum returns a multiset containing each element of m and o, with a count
+// equal to the sum of its counts in m and o.
+func (m *Multiset[T]) Sum(o *Multiset[T]) *Multiset[T] {
+	result := m.Copy()
+	result.AddMultiset(o)
+	return result
+}
+
+// Intersect returns a multiset containing each element present in both m and
+// o, with a count equal to the smaller of its counts in m and o.
+func (m *Multiset[T]) Intersect(o *Multiset[T]) *Multiset[T] {
+	small, big := m, o
+	if len(m.counts) > len(o.counts) {
+		small, big = o, m
+	}
+	result := NewMultiset[T](0)
+	for item, count := range small.counts {
+		result.AddN(item, min(count, big.counts[item]))
+	}
+	return result
+}
+
+// Difference returns a multiset containing each element of m with its count
+// reduced by its count in o. Elements whose count would drop to zero or less
+// are not included.
+func (m *Multiset[T]) Difference(o *Multiset[T]) *Multiset[T] {
+	result := NewMultiset[T](0)
+	for item, count := range m.counts {
+		result.AddN(item, count-o.counts[item])
+	}
+	return result
+}
+
+// Subset returns whether o is a subset of m, that is whether every element of
+// o occurs in m at least as many times as it occurs in o.
+func (m *Multiset[T]) Subset(o *Multiset[T]) bool {
+	if m.size < o.size {
+		return false
+	}
+	for item, count := range o.counts {
+		if m.counts[item] < count {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns whether m and o contain the same elements with the same counts.
+func (m *Multiset[T]) Equal(o *Multiset[T]) bool {
+	if m.size != o.size || len(m.counts) != len(o.counts) {
+		return false
+	}
+	for item, count := range m.counts {
+		if o.counts[item] != count {
+			return false
+		}
+	}
+	return true
+}
+
+// Copy creates a copy of m.
+func (m *Multiset[T]) Copy() *Multiset[T] {
+	result := NewMultiset[T](len(m.counts))
+	result.AddMultiset(m)
+	return result
+}
+
+// Slice creates a copy of m as a slice, in which each element appears once
+// per occurrence. Elements are in no particular order, though all occurrences
+// of an element are adjacent.
+func (m *Multiset[T]) Slice() []T {
+	result := make([]T, 0, m.size)
+	for item, count := range m.counts {
+		for i := 0; i < count; i++ {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// ForEach calls visit once for each occurrence of each element of m, in no
+// particular order. Iteration stops early if visit returns false.
+func (m *Multiset[T]) ForEach(visit func(item T) bool) {
+	for item, count := range m.counts {
+		for i := 0; i < count; i++ {
+			if !visit(item) {
+				return
+			}
+		}
+	}
+}
+
+// ForEachCount calls visit once for each distinct element of m along with its
+// number of occurrences, in no particular order. Iteration stops early if
+// visit returns false.
+func (m *Multiset[T]) ForEachCount(visit func(item T, count int) bool) {
+	for item, count := range m.counts {
+		if !visit(item, count) {
+			return
+		}
+	}
+}
+
+// String creates a string representation of m, using "%v" printf formatting to
+// transform each element into a string, followed by its count. The result
+// contains elements sorted by their lexical string order.
+func (m *Multiset[T]) String() string {
+	return m.StringFunc(func(element T) string {
+		return fmt.Sprintf("%v", element)
+	})
+}
+
+// StringFunc creates a string representation of m, using f to transform each
+// element into a string, followed by its count. The result contains elements
+// sorted by their lexical string order.
+func (m *Multiset[T]) StringFunc(f func(element T) string) string {
+	l := make([]string, 0, len(m.counts))
+	for item, count := range m.counts {
+		l = append(l, fmt.Sprintf("%s:%d", f(item), count))
+	}
+	sort.Strings(l)
+	return fmt.Sprintf("%s", l)
+}
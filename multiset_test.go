@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestMultiset_New(t *testing.T) {
+	m := NewMultiset[int](10)
+	must.True(t, m.Empty())
+	must.Eq(t, 0, m.Size())
+
+	m = MultisetOf(1, 2, 2, 3, 3, 3)
+	must.Eq(t, 6, m.Size())
+	must.Eq(t, 3, m.DistinctSize())
+	must.Eq(t, "[1:1 2:2 3:3]", m.String())
+}
+
+func TestMultiset_Add(t *testing.T) {
+	m := NewMultiset[string](0)
+	must.Eq(t, 1, m.Add("a"))
+	must.Eq(t, 2, m.Add("a"))
+	must.Eq(t, 5, m.AddN("a", 3))
+	must.Eq(t, 5, m.AddN("a", 0))
+	must.Eq(t, 0, m.AddN("b", -1))
+	must.False(t, m.Contains("b"))
+	must.Eq(t, 5, m.Size())
+
+	m.AddSlice([]string{"b", "c", "b"})
+	must.Eq(t, 2, m.Count("b"))
+	must.Eq(t, 8, m.Size())
+
+	m.AddMultiset(MultisetOf("a", "d"))
+	must.Eq(t, 6, m.Count("a"))
+	must.Eq(t, 1, m.Count("d"))
+	must.Eq(t, 10, m.Size())
+}
+
+func TestMultiset_Remove(t *testing.T) {
+	m := MultisetOf("a", "a", "a", "b", "c", "c")
+
+	must.True(t, m.Remove("a"))
+	must.Eq(t, 2, m.Count("a"))
+	must.False(t, m.Remove("z"))
+
+	must.Eq(t, 0, m.RemoveN("c", 0))
+	must.Eq(t, 2, m.RemoveN("c", 5))
+	must.False(t, m.Contains("c"))
+
+	must.Eq(t, 2, m.RemoveAll("a"))
+	must.Eq(t, 0, m.RemoveAll("a"))
+	must.Eq(t, 1, m.Size())
+	must.Eq(t, 1, m.DistinctSize())
+
+	must.True(t, m.Remove("b"))
+	must.True(t, m.Empty())
+}
+
+func TestMultiset_SetCount(t *testing.T) {
+	m := MultisetOf(1, 1, 2)
+	must.Eq(t, 2, m.SetCount(1, 5))
+	must.Eq(t, 5, m.Count(1))
+	must.Eq(t, 6, m.Size())
+
+	must.Eq(t, 1, m.SetCount(2, 0))
+	must.False(t, m.Contains(2))
+	must.Eq(t, 5, m.Size())
+
+	must.Eq(t, 0, m.SetCount(3, 1))
+	must.Eq(t, 6, m.Size())
+}
+
+func TestMultiset_Distinct(t *testing.T) {
+	m := MultisetOf(1, 2, 2, 3, 3, 3)
+	must.True(t, m.Distinct().EqualSlice([]int{1, 2, 3}))
+	must.Empty(t, NewMultiset[int](0).Distinct())
+}
+
+func TestMultiset_Algebra(t *testing.T) {
+	a := MultisetOf(1, 1, 1, 2, 2, 3)
+	b := MultisetOf(1, 2, 2, 2, 4)
+
+	t.Run("union", func(t *testing.T) {
+		must.Eq(t, "[1:3 2:3 3:1 4:1]", a.Union(b).String())
+		must.Eq(t, 8, a.Union(b).Size())
+	})
+
+	t.Run("sum", func(t *testing.T) {
+		must.Eq(t, "[1:4 2:5 3:1 4:1]", a.Sum(b).String())
+		must.Eq(t, 11, a.Sum(b).Size())
+	})
+
+	t.Run("intersect", func(t *testing.T) {
+		must.Eq(t, "[1:1 2:2]", a.Intersect(b).String())
+		must.Eq(t, "[1:1 2:2]", b.Intersect(a).String())
+		must.Eq(t, 3, a.Intersect(b).Size())
+	})
+
+	t.Run("difference", func(t *testing.T) {
+		must.Eq(t, "[1:2 3:1]", a.Difference(b).String())
+		must.Eq(t, "[2:1 4:1]", b.Difference(a).String())
+		must.Eq(t, 3, a.Difference(b).Size())
+	})
+
+	t.Run("unmodified", func(t *testing.T) {
+		must.Eq(t, "[1:3 2:2 3:1]", a.String())
+		must.Eq(t, "[1:1 2:3 4:1]", b.String())
+	})
+}
+
+func TestMultiset_Subset(t *testing.T) {
+	a := MultisetOf(1, 1, 2, 3)
+	must.True(t, a.Subset(MultisetOf(1, 1, 3)))
+	must.True(t, a.Subset(NewMultiset[int](0)))
+	must.True(t, a.Subset(a))
+	must.False(t, a.Subset(MultisetOf(1, 1, 1)))
+	must.False(t, a.Subset(MultisetOf(4)))
+}
+
+func TestMultiset_Equal(t *testing.T) {
+	a := MultisetOf(1, 1, 2)
+	must.True(t, a.Equal(MultisetOf(2, 1, 1)))
+	must.False(t, a.Equal(MultisetOf(1, 2, 2)))
+	must.False(t, a.Equal(MultisetOf(1, 2)))
+	must.True(t, NewMultiset[int](0).Equal(NewMultiset[int](5)))
+}
+
+func TestMultiset_Copy(t *testing.T) {
+	a := MultisetOf(1, 1, 2)
+	b := a.Copy()
+	must.True(t, a.Equal(b))
+	b.Add(1)
+	must.Eq(t, 2, a.Count(1))
+	must.Eq(t, 3, b.Count(1))
+}
+
+func TestMultiset_Slice(t *testing.T) {
+	m := MultisetOf(3, 1, 3, 2, 3)
+	result := m.Slice()
+	slices.Sort(result)
+	must.Eq(t, []int{1, 2, 3, 3, 3}, result)
+	must.SliceEmpty(t, NewMultiset[int](0).Slice())
+}
+
+func TestMultiset_ForEach(t *testing.T) {
+	m := MultisetOf(1, 2, 2, 3, 3, 3)
+
+	t.Run("occurrences", func(t *testing.T) {
+		var result []int
+		m.ForEach(func(item int) bool {
+			result = append(result, item)
+			return true
+		})
+		slices.Sort(result)
+		must.Eq(t, []int{1, 2, 2, 3, 3, 3}, result)
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		visits := 0
+		m.ForEach(func(int) bool {
+			visits++
+			return visits < 4
+		})
+		must.Eq(t, 4, visits)
+	})
+
+	t.Run("counts", func(t *testing.T) {
+		result := make(map[int]int)
+		m.ForEachCount(func(item, count int) bool {
+			result[item] = count
+			return true
+		})
+		must.MapEq(t, map[int]int{1: 1, 2: 2, 3: 3}, result)
+	})
+}
+
+func TestMultiset_StringFunc(t *testing.T) {
+	m := MultisetOf("b", "a", "b")
+	must.Eq(t, "[A:1 B:2]", m.StringFunc(func(s string) string {
+		return string(s[0] - 'a' + 'A')
+	}))
+	must.Eq(t, "[]", NewMultiset[string](0).String())
+}
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+// Number types compatible with Sum and Mean
+type Number interface {
+	~int | ~uint | ~int64 | ~uint64 | ~int32 | ~uint32 | ~int16 | ~uint16 | ~int8 | ~uint8 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Sum returns the sum of the elements of s, or zero if s is empty.
+//
+// The sum is accumulated in N, and may overflow for integer types.
+func Sum[N Number](s *Set[N]) N {
+	var sum N
+	for item := range s.items {
+		sum += item
+	}
+	return sum
+}
+
+// Mean returns the arithmetic mean of the elements of s, and true. If s is
+// empty, zero and false are returned.
+//
+// The sum is accumulated as a float64, so the mean of a set of large integers
+// does not overflow but may lose precision.
+func Mean[N Number](s *Set[N]) (float64, bool) {
+	if s.Empty() {
+		return 0, false
+	}
+	var sum float64
+	for item := range s.items {
+		sum += float64(item)
+	}
+	return sum / float64(s.Size()), true
+}
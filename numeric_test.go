@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestSum(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		must.Zero(t, Sum(New[int](0)))
+		must.Zero(t, Sum(New[float64](0)))
+	})
+
+	t.Run("ints", func(t *testing.T) {
+		must.Eq(t, 5050, Sum(From(ints(100))))
+	})
+
+	t.Run("negative duplicates", func(t *testing.T) {
+		must.Eq(t, -4, Sum(Of(-5, 1, 1, 1)))
+	})
+
+	t.Run("floats", func(t *testing.T) {
+		must.Eq(t, 4.0, Sum(Of(0.5, 1.5, 2.0)))
+	})
+
+	t.Run("named", func(t *testing.T) {
+		type weight uint16
+		must.Eq(t, weight(60), Sum(Of[weight](10, 20, 30)))
+	})
+}
+
+func TestMean(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		mean, ok := Mean(New[int](0))
+		must.False(t, ok)
+		must.Zero(t, mean)
+	})
+
+	t.Run("ints", func(t *testing.T) {
+		mean, ok := Mean(Of(1, 2, 3, 4))
+		must.True(t, ok)
+		must.Eq(t, 2.5, mean)
+	})
+
+	t.Run("floats", func(t *testing.T) {
+		mean, ok := Mean(Of(float32(1), float32(2)))
+		must.True(t, ok)
+		must.Eq(t, 1.5, mean)
+	})
+
+	t.Run("no overflow", func(t *testing.T) {
+		mean, ok := Mean(Of[int8](math.MaxInt8, math.MaxInt8-1))
+		must.True(t, ok)
+		must.Eq(t, 126.5, mean)
+	})
+}
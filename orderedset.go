@@ -0,0 +1,438 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"iter"
+)
+
+// OrderedSet is a generic implementation of the set mathematical data structure
+// that remembers the order in which elements were inserted. Iteration and
+// Slice produce elements in insertion order, while Contains remains O(1).
+//
+// Re-inserting an element that is already present does not change its position.
+// An element that is removed and inserted again is moved to the end.
+//
+// The underlying data structure is a map of elements into a doubly linked list.
+type OrderedSet[T comparable] struct {
+	items map[T]*link[T]
+	root  link[T]
+}
+
+// link is an entry in the insertion-ordered list of an OrderedSet.
+type link[T comparable] struct {
+	element    T
+	prev, next *link[T]
+}
+
+// NewOrderedSet creates a new OrderedSet with initial underlying capacity of size.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect. For these types, use HashSet instead.
+func NewOrderedSet[T comparable](size int) *OrderedSet[T] {
+	s := &OrderedSet[T]{
+		items: make(map[T]*link[T], max(0, size)),
+	}
+	s.root.next = &s.root
+	s.root.prev = &s.root
+	return s
+}
+
+// OrderedSetFrom creates a new OrderedSet containing each item in items, in
+// the order they first appear, sized to hold items without growing. To leave
+// room for more elements, create the set with NewOrderedSet and insert items
+// with InsertSlice instead.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect. For these types, use HashSet instead.
+func OrderedSetFrom[T comparable](items []T) *OrderedSet[T] {
+	s := NewOrderedSet[T](len(items))
+	s.InsertSlice(items)
+	return s
+}
+
+// OrderedSetOf creates a new OrderedSet containing each item passed as an
+// argument, in the order they first appear.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect. For these types, use HashSet instead.
+func OrderedSetOf[T comparable](items ...T) *OrderedSet[T] {
+	return OrderedSetFrom(items)
+}
+
+// OrderedSetCollect creates a new OrderedSet containing each item produced by
+// seq, in the order they first appear.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect. For these types, use HashSet instead.
+func OrderedSetCollect[T comparable](seq iter.Seq[T]) *OrderedSet[T] {
+	s := NewOrderedSet[T](0)
+	for item := range seq {
+		s.Insert(item)
+	}
+	return s
+}
+
+// Insert item into s, after all other elements.
+//
+// Return true if s was modified (item was not already in s), false otherwise.
+func (s *OrderedSet[T]) Insert(item T) bool {
+	if _, exists := s.items[item]; exists {
+		return false
+	}
+	l := &link[T]{
+		element: item,
+		prev:    s.root.prev,
+		next:    &s.root,
+	}
+	s.root.prev.next = l
+	s.root.prev = l
+	s.items[item] = l
+	return true
+}
+
+// InsertSlice will insert each item in items into s.
+//
+// Return true if s was modified (at least one item was not already in s), false otherwise.
+func (s *OrderedSet[T]) InsertSlice(items []T) bool {
+	modified := false
+	for _, item := range items {
+		if s.Insert(item) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// InsertMany will insert each item passed as an argument into s.
+//
+// Return true if s was modified (at least one item was not already in s), false otherwise.
+func (s *OrderedSet[T]) InsertMany(items ...T) bool {
+	return s.InsertSlice(items)
+}
+
+// InsertSet will insert each element of o into s, in the order of o.
+//
+// Return true if s was modified (at least one item of o was not already in s), false otherwise.
+func (s *OrderedSet[T]) InsertSet(o *OrderedSet[T]) bool {
+	modified := false
+	for l := o.root.next; l != &o.root; l = l.next {
+		if s.Insert(l.element) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// Remove will remove item from s.
+//
+// Return true if s was modified (item was present), false otherwise.
+func (s *OrderedSet[T]) Remove(item T) bool {
+	l, exists := s.items[item]
+	if !exists {
+		return false
+	}
+	l.prev.next = l.next
+	l.next.prev = l.prev
+	l.prev, l.next = nil, nil
+	delete(s.items, item)
+	return true
+}
+
+// RemoveSlice will remove each item in items from s.
+//
+// Return true if s was modified (any item was present), false otherwise.
+func (s *OrderedSet[T]) RemoveSlice(items []T) bool {
+	modified := false
+	for _, item := range items {
+		if s.Remove(item) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// RemoveMany will remove each item passed as an argument from s.
+//
+// Return true if s was modified (any item was present), false otherwise.
+func (s *OrderedSet[T]) RemoveMany(items ...T) bool {
+	return s.RemoveSlice(items)
+}
+
+// RemoveSet will remove each element of o from s.
+//
+// Return true if s was modified (any item of o was present in s), false otherwise.
+func (s *OrderedSet[T]) RemoveSet(o *OrderedSet[T]) bool {
+	modified := false
+	for item := range o.items {
+		if s.Remove(item) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// RemoveFunc will remove each element from s that satisfies condition f.
+//
+// Return true if s was modified, false otherwise.
+func (s *OrderedSet[T]) RemoveFunc(f func(item T) bool) bool {
+	modified := false
+	for l := s.root.next; l != &s.root; {
+		next := l.next
+		if applies := f(l.element); applies {
+			s.Remove(l.element)
+			modified = true
+		}
+		l = next
+	}
+	return modified
+}
+
+// Contains returns whether item is present in s.
+func (s *OrderedSet[T]) Contains(item T) bool {
+	_, exists := s.items[item]
+	return exists
+}
+
+// ContainsAll returns whether s contains at least every item in items.
+func (s *OrderedSet[T]) ContainsAll(items ...T) bool {
+	for _, item := range items {
+		if !s.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny returns whether s contains at least one of the items passed as
+// an argument.
+func (s *OrderedSet[T]) ContainsAny(items ...T) bool {
+	for _, item := range items {
+		if s.Contains(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsSlice returns whether s contains the same set of of elements
+// that are in items. The elements of items may contain duplicates.
+//
+// If the slice is known to be set-like (no duplicates), EqualSlice provides
+// a more efficient implementation.
+func (s *OrderedSet[T]) ContainsSlice(items []T) bool {
+	return s.Equal(OrderedSetFrom(items))
+}
+
+// ContainsFunc returns whether s contains at least one element that satisfies
+// condition f. Elements are tested in insertion order.
+func (s *OrderedSet[T]) ContainsFunc(f func(item T) bool) bool {
+	for l := s.root.next; l != &s.root; l = l.next {
+		if f(l.element) {
+			return true
+		}
+	}
+	return false
+}
+
+// Subset returns whether o is a subset of s.
+func (s *OrderedSet[T]) Subset(o *OrderedSet[T]) bool {
+	if len(s.items) < len(o.items) {
+		return false
+	}
+	for item := range o.items {
+		if !s.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersects returns whether s and o have at least one element in common.
+//
+// Returns as soon as a common element is found.
+func (s *OrderedSet[T]) Intersects(o *OrderedSet[T]) bool {
+	small, big := s, o
+	if s.Size() > o.Size() {
+		small, big = o, s
+	}
+	for item := range small.items {
+		if big.Contains(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Disjoint returns whether s and o have no elements in common.
+func (s *OrderedSet[T]) Disjoint(o *OrderedSet[T]) bool {
+	return !s.Intersects(o)
+}
+
+// Size returns the cardinality of s.
+func (s *OrderedSet[T]) Size() int {
+	return len(s.items)
+}
+
+// Empty returns true if s contains no elements, false otherwise.
+func (s *OrderedSet[T]) Empty() bool {
+	return s.Size() == 0
+}
+
+// Union returns a set that contains all elements of s and o combined.
+//
+// The elements of s come first in their order, followed by the elements of o
+// that are not in s, in their order.
+func (s *OrderedSet[T]) Union(o *OrderedSet[T]) *OrderedSet[T] {
+	result := NewOrderedSet[T](s.Size() + o.Size())
+	result.InsertSet(s)
+	result.InsertSet(o)
+	return result
+}
+
+// Difference returns a set that contains elements of s that are not in o, in
+// the order of s.
+func (s *OrderedSet[T]) Difference(o *OrderedSet[T]) *OrderedSet[T] {
+	result := NewOrderedSet[T](s.Size())
+	for l := s.root.next; l != &s.root; l = l.next {
+		if !o.Contains(l.element) {
+			result.Insert(l.element)
+		}
+	}
+	return result
+}
+
+// Intersect returns a set that contains elements that are present in both s
+// and o, in the order of s.
+func (s *OrderedSet[T]) Intersect(o *OrderedSet[T]) *OrderedSet[T] {
+	result := NewOrderedSet[T](min(s.Size(), o.Size()))
+	for l := s.root.next; l != &s.root; l = l.next {
+		if o.Contains(l.element) {
+			result.Insert(l.element)
+		}
+	}
+	return result
+}
+
+// Copy creates a copy of s, preserving order.
+func (s *OrderedSet[T]) Copy() *OrderedSet[T] {
+	result := NewOrderedSet[T](s.Size())
+	result.InsertSet(s)
+	return result
+}
+
+// Slice creates a copy of s as a slice. Elements are in insertion order.
+func (s *OrderedSet[T]) Slice() []T {
+	result := make([]T, 0, s.Size())
+	for l := s.root.next; l != &s.root; l = l.next {
+		result = append(result, l.element)
+	}
+	return result
+}
+
+// ForEach calls visit for each element of s, in insertion order. Iteration
+// stops early if visit returns false.
+func (s *OrderedSet[T]) ForEach(visit func(item T) bool) {
+	for l := s.root.next; l != &s.root; l = l.next {
+		if !visit(l.element) {
+			return
+		}
+	}
+}
+
+// String creates a string representation of s, using "%v" printf formatting to transform
+// each element into a string. The result contains elements in insertion order.
+func (s *OrderedSet[T]) String() string {
+	return s.StringFunc(func(element T) string {
+		return fmt.Sprintf("%v", element)
+	})
+}
+
+// StringFunc creates a string representation of s, using f to transform each element
+// into a string. The result contains elements in insertion order.
+func (s *OrderedSet[T]) StringFunc(f func(element T) string) string {
+	l := make([]string, 0, s.Size())
+	for e := s.root.next; e != &s.root; e = e.next {
+		l = append(l, f(e.element))
+	}
+	return fmt.Sprintf("%s", l)
+}
+
+// Equal returns whether s and o contain the same elements.
+//
+// The order of elements is not considered.
+func (s *OrderedSet[T]) Equal(o *OrderedSet[T]) bool {
+	if len(s.items) != len(o.items) {
+		return false
+	}
+	for item := range s.items {
+		if !o.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualSlice returns whether s and items contain the same elements.
+//
+// If items contains duplicates EqualSlice will return false; it is
+// assumed that items is itself set-like. For comparing equality with
+// a slice that may contain duplicates, use ContainsSlice.
+func (s *OrderedSet[T]) EqualSlice(items []T) bool {
+	if len(s.items) != len(items) {
+		return false
+	}
+	return s.ContainsAll(items...)
+}
+
+// Fingerprint returns a hash of the elements of s that does not depend on
+// their order, so that sets containing the same elements have the same
+// fingerprint. Sets with different elements have different fingerprints with
+// high probability.
+//
+// Fingerprints are only comparable within a single process.
+func (s *OrderedSet[T]) Fingerprint() uint64 {
+	return fingerprint(s.ForEach)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s *OrderedSet[T]) MarshalJSON() ([]byte, error) {
+	return marshalJSON[T](s)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// A zero value OrderedSet (e.g. the field of a struct) is initialized before
+// decoding.
+func (s *OrderedSet[T]) UnmarshalJSON(data []byte) error {
+	s.init()
+	return unmarshalJSON[T](s, data)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. Elements
+// are encoded in insertion order.
+func (s *OrderedSet[T]) MarshalBinary() ([]byte, error) {
+	return marshalBinary[T](s)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+//
+// As with UnmarshalJSON, a zero value OrderedSet is initialized before
+// decoding.
+func (s *OrderedSet[T]) UnmarshalBinary(data []byte) error {
+	s.init()
+	return unmarshalBinary[T](s, data)
+}
+
+// init prepares a zero value OrderedSet for use.
+func (s *OrderedSet[T]) init() {
+	if s.items == nil {
+		s.items = make(map[T]*link[T])
+	}
+	if s.root.next == nil {
+		s.root.next = &s.root
+		s.root.prev = &s.root
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestOrderedSet_New(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		s := NewOrderedSet[int](10)
+		must.MapEmpty(t, s.items)
+		must.SliceEmpty(t, s.Slice())
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		s := NewOrderedSet[string](-1)
+		must.MapEmpty(t, s.items)
+	})
+}
+
+func TestOrderedSet_From(t *testing.T) {
+	t.Run("from nil", func(t *testing.T) {
+		s := OrderedSetFrom[string](nil)
+		must.Empty(t, s)
+	})
+
+	t.Run("from some", func(t *testing.T) {
+		s := OrderedSetFrom([]string{"cherry", "apple", "banana", "apple"})
+		must.Eq(t, []string{"cherry", "apple", "banana"}, s.Slice())
+	})
+
+	t.Run("of some", func(t *testing.T) {
+		s := OrderedSetOf(3, 1, 2, 3, 1)
+		must.Eq(t, []int{3, 1, 2}, s.Slice())
+	})
+}
+
+func TestOrderedSet_Collect(t *testing.T) {
+	s := OrderedSetCollect(slices.Values([]string{"c", "a", "c", "b"}))
+	must.Eq(t, []string{"c", "a", "b"}, s.Slice())
+}
+
+func TestOrderedSet_Insert(t *testing.T) {
+	t.Run("preserves order", func(t *testing.T) {
+		s := NewOrderedSet[int](0)
+		must.True(t, s.Insert(5))
+		must.True(t, s.Insert(1))
+		must.True(t, s.Insert(3))
+		must.Eq(t, []int{5, 1, 3}, s.Slice())
+	})
+
+	t.Run("re-insert keeps position", func(t *testing.T) {
+		s := OrderedSetOf(5, 1, 3)
+		must.False(t, s.Insert(5))
+		must.Eq(t, []int{5, 1, 3}, s.Slice())
+	})
+
+	t.Run("insert after remove moves to end", func(t *testing.T) {
+		s := OrderedSetOf(5, 1, 3)
+		must.True(t, s.Remove(5))
+		must.True(t, s.Insert(5))
+		must.Eq(t, []int{1, 3, 5}, s.Slice())
+	})
+
+	t.Run("insert many", func(t *testing.T) {
+		s := NewOrderedSet[string](0)
+		must.True(t, s.InsertMany("b", "a"))
+		must.True(t, s.InsertSlice([]string{"a", "c"}))
+		must.False(t, s.InsertMany("c", "b"))
+		must.Eq(t, []string{"b", "a", "c"}, s.Slice())
+	})
+
+	t.Run("insert set", func(t *testing.T) {
+		a := OrderedSetOf(1, 2, 3)
+		b := OrderedSetOf(6, 3, 5, 4)
+		must.True(t, a.InsertSet(b))
+		must.False(t, a.InsertSet(b))
+		must.Eq(t, []int{1, 2, 3, 6, 5, 4}, a.Slice())
+	})
+}
+
+func TestOrderedSet_Remove(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := NewOrderedSet[int](0)
+		must.False(t, s.Remove(1))
+	})
+
+	t.Run("first middle last", func(t *testing.T) {
+		s := OrderedSetOf(1, 2, 3, 4, 5)
+		must.True(t, s.Remove(1))
+		must.True(t, s.Remove(3))
+		must.True(t, s.Remove(5))
+		must.False(t, s.Remove(5))
+		must.Eq(t, []int{2, 4}, s.Slice())
+		must.MapContainsKeys(t, s.items, []int{2, 4})
+	})
+
+	t.Run("remove many", func(t *testing.T) {
+		s := OrderedSetOf(1, 2, 3, 4, 5)
+		must.True(t, s.RemoveMany(2, 4, 6))
+		must.True(t, s.RemoveSlice([]int{5}))
+		must.False(t, s.RemoveSlice([]int{5}))
+		must.Eq(t, []int{1, 3}, s.Slice())
+	})
+
+	t.Run("remove set", func(t *testing.T) {
+		s := OrderedSetOf(1, 2, 3, 4, 5)
+		must.True(t, s.RemoveSet(OrderedSetOf(4, 2)))
+		must.False(t, s.RemoveSet(OrderedSetOf(4, 2)))
+		must.Eq(t, []int{1, 3, 5}, s.Slice())
+	})
+
+	t.Run("remove func", func(t *testing.T) {
+		s := OrderedSetOf(9, 8, 7, 6, 5, 4, 3, 2, 1)
+		must.True(t, s.RemoveFunc(func(i int) bool { return i%2 == 0 }))
+		must.False(t, s.RemoveFunc(func(i int) bool { return i%2 == 0 }))
+		must.Eq(t, []int{9, 7, 5, 3, 1}, s.Slice())
+	})
+
+	t.Run("remove all", func(t *testing.T) {
+		s := OrderedSetOf(1, 2, 3)
+		must.True(t, s.RemoveMany(3, 1, 2))
+		must.Empty(t, s)
+		must.SliceEmpty(t, s.Slice())
+		must.True(t, s.Insert(4))
+		must.Eq(t, []int{4}, s.Slice())
+	})
+}
+
+func TestOrderedSet_Contains(t *testing.T) {
+	s := OrderedSetOf("apple", "banana", "cherry")
+	must.True(t, s.Contains("banana"))
+	must.False(t, s.Contains("zucchini"))
+	must.True(t, s.ContainsAll("cherry", "apple"))
+	must.False(t, s.ContainsAll("cherry", "zucchini"))
+	must.True(t, OrderedSetOf("apple").ContainsAll("apple", "apple"))
+	must.True(t, s.ContainsAny("zucchini", "apple"))
+	must.False(t, s.ContainsAny("zucchini"))
+	must.True(t, s.ContainsSlice([]string{"banana", "apple", "cherry", "apple"}))
+	must.False(t, s.ContainsSlice([]string{"banana", "apple"}))
+	must.True(t, s.ContainsFunc(func(item string) bool { return len(item) == 6 }))
+	must.False(t, s.ContainsFunc(func(item string) bool { return len(item) == 3 }))
+}
+
+func TestOrderedSet_Subset(t *testing.T) {
+	a := OrderedSetOf(1, 2, 3, 4)
+	must.True(t, a.Subset(OrderedSetOf(3, 1)))
+	must.True(t, a.Subset(NewOrderedSet[int](0)))
+	must.False(t, a.Subset(OrderedSetOf(3, 5)))
+	must.False(t, OrderedSetOf(1).Subset(a))
+}
+
+func TestOrderedSet_Intersects(t *testing.T) {
+	a := OrderedSetOf(1, 2, 3)
+	must.True(t, a.Intersects(OrderedSetOf(5, 4, 3)))
+	must.False(t, a.Intersects(OrderedSetOf(5, 4)))
+	must.True(t, a.Disjoint(NewOrderedSet[int](0)))
+}
+
+func TestOrderedSet_Union(t *testing.T) {
+	a := OrderedSetOf(3, 1, 2)
+	b := OrderedSetOf(5, 2, 4)
+	union := a.Union(b)
+	must.Eq(t, []int{3, 1, 2, 5, 4}, union.Slice())
+	must.Eq(t, []int{3, 1, 2}, a.Slice())
+}
+
+func TestOrderedSet_Difference(t *testing.T) {
+	a := OrderedSetOf(8, 1, 7, 2, 6, 3)
+	b := OrderedSetOf(1, 2, 3)
+	must.Eq(t, []int{8, 7, 6}, a.Difference(b).Slice())
+	must.Empty(t, b.Difference(a))
+}
+
+func TestOrderedSet_Intersect(t *testing.T) {
+	a := OrderedSetOf(8, 1, 7, 2, 6, 3)
+	b := OrderedSetOf(3, 2, 9)
+	must.Eq(t, []int{2, 3}, a.Intersect(b).Slice())
+	must.Eq(t, []int{3, 2}, b.Intersect(a).Slice())
+}
+
+func TestOrderedSet_Copy(t *testing.T) {
+	a := OrderedSetOf(3, 1, 2)
+	b := a.Copy()
+	must.Eq(t, []int{3, 1, 2}, b.Slice())
+	must.True(t, b.Remove(1))
+	must.True(t, b.Insert(1))
+	must.Eq(t, []int{3, 2, 1}, b.Slice())
+	must.Eq(t, []int{3, 1, 2}, a.Slice())
+}
+
+func TestOrderedSet_ForEach(t *testing.T) {
+	t.Run("in order", func(t *testing.T) {
+		s := OrderedSetOf(5, 3, 4, 1)
+		result := make([]int, 0, 4)
+		s.ForEach(func(i int) bool {
+			result = append(result, i)
+			return true
+		})
+		must.Eq(t, []int{5, 3, 4, 1}, result)
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		s := OrderedSetOf(5, 3, 4, 1)
+		result := make([]int, 0, 4)
+		s.ForEach(func(i int) bool {
+			result = append(result, i)
+			return i != 3
+		})
+		must.Eq(t, []int{5, 3}, result)
+	})
+}
+
+func TestOrderedSet_String(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := NewOrderedSet[int](0)
+		must.Eq(t, "[]", s.String())
+	})
+
+	t.Run("insertion order", func(t *testing.T) {
+		s := OrderedSetOf(100, 9, 20, 3)
+		must.Eq(t, "[100 9 20 3]", s.String())
+	})
+
+	t.Run("func", func(t *testing.T) {
+		s := OrderedSetOf(employee{"bob", 2}, employee{"alice", 1})
+		result := s.StringFunc(func(e employee) string {
+			return fmt.Sprintf("(%d %s)", e.id, e.name)
+		})
+		must.Eq(t, "[(2 bob) (1 alice)]", result)
+	})
+}
+
+func TestOrderedSet_Equal(t *testing.T) {
+	a := OrderedSetOf(1, 2, 3)
+	must.True(t, a.Equal(OrderedSetOf(3, 2, 1)))
+	must.False(t, a.Equal(OrderedSetOf(1, 2)))
+	must.False(t, a.Equal(OrderedSetOf(1, 2, 4)))
+	must.True(t, a.EqualSlice([]int{2, 3, 1}))
+	must.False(t, a.EqualSlice([]int{1, 2, 4}))
+}
+
+func TestOrderedSet_JSON(t *testing.T) {
+	a := OrderedSetOf("c", "a", "b")
+	bs, err := json.Marshal(a)
+	must.NoError(t, err)
+	must.Eq(t, `["c","a","b"]`, string(bs))
+
+	b := NewOrderedSet[string](0)
+	must.NoError(t, json.Unmarshal(bs, b))
+	must.Eq(t, []string{"c", "a", "b"}, b.Slice())
+
+	t.Run("zero value", func(t *testing.T) {
+		var c struct{ S OrderedSet[string] }
+		must.NoError(t, json.Unmarshal([]byte(`{"S":["c","a","b","a"]}`), &c))
+		must.Eq(t, []string{"c", "a", "b"}, c.S.Slice())
+
+		bs, err := a.MarshalBinary()
+		must.NoError(t, err)
+		var d OrderedSet[string]
+		must.NoError(t, d.UnmarshalBinary(bs))
+		must.Eq(t, []string{"c", "a", "b"}, d.Slice())
+	})
+}
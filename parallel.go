@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"maps"
+	"runtime"
+	"sync"
+)
+
+// parallelThreshold is the least number of elements to be probed for which
+// the parallel set operations start goroutines; smaller operations are
+// faster on one goroutine.
+const parallelThreshold = 4096
+
+// UnionParallel returns a set that contains all elements of s and o combined,
+// as with Union, probing elements on up to parallelism goroutines. A
+// parallelism of zero or less uses runtime.GOMAXPROCS goroutines.
+//
+// The larger of s and o is copied in bulk, while the elements of the smaller
+// are checked against it concurrently, so UnionParallel is fastest when the
+// operands share many elements. Neither s nor o may be modified until
+// UnionParallel returns.
+func (s *Set[T]) UnionParallel(o *Set[T], parallelism int) *Set[T] {
+	big, small := s, o
+	if s.Size() < o.Size() {
+		big, small = o, s
+	}
+	result := &Set[T]{items: maps.Clone(big.items)}
+	if result.items == nil {
+		result.items = make(map[T]nothing)
+	}
+	for _, part := range parallelFilter(small.Slice(), parallelism, func(item T) bool {
+		return !big.Contains(item)
+	}) {
+		for _, item := range part {
+			result.items[item] = sentinel
+		}
+	}
+	return result
+}
+
+// IntersectParallel returns a set that contains elements that are present in
+// both s and o, as with Intersect, probing elements on up to parallelism
+// goroutines. A parallelism of zero or less uses runtime.GOMAXPROCS
+// goroutines.
+//
+// Neither s nor o may be modified until IntersectParallel returns.
+func (s *Set[T]) IntersectParallel(o *Set[T], parallelism int) *Set[T] {
+	big, small := s, o
+	if s.Size() < o.Size() {
+		big, small = o, s
+	}
+	parts := parallelFilter(small.Slice(), parallelism, big.Contains)
+	size := 0
+	for _, part := range parts {
+		size += len(part)
+	}
+	result := New[T](size)
+	for _, part := range parts {
+		for _, item := range part {
+			result.items[item] = sentinel
+		}
+	}
+	return result
+}
+
+// parallelFilter returns the items for which keep returns true, calling keep
+// on chunks of items concurrently on up to parallelism goroutines. The result
+// holds the kept items of each chunk.
+func parallelFilter[T any](items []T, parallelism int, keep func(T) bool) [][]T {
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	parallelism = max(1, min(parallelism, len(items)/parallelThreshold))
+
+	parts := make([][]T, parallelism)
+	filter := func(i int) {
+		chunk := items[i*len(items)/parallelism : (i+1)*len(items)/parallelism]
+		for _, item := range chunk {
+			if keep(item) {
+				parts[i] = append(parts[i], item)
+			}
+		}
+	}
+	if parallelism == 1 {
+		filter(0)
+		return parts
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(parallelism)
+	for i := range parallelism {
+		go func() {
+			defer wg.Done()
+			filter(i)
+		}()
+	}
+	wg.Wait()
+	return parts
+}
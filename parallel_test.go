@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestSet_UnionParallel(t *testing.T) {
+	for _, n := range []int{0, 10, 10_000, 100_000} {
+		a := From(ints(n))
+		b := FromRange(n/2, n+n/2, 1)
+		for _, parallelism := range []int{0, 1, 4} {
+			t.Run(strconv.Itoa(n)+"/"+strconv.Itoa(parallelism), func(t *testing.T) {
+				result := a.UnionParallel(b, parallelism)
+				must.Eq(t, a.Union(b), result)
+				must.Eq(t, result, b.UnionParallel(a, parallelism))
+
+				// the result is independent of its operands
+				result.Insert(-1)
+				must.NotContains[int](t, -1, a)
+				must.NotContains[int](t, -1, b)
+			})
+		}
+	}
+}
+
+func TestSet_IntersectParallel(t *testing.T) {
+	for _, n := range []int{0, 10, 10_000, 100_000} {
+		a := From(ints(n))
+		b := FromRange(n/2, n+n/2, 1)
+		for _, parallelism := range []int{0, 1, 4} {
+			t.Run(strconv.Itoa(n)+"/"+strconv.Itoa(parallelism), func(t *testing.T) {
+				result := a.IntersectParallel(b, parallelism)
+				must.Eq(t, a.Intersect(b), result)
+				must.Eq(t, result, b.IntersectParallel(a, parallelism))
+			})
+		}
+	}
+
+	t.Run("zero value", func(t *testing.T) {
+		var a, b Set[int]
+		must.True(t, a.IntersectParallel(&b, 0).Empty())
+		result := a.UnionParallel(&b, 0)
+		must.True(t, result.Insert(1))
+	})
+}
+
+func BenchmarkSet_UnionParallel(b *testing.B) {
+	s := From(random[int](1_000_000))
+	o := From(append(s.Slice()[:500_000], random[int](500_000)...))
+	for _, parallelism := range []int{1, 4} {
+		b.Run(strconv.Itoa(parallelism), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = s.UnionParallel(o, parallelism)
+			}
+		})
+	}
+}
+
+func BenchmarkSet_IntersectParallel(b *testing.B) {
+	s := From(random[int](1_000_000))
+	o := From(append(s.Slice()[:500_000], random[int](500_000)...))
+	for _, parallelism := range []int{1, 4} {
+		b.Run(strconv.Itoa(parallelism), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = s.IntersectParallel(o, parallelism)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+	"sort"
+)
+
+const (
+	// perfectBucketSize is the average number of elements per bucket of a
+	// PerfectSet; larger buckets use less memory but take longer to build.
+	perfectBucketSize = 4
+
+	// perfectDirect marks the seed of a bucket holding a single element,
+	// whose remaining bits are the slot of the element.
+	perfectDirect = 1 << 31
+)
+
+// PerfectSet is a read-only set backed by a minimal perfect hash function,
+// which maps each of its n elements to a distinct slot from 0 up to n. A
+// PerfectSet answers Contains with one hash of the element and a single
+// comparison, and needs only about one byte of memory per element beyond the
+// elements themselves. A PerfectSet is built once from a set whose elements
+// are known in advance, such as a large static denylist.
+//
+// The perfect hash function is built with the hash and displace algorithm.
+// Elements are divided into buckets, and a seed is found for each bucket such
+// that the elements of the bucket hash to slots not taken by other buckets.
+//
+// http://cmph.sourceforge.net/papers/esa09.pdf
+//
+// A PerfectSet is immutable, and safe for concurrent use.
+type PerfectSet[T comparable] struct {
+	hash  func(T) uint64
+	seeds []uint32
+	items []T
+}
+
+// BuildPerfectSet creates a PerfectSet containing each element of s, hashing
+// elements with hash.
+//
+// Returns an error if two elements of s have the same hash, in which case a
+// different hash function must be used.
+func BuildPerfectSet[T comparable](s ReadOnly[T], hash func(T) uint64) (*PerfectSet[T], error) {
+	n := s.Size()
+	p := &PerfectSet[T]{
+		hash:  hash,
+		seeds: make([]uint32, max(1, n/perfectBucketSize)),
+		items: make([]T, n),
+	}
+	if n == 0 {
+		return p, nil
+	}
+
+	type entry struct {
+		item   T
+		hash   uint64
+		bucket int
+	}
+	entries := make([]entry, 0, n)
+	s.ForEach(func(item T) bool {
+		h := mix(hash(item))
+		entries = append(entries, entry{item: item, hash: h, bucket: perfectBucket(h, len(p.seeds))})
+		return true
+	})
+	slices.SortFunc(entries, func(a, b entry) int {
+		if c := Cmp(a.bucket, b.bucket); c != 0 {
+			return c
+		}
+		return Cmp(a.hash, b.hash)
+	})
+	for i := 1; i < n; i++ {
+		if entries[i].hash == entries[i-1].hash {
+			return nil, fmt.Errorf("set: elements %v and %v have the same hash", entries[i-1].item, entries[i].item)
+		}
+	}
+
+	// the entries of each bucket, placed largest bucket first
+	var buckets [][]entry
+	for i := 0; i < n; {
+		j := i + 1
+		for j < n && entries[j].bucket == entries[i].bucket {
+			j++
+		}
+		buckets = append(buckets, entries[i:j])
+		i = j
+	}
+	sort.SliceStable(buckets, func(i, j int) bool {
+		return len(buckets[i]) > len(buckets[j])
+	})
+
+	taken := make([]uint64, (n+63)/64)
+	isTaken := func(slot int) bool {
+		return taken[slot/64]&(1<<(slot%64)) != 0
+	}
+	slots := make([]int, 0, perfectBucketSize)
+	free := 0
+	for _, bucket := range buckets {
+		if len(bucket) == 1 {
+			// a single element takes the next free slot directly
+			for isTaken(free) {
+				free++
+			}
+			p.place(bucket[0].bucket, perfectDirect|uint32(free), free, bucket[0].item)
+			taken[free/64] |= 1 << (free % 64)
+			continue
+		}
+
+		placed := false
+		for seed := uint32(0); seed < perfectDirect; seed++ {
+			slots = slots[:0]
+			for _, e := range bucket {
+				slot := perfectSlot(e.hash, seed, n)
+				if isTaken(slot) || slices.Contains(slots, slot) {
+					break
+				}
+				slots = append(slots, slot)
+			}
+			if len(slots) < len(bucket) {
+				continue
+			}
+			for i, e := range bucket {
+				p.place(e.bucket, seed, slots[i], e.item)
+				taken[slots[i]/64] |= 1 << (slots[i] % 64)
+			}
+			placed = true
+			break
+		}
+		if !placed {
+			return nil, fmt.Errorf("set: no perfect hash found for %d elements", n)
+		}
+	}
+	return p, nil
+}
+
+// place records the seed of bucket, and stores item in slot.
+func (p *PerfectSet[T]) place(bucket int, seed uint32, slot int, item T) {
+	p.seeds[bucket] = seed
+	p.items[slot] = item
+}
+
+// perfectBucket returns which of buckets holds an element with hash h. Hashes
+// are mixed before use, since the upper bits of hashes such as FNV are poorly
+// distributed for similar elements.
+func perfectBucket(h uint64, buckets int) int {
+	hi, _ := bits.Mul64(h, uint64(buckets))
+	return int(hi)
+}
+
+// perfectSlot returns which of slots holds an element with hash h in a bucket
+// with seed.
+func perfectSlot(h uint64, seed uint32, slots int) int {
+	if seed&perfectDirect != 0 {
+		return int(seed &^ perfectDirect)
+	}
+	hi, _ := bits.Mul64(mix(h^mix(uint64(seed)+1)), uint64(slots))
+	return int(hi)
+}
+
+// Contains returns whether item is present in p.
+func (p *PerfectSet[T]) Contains(item T) bool {
+	if len(p.items) == 0 {
+		return false
+	}
+	h := mix(p.hash(item))
+	return p.items[perfectSlot(h, p.seeds[perfectBucket(h, len(p.seeds))], len(p.items))] == item
+}
+
+// Size returns the cardinality of p.
+func (p *PerfectSet[T]) Size() int {
+	return len(p.items)
+}
+
+// Empty returns true if p contains no elements, false otherwise.
+func (p *PerfectSet[T]) Empty() bool {
+	return len(p.items) == 0
+}
+
+// Slice creates a copy of p as a slice. Elements are in no particular order.
+func (p *PerfectSet[T]) Slice() []T {
+	return slices.Clone(p.items)
+}
+
+// ForEach calls visit for each element of p, in no particular order.
+// Iteration stops early if visit returns false.
+func (p *PerfectSet[T]) ForEach(visit func(item T) bool) {
+	for _, item := range p.items {
+		if !visit(item) {
+			return
+		}
+	}
+}
+
+// String creates a string representation of p, using "%v" printf formatting to transform
+// each element into a string. The result contains elements sorted by their lexical
+// string order.
+func (p *PerfectSet[T]) String() string {
+	l := make([]string, 0, len(p.items))
+	for _, item := range p.items {
+		l = append(l, fmt.Sprintf("%v", item))
+	}
+	sort.Strings(l)
+	return fmt.Sprintf("%s", l)
+}
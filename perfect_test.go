@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestBuildPerfectSet(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		p, err := BuildPerfectSet[string](New[string](0), fnvString)
+		must.NoError(t, err)
+		must.True(t, p.Empty())
+		must.Eq(t, 0, p.Size())
+		must.False(t, p.Contains("a"))
+		must.Eq(t, "[]", p.String())
+	})
+
+	t.Run("one", func(t *testing.T) {
+		p, err := BuildPerfectSet[string](From([]string{"a"}), fnvString)
+		must.NoError(t, err)
+		must.True(t, p.Contains("a"))
+		must.False(t, p.Contains("b"))
+		must.Eq(t, "[a]", p.String())
+	})
+
+	t.Run("collision", func(t *testing.T) {
+		constant := func(string) uint64 { return 7 }
+		_, err := BuildPerfectSet[string](From([]string{"a", "b"}), constant)
+		must.ErrorContains(t, err, "have the same hash")
+	})
+}
+
+func TestPerfectSet_Contains(t *testing.T) {
+	for _, n := range []int{2, 3, 5, 17, 100, 10_000} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			s := New[string](n)
+			for i := range n {
+				s.Insert("item-" + strconv.Itoa(i))
+			}
+			p, err := BuildPerfectSet[string](s, fnvString)
+			must.NoError(t, err)
+			must.Eq(t, n, p.Size())
+			must.False(t, p.Empty())
+
+			for i := range n {
+				must.True(t, p.Contains("item-"+strconv.Itoa(i)))
+				must.False(t, p.Contains("other-"+strconv.Itoa(i)))
+			}
+			must.Eq(t, s, From(p.Slice()))
+		})
+	}
+}
+
+func TestPerfectSet_ForEach(t *testing.T) {
+	p, err := BuildPerfectSet[int](From(ints(10)), func(i int) uint64 { return mix(uint64(i)) })
+	must.NoError(t, err)
+
+	visited := New[int](10)
+	p.ForEach(func(i int) bool {
+		visited.Insert(i)
+		return true
+	})
+	must.Eq(t, From(ints(10)), visited)
+
+	count := 0
+	p.ForEach(func(int) bool {
+		count++
+		return count < 3
+	})
+	must.Eq(t, 3, count)
+	must.Eq(t, "[1 10 2 3 4 5 6 7 8 9]", p.String())
+}
+
+func BenchmarkPerfectSet_Contains(b *testing.B) {
+	s := New[string](100_000)
+	for i := range 100_000 {
+		s.Insert("item-" + strconv.Itoa(i))
+	}
+	p, err := BuildPerfectSet[string](s, fnvString)
+	must.NoError(b, err)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Contains("item-" + strconv.Itoa(i%200_000))
+	}
+}
@@ -0,0 +1,375 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"hash/maphash"
+	"math/bits"
+	"slices"
+	"sort"
+)
+
+// hamtBits is the number of bits of an element hash consumed per level of
+// the trie of a PersistentSet.
+const hamtBits = 5
+
+// PersistentSet is an immutable hash set with structural sharing. Insert and
+// Remove return a new version of the set, leaving the original unchanged,
+// while sharing all but O(log n) of their storage with it. Keeping many
+// versions of a large set is therefore cheap, and a version may be shared
+// between goroutines without locking.
+//
+// The underlying data structure is a Hash Array Mapped Trie, in which each
+// node is indexed by 5 bits of the hash of an element, and stores only the
+// children that are present.
+//
+// https://en.wikipedia.org/wiki/Hash_array_mapped_trie
+//
+// The zero value is an empty set.
+type PersistentSet[T comparable] struct {
+	root *hamtNode[T]
+	size int
+}
+
+// hamtNode is a node of the trie of a PersistentSet. The bit of bitmap for
+// each present child is set, and entries holds the children in order of
+// their bits.
+type hamtNode[T comparable] struct {
+	bitmap  uint32
+	entries []hamtEntry[T]
+}
+
+// hamtEntry is a child of a hamtNode, which is either a subtrie node, or a
+// leaf of the elements sharing one full hash.
+type hamtEntry[T comparable] struct {
+	node  *hamtNode[T]
+	hash  uint64
+	items []T
+}
+
+// NewPersistentSet creates a new empty PersistentSet.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func NewPersistentSet[T comparable]() *PersistentSet[T] {
+	return new(PersistentSet[T])
+}
+
+// PersistentSetFrom creates a new PersistentSet containing each item in items.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func PersistentSetFrom[T comparable](items []T) *PersistentSet[T] {
+	return NewPersistentSet[T]().InsertSlice(items)
+}
+
+// PersistentSetOf creates a new PersistentSet containing each item passed as
+// an argument.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func PersistentSetOf[T comparable](items ...T) *PersistentSet[T] {
+	return PersistentSetFrom(items)
+}
+
+func hamtHash[T comparable](item T) uint64 {
+	return maphash.Comparable(fingerprintSeed, item)
+}
+
+// Insert returns a version of s that also contains item. If item is already
+// in s, s itself is returned.
+func (s *PersistentSet[T]) Insert(item T) *PersistentSet[T] {
+	root, added := s.root.insert(hamtHash(item), 0, item)
+	if !added {
+		return s
+	}
+	return &PersistentSet[T]{root: root, size: s.size + 1}
+}
+
+// InsertSlice returns a version of s that also contains each item in items.
+// If every item is already in s, s itself is returned.
+func (s *PersistentSet[T]) InsertSlice(items []T) *PersistentSet[T] {
+	result := s
+	for _, item := range items {
+		result = result.Insert(item)
+	}
+	return result
+}
+
+// Remove returns a version of s that does not contain item. If item is not
+// in s, s itself is returned.
+func (s *PersistentSet[T]) Remove(item T) *PersistentSet[T] {
+	root, removed := s.root.remove(hamtHash(item), 0, item)
+	if !removed {
+		return s
+	}
+	return &PersistentSet[T]{root: root, size: s.size - 1}
+}
+
+// RemoveSlice returns a version of s that does not contain any item in items.
+// If no item is in s, s itself is returned.
+func (s *PersistentSet[T]) RemoveSlice(items []T) *PersistentSet[T] {
+	result := s
+	for _, item := range items {
+		result = result.Remove(item)
+	}
+	return result
+}
+
+// Contains returns whether item is present in s.
+func (s *PersistentSet[T]) Contains(item T) bool {
+	h := hamtHash(item)
+	n := s.root
+	for shift := 0; n != nil; shift += hamtBits {
+		e, ok := n.find(h, shift)
+		if !ok {
+			return false
+		}
+		if e.node == nil {
+			return e.hash == h && slices.Contains(e.items, item)
+		}
+		n = e.node
+	}
+	return false
+}
+
+// Size returns the cardinality of s.
+func (s *PersistentSet[T]) Size() int {
+	return s.size
+}
+
+// Empty returns true if s contains no elements, false otherwise.
+func (s *PersistentSet[T]) Empty() bool {
+	return s.size == 0
+}
+
+// Union returns a set that contains all elements of s and o combined. The
+// elements of the smaller set are inserted into the larger.
+func (s *PersistentSet[T]) Union(o *PersistentSet[T]) *PersistentSet[T] {
+	small, big := s, o
+	if s.size > o.size {
+		small, big = o, s
+	}
+	result := big
+	small.ForEach(func(item T) bool {
+		result = result.Insert(item)
+		return true
+	})
+	return result
+}
+
+// Difference returns a set that contains elements of s that are not in o.
+func (s *PersistentSet[T]) Difference(o *PersistentSet[T]) *PersistentSet[T] {
+	result := s
+	s.ForEach(func(item T) bool {
+		if o.Contains(item) {
+			result = result.Remove(item)
+		}
+		return true
+	})
+	return result
+}
+
+// Intersect returns a set that contains elements that are present in both s and o.
+func (s *PersistentSet[T]) Intersect(o *PersistentSet[T]) *PersistentSet[T] {
+	return s.Difference(s.Difference(o))
+}
+
+// Subset returns whether o is a subset of s.
+func (s *PersistentSet[T]) Subset(o *PersistentSet[T]) bool {
+	if s.size < o.size {
+		return false
+	}
+	subset := true
+	o.ForEach(func(item T) bool {
+		subset = s.Contains(item)
+		return subset
+	})
+	return subset
+}
+
+// Equal returns whether s and o contain the same elements.
+func (s *PersistentSet[T]) Equal(o *PersistentSet[T]) bool {
+	return s.size == o.size && s.Subset(o)
+}
+
+// Slice creates a copy of s as a slice. Elements are in no particular order.
+func (s *PersistentSet[T]) Slice() []T {
+	result := make([]T, 0, s.size)
+	s.ForEach(func(item T) bool {
+		result = append(result, item)
+		return true
+	})
+	return result
+}
+
+// ForEach calls visit for each element of s, in no particular order.
+// Iteration stops early if visit returns false.
+func (s *PersistentSet[T]) ForEach(visit func(item T) bool) {
+	s.root.each(visit)
+}
+
+// Set creates a mutable Set containing the elements of s.
+func (s *PersistentSet[T]) Set() *Set[T] {
+	result := New[T](s.size)
+	s.ForEach(func(item T) bool {
+		result.Insert(item)
+		return true
+	})
+	return result
+}
+
+// String creates a string representation of s, using "%v" printf formatting to transform
+// each element into a string. The result contains elements sorted by their lexical
+// string order.
+func (s *PersistentSet[T]) String() string {
+	l := make([]string, 0, s.size)
+	s.ForEach(func(item T) bool {
+		l = append(l, fmt.Sprintf("%v", item))
+		return true
+	})
+	sort.Strings(l)
+	return fmt.Sprintf("%s", l)
+}
+
+// position returns the bit of n for hash h at shift, and the index into the
+// entries of n at which a child with that bit is or would be.
+func (n *hamtNode[T]) position(h uint64, shift int) (uint32, int) {
+	bit := uint32(1) << ((h >> shift) & (1<<hamtBits - 1))
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *hamtNode[T]) find(h uint64, shift int) (hamtEntry[T], bool) {
+	bit, i := n.position(h, shift)
+	if n.bitmap&bit == 0 {
+		return hamtEntry[T]{}, false
+	}
+	return n.entries[i], true
+}
+
+// with returns a copy of n with the entry at index i replaced by e.
+func (n *hamtNode[T]) with(i int, e hamtEntry[T]) *hamtNode[T] {
+	entries := slices.Clone(n.entries)
+	entries[i] = e
+	return &hamtNode[T]{bitmap: n.bitmap, entries: entries}
+}
+
+// insert returns a copy of the trie rooted at n with item added, and whether
+// item was not already present. n may be nil.
+func (n *hamtNode[T]) insert(h uint64, shift int, item T) (*hamtNode[T], bool) {
+	leaf := hamtEntry[T]{hash: h, items: []T{item}}
+	if n == nil {
+		return &hamtNode[T]{
+			bitmap:  1 << ((h >> shift) & (1<<hamtBits - 1)),
+			entries: []hamtEntry[T]{leaf},
+		}, true
+	}
+
+	bit, i := n.position(h, shift)
+	if n.bitmap&bit == 0 {
+		return &hamtNode[T]{
+			bitmap:  n.bitmap | bit,
+			entries: slices.Insert(slices.Clone(n.entries), i, leaf),
+		}, true
+	}
+
+	e := n.entries[i]
+	switch {
+	case e.node != nil:
+		child, added := e.node.insert(h, shift+hamtBits, item)
+		if !added {
+			return n, false
+		}
+		return n.with(i, hamtEntry[T]{node: child}), true
+	case e.hash == h:
+		if slices.Contains(e.items, item) {
+			return n, false
+		}
+		items := append(slices.Clip(e.items), item)
+		return n.with(i, hamtEntry[T]{hash: h, items: items}), true
+	default:
+		// two leaves with different hashes; push both down a level, where
+		// their hashes may differ
+		child := &hamtNode[T]{
+			bitmap:  1 << ((e.hash >> (shift + hamtBits)) & (1<<hamtBits - 1)),
+			entries: []hamtEntry[T]{e},
+		}
+		child, _ = child.insert(h, shift+hamtBits, item)
+		return n.with(i, hamtEntry[T]{node: child}), true
+	}
+}
+
+// remove returns a copy of the trie rooted at n without item, and whether
+// item was present. The result is nil if the trie becomes empty.
+func (n *hamtNode[T]) remove(h uint64, shift int, item T) (*hamtNode[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+	bit, i := n.position(h, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+
+	e := n.entries[i]
+	var replacement *hamtEntry[T]
+	switch {
+	case e.node != nil:
+		child, removed := e.node.remove(h, shift+hamtBits, item)
+		if !removed {
+			return n, false
+		}
+		switch {
+		case child == nil:
+			// the child is now empty, and is dropped
+		case len(child.entries) == 1 && child.entries[0].node == nil:
+			// a child of a single leaf is replaced by the leaf
+			replacement = &child.entries[0]
+		default:
+			replacement = &hamtEntry[T]{node: child}
+		}
+	case e.hash == h:
+		j := slices.Index(e.items, item)
+		if j < 0 {
+			return n, false
+		}
+		if len(e.items) > 1 {
+			items := slices.Delete(slices.Clone(e.items), j, j+1)
+			replacement = &hamtEntry[T]{hash: h, items: items}
+		}
+	default:
+		return n, false
+	}
+
+	if replacement != nil {
+		return n.with(i, *replacement), true
+	}
+	if len(n.entries) == 1 {
+		return nil, true
+	}
+	return &hamtNode[T]{
+		bitmap:  n.bitmap &^ bit,
+		entries: slices.Delete(slices.Clone(n.entries), i, i+1),
+	}, true
+}
+
+func (n *hamtNode[T]) each(visit func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	for _, e := range n.entries {
+		if e.node != nil {
+			if !e.node.each(visit) {
+				return false
+			}
+			continue
+		}
+		for _, item := range e.items {
+			if !visit(item) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestPersistentSet_Insert(t *testing.T) {
+	var zero PersistentSet[int]
+	must.True(t, zero.Empty())
+	must.False(t, zero.Contains(1))
+
+	a := NewPersistentSet[int]()
+	b := a.Insert(1)
+	c := b.Insert(2)
+	must.Eq(t, 0, a.Size())
+	must.Eq(t, 1, b.Size())
+	must.Eq(t, 2, c.Size())
+	must.False(t, b.Contains(2))
+	must.True(t, c.Contains(1))
+	must.True(t, c.Contains(2))
+
+	// inserting a present element returns the same version
+	must.Eq(t, c, c.Insert(1))
+	must.Eq(t, "[1 2]", c.String())
+}
+
+func TestPersistentSet_Remove(t *testing.T) {
+	a := PersistentSetFrom(ints(100))
+	b := a.Remove(50)
+	must.Eq(t, 100, a.Size())
+	must.Eq(t, 99, b.Size())
+	must.True(t, a.Contains(50))
+	must.False(t, b.Contains(50))
+	must.Eq(t, b, b.Remove(50))
+	must.Eq(t, b, b.Remove(1000))
+
+	empty := b.RemoveSlice(ints(100))
+	must.True(t, empty.Empty())
+	must.Nil(t, empty.root)
+}
+
+func TestPersistentSet_versions(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	versions := []*PersistentSet[int]{NewPersistentSet[int]()}
+	models := []*Set[int]{New[int](0)}
+	for i := 0; i < 2000; i++ {
+		item := rng.Intn(500)
+		last := versions[len(versions)-1]
+		model := models[len(models)-1].Copy()
+		if rng.Intn(3) == 0 {
+			last = last.Remove(item)
+			model.Remove(item)
+		} else {
+			last = last.Insert(item)
+			model.Insert(item)
+		}
+		versions = append(versions, last)
+		models = append(models, model)
+	}
+
+	// every version still holds exactly the elements it held when created
+	for i, version := range versions {
+		must.Eq(t, models[i].Size(), version.Size())
+		must.True(t, models[i].Equal(version.Set()))
+		for item := 0; item < 500; item++ {
+			must.Eq(t, models[i].Contains(item), version.Contains(item))
+		}
+	}
+}
+
+func TestPersistentSet_collisions(t *testing.T) {
+	// drive the trie directly with chosen hashes, so that elements share
+	// full hashes and long hash prefixes
+	var root *hamtNode[string]
+	insert := func(h uint64, item string) bool {
+		var added bool
+		root, added = root.insert(h, 0, item)
+		return added
+	}
+	remove := func(h uint64, item string) bool {
+		var removed bool
+		root, removed = root.remove(h, 0, item)
+		return removed
+	}
+	items := func() *Set[string] {
+		result := New[string](0)
+		root.each(func(item string) bool {
+			result.Insert(item)
+			return true
+		})
+		return result
+	}
+
+	must.True(t, insert(7, "a"))
+	must.True(t, insert(7, "b"))
+	must.False(t, insert(7, "a"))
+	must.True(t, insert(7|1<<60, "c"))
+	must.True(t, insert(8, "d"))
+	must.True(t, items().EqualSlice([]string{"a", "b", "c", "d"}))
+
+	must.False(t, remove(7, "c"))
+	must.False(t, remove(9, "a"))
+	must.True(t, remove(7, "a"))
+	must.True(t, remove(7, "b"))
+	must.True(t, items().EqualSlice([]string{"c", "d"}))
+
+	// the deep subtrie collapses back into a leaf
+	must.Nil(t, root.entries[0].node)
+	must.True(t, remove(7|1<<60, "c"))
+	must.True(t, remove(8, "d"))
+	must.Nil(t, root)
+}
+
+func TestPersistentSet_Algebra(t *testing.T) {
+	a := PersistentSetOf(1, 2, 3, 4)
+	b := PersistentSetOf(3, 4, 5)
+	must.Eq(t, "[1 2 3 4 5]", a.Union(b).String())
+	must.Eq(t, "[1 2]", a.Difference(b).String())
+	must.Eq(t, "[3 4]", a.Intersect(b).String())
+	must.True(t, a.Subset(PersistentSetOf(2, 3)))
+	must.False(t, a.Subset(b))
+	must.True(t, a.Equal(PersistentSetOf(4, 3, 2, 1)))
+	must.False(t, a.Equal(b))
+
+	// operands are unchanged
+	must.Eq(t, "[1 2 3 4]", a.String())
+	must.Eq(t, "[3 4 5]", b.String())
+}
+
+func TestPersistentSet_ForEach(t *testing.T) {
+	s := PersistentSetFrom(ints(1000))
+	must.SliceContainsAll(t, ints(1000), s.Slice())
+
+	visits := 0
+	s.ForEach(func(int) bool {
+		visits++
+		return visits < 10
+	})
+	must.Eq(t, 10, visits)
+}
+
+func TestPersistentSet_concurrent(t *testing.T) {
+	base := PersistentSetFrom(ints(1000))
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			s := base
+			for i := 0; i < 100; i++ {
+				s = s.Insert(1000 + w*100 + i + 1).Remove(i + 1)
+			}
+			must.Eq(t, 1000, s.Size())
+		}(w)
+	}
+	wg.Wait()
+	must.Eq(t, 1000, base.Size())
+	must.True(t, base.Contains(1))
+}
@@ -3,7 +3,16 @@
 
 package set
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
 
 // serializable is an interface that allows a set to be serialized
 type serializable[T any] interface {
@@ -26,3 +35,172 @@ func unmarshalJSON[T any](s serializable[T], data []byte) error {
 	s.InsertSlice(slice)
 	return nil
 }
+
+// encodeGob will serialize a Serializable[T] into a gob byte array
+func encodeGob[T any](s serializable[T]) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s.Slice()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeGob will deserialize a gob byte array into a Serializable[T]
+func decodeGob[T any](s serializable[T], data []byte) error {
+	slice := make([]T, 0)
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&slice)
+	if err != nil {
+		return err
+	}
+	s.InsertSlice(slice)
+	return nil
+}
+
+// The binary format of a set, as produced by MarshalBinary, is
+//
+//	version  byte    (binaryVersion)
+//	encoding byte    (how each element is encoded)
+//	count    uvarint (number of elements)
+//	elements
+//
+// Elements are encoded according to the kind of T: signed integers as varints,
+// unsigned integers as uvarints, strings as a uvarint length followed by their
+// bytes, and floats as the 8 byte big endian IEEE 754 bits of a float64. The
+// elements of any other type are encoded together as a gob encoded slice.
+//
+// Elements appear in the order produced by Slice, so the elements of a TreeSet
+// are encoded in sorted order.
+const binaryVersion = 1
+
+const (
+	binaryVarint byte = iota + 1
+	binaryUvarint
+	binaryString
+	binaryFloat
+	binaryGob
+)
+
+var errBinaryCorrupt = errors.New("set: corrupt binary data")
+
+// binaryEncoding returns the encoding used for elements of type t
+func binaryEncoding(t reflect.Type) byte {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binaryVarint
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binaryUvarint
+	case reflect.String:
+		return binaryString
+	case reflect.Float32, reflect.Float64:
+		return binaryFloat
+	default:
+		return binaryGob
+	}
+}
+
+// marshalBinary will serialize a Serializable[T] into the binary format
+func marshalBinary[T any](s serializable[T]) ([]byte, error) {
+	items := s.Slice()
+	encoding := binaryEncoding(reflect.TypeFor[T]())
+	buf := []byte{binaryVersion, encoding}
+	buf = binary.AppendUvarint(buf, uint64(len(items)))
+
+	if encoding == binaryGob {
+		var b bytes.Buffer
+		if err := gob.NewEncoder(&b).Encode(items); err != nil {
+			return nil, err
+		}
+		return append(buf, b.Bytes()...), nil
+	}
+
+	for i := range items {
+		v := reflect.ValueOf(&items[i]).Elem()
+		switch encoding {
+		case binaryVarint:
+			buf = binary.AppendVarint(buf, v.Int())
+		case binaryUvarint:
+			buf = binary.AppendUvarint(buf, v.Uint())
+		case binaryString:
+			buf = binary.AppendUvarint(buf, uint64(v.Len()))
+			buf = append(buf, v.String()...)
+		case binaryFloat:
+			buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(v.Float()))
+		}
+	}
+	return buf, nil
+}
+
+// unmarshalBinary will deserialize the binary format into a Serializable[T]
+func unmarshalBinary[T any](s serializable[T], data []byte) error {
+	if len(data) < 2 {
+		return errBinaryCorrupt
+	}
+	if version := data[0]; version != binaryVersion {
+		return fmt.Errorf("set: unsupported binary format version %d", version)
+	}
+	encoding := data[1]
+	if expected := binaryEncoding(reflect.TypeFor[T]()); encoding != expected {
+		return fmt.Errorf("set: binary element encoding %d does not match %s", encoding, reflect.TypeFor[T]())
+	}
+	count, n := binary.Uvarint(data[2:])
+	if n <= 0 {
+		return errBinaryCorrupt
+	}
+	data = data[2+n:]
+
+	if encoding == binaryGob {
+		items := make([]T, 0)
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&items); err != nil {
+			return err
+		}
+		if uint64(len(items)) != count {
+			return errBinaryCorrupt
+		}
+		s.InsertSlice(items)
+		return nil
+	}
+
+	// each element occupies at least one byte, which bounds a corrupt count
+	if count > uint64(len(data)) {
+		return errBinaryCorrupt
+	}
+	items := make([]T, count)
+	for i := range items {
+		v := reflect.ValueOf(&items[i]).Elem()
+		switch encoding {
+		case binaryVarint:
+			x, n := binary.Varint(data)
+			if n <= 0 || v.OverflowInt(x) {
+				return errBinaryCorrupt
+			}
+			v.SetInt(x)
+			data = data[n:]
+		case binaryUvarint:
+			x, n := binary.Uvarint(data)
+			if n <= 0 || v.OverflowUint(x) {
+				return errBinaryCorrupt
+			}
+			v.SetUint(x)
+			data = data[n:]
+		case binaryString:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return errBinaryCorrupt
+			}
+			end := n + int(length)
+			v.SetString(string(data[n:end]))
+			data = data[end:]
+		case binaryFloat:
+			if len(data) < 8 {
+				return errBinaryCorrupt
+			}
+			v.SetFloat(math.Float64frombits(binary.BigEndian.Uint64(data)))
+			data = data[8:]
+		}
+	}
+	if len(data) > 0 {
+		return errBinaryCorrupt
+	}
+	s.InsertSlice(items)
+	return nil
+}
@@ -4,8 +4,13 @@
 package set
 
 import (
+	"bytes"
+	"encoding/gob"
 	"encoding/json"
+	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/shoenig/test/must"
 )
@@ -56,3 +61,202 @@ func TestSerialization(t *testing.T) {
 		must.Eq(t, set.Slice(), dstSet.Slice())
 	})
 }
+
+func TestSerialization_HashSet(t *testing.T) {
+	type payload struct {
+		Name    string                     `json:"name"`
+		Offices *HashSet[*company, string] `json:"offices"`
+	}
+
+	t.Run("embedded", func(t *testing.T) {
+		src := payload{
+			Name:    "example",
+			Offices: HashSetOf[*company, string](c1, c2, c3),
+		}
+		bs, err := json.Marshal(src)
+		must.NoError(t, err)
+
+		var dst payload
+		must.NoError(t, json.Unmarshal(bs, &dst))
+		must.Eq(t, "example", dst.Name)
+		must.True(t, src.Offices.Equal(dst.Offices))
+
+		// hashes are rebuilt from the decoded elements
+		must.MapContainsKeys(t, dst.Offices.items, []string{"street:1", "street:2", "street:3"})
+		must.False(t, dst.Offices.Insert(&company{address: "street", floor: 2}))
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var dst payload
+		must.NoError(t, json.Unmarshal([]byte(`{"name":"x","offices":null}`), &dst))
+		must.Nil(t, dst.Offices)
+	})
+
+	t.Run("existing hash func", func(t *testing.T) {
+		dst := NewHashSetFunc(func(s string) int { return len(s) }, 0)
+		must.NoError(t, json.Unmarshal([]byte(`["a","bb","cc"]`), dst))
+		must.Size(t, 2, dst)
+	})
+
+	t.Run("no hash func", func(t *testing.T) {
+		dst := new(HashSet[string, int])
+		err := json.Unmarshal([]byte(`["a","bb"]`), dst)
+		must.ErrorContains(t, err, "does not implement HashFunc")
+	})
+}
+
+func TestSerialization_Gob(t *testing.T) {
+	t.Run("Set", func(t *testing.T) {
+		set := From([]string{"a", "b", "c"})
+
+		var buf bytes.Buffer
+		must.NoError(t, gob.NewEncoder(&buf).Encode(set))
+
+		dstSet := New[string](0)
+		must.NoError(t, gob.NewDecoder(&buf).Decode(dstSet))
+		must.MapEq(t, set.items, dstSet.items)
+	})
+
+	t.Run("embedded", func(t *testing.T) {
+		type snapshot struct {
+			Name  string
+			Nodes *Set[int]
+			Tags  *Set[string]
+		}
+
+		src := snapshot{
+			Name:  "example",
+			Nodes: From([]int{1, 2, 3}),
+			Tags:  From([]string{"x", "y"}),
+		}
+
+		var buf bytes.Buffer
+		must.NoError(t, gob.NewEncoder(&buf).Encode(src))
+
+		var dst snapshot
+		must.NoError(t, gob.NewDecoder(&buf).Decode(&dst))
+		must.Eq(t, "example", dst.Name)
+		must.True(t, src.Nodes.Equal(dst.Nodes))
+		must.True(t, src.Tags.Equal(dst.Tags))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		must.NoError(t, gob.NewEncoder(&buf).Encode(New[int](0)))
+
+		dstSet := new(Set[int])
+		must.NoError(t, gob.NewDecoder(&buf).Decode(dstSet))
+		must.Empty(t, dstSet)
+		must.True(t, dstSet.Insert(1))
+	})
+
+	t.Run("corrupt", func(t *testing.T) {
+		dstSet := New[int](0)
+		must.Error(t, dstSet.GobDecode([]byte{0xff, 0x01}))
+	})
+}
+
+// label is a gob encodable type implementing HashFunc
+type label struct {
+	Name string
+}
+
+func (l label) Hash() string {
+	return l.Name
+}
+
+func TestBinary(t *testing.T) {
+	t.Run("Set ints", func(t *testing.T) {
+		a := Of(-1, 0, 300)
+		bs, err := a.MarshalBinary()
+		must.NoError(t, err)
+		must.Eq(t, []byte{binaryVersion, binaryVarint, 3}, bs[:3])
+		must.Len(t, 3+1+1+2, bs)
+
+		var b Set[int]
+		must.NoError(t, b.UnmarshalBinary(bs))
+		must.True(t, a.Equal(&b))
+	})
+
+	t.Run("TreeSet strings sorted", func(t *testing.T) {
+		a := TreeSetFrom[string, Compare[string]]([]string{"b", "", "a"}, Cmp[string])
+		bs, err := a.MarshalBinary()
+		must.NoError(t, err)
+		must.Eq(t, []byte{binaryVersion, binaryString, 3, 0, 1, 'a', 1, 'b'}, bs)
+
+		b := NewTreeSet[string, Compare[string]](Cmp[string])
+		must.NoError(t, b.UnmarshalBinary(bs))
+		must.Eq(t, []string{"", "a", "b"}, b.Slice())
+	})
+
+	t.Run("OrderedSet floats", func(t *testing.T) {
+		a := OrderedSetOf(2.5, -1, math.Inf(1))
+		bs, err := a.MarshalBinary()
+		must.NoError(t, err)
+		must.Len(t, 3+3*8, bs)
+
+		b := NewOrderedSet[float64](0)
+		must.NoError(t, b.UnmarshalBinary(bs))
+		must.Eq(t, []float64{2.5, -1, math.Inf(1)}, b.Slice())
+	})
+
+	t.Run("HashSet gob", func(t *testing.T) {
+		type point struct{ X, Y int }
+		hash := func(p point) string { return fmt.Sprintf("%d,%d", p.X, p.Y) }
+		a := NewHashSetFunc(hash, 0)
+		a.InsertSlice([]point{{1, 2}, {3, 4}})
+		bs, err := a.MarshalBinary()
+		must.NoError(t, err)
+		must.Eq(t, binaryGob, bs[1])
+
+		b := NewHashSetFunc(hash, 0)
+		must.NoError(t, b.UnmarshalBinary(bs))
+		must.True(t, a.Equal(b))
+	})
+
+	t.Run("SyncSet uints", func(t *testing.T) {
+		a := SyncSetOf[uint8](0, 255)
+		bs, err := a.MarshalBinary()
+		must.NoError(t, err)
+
+		b := NewSyncSet[uint8](0)
+		must.NoError(t, b.UnmarshalBinary(bs))
+		must.True(t, a.Equal(b))
+	})
+
+	t.Run("SyncHashSet zero value", func(t *testing.T) {
+		a := NewSyncHashSet[label, string](0)
+		a.InsertSlice([]label{{Name: "a"}, {Name: "b"}})
+		bs, err := a.MarshalBinary()
+		must.NoError(t, err)
+
+		var b SyncHashSet[label, string]
+		must.NoError(t, b.UnmarshalBinary(bs))
+		must.Eq(t, 2, b.Size())
+		must.True(t, b.Contains(label{Name: "a"}))
+	})
+
+	t.Run("named types", func(t *testing.T) {
+		a := Of(time.Second, time.Minute)
+		bs, err := a.MarshalBinary()
+		must.NoError(t, err)
+
+		b := New[time.Duration](0)
+		must.NoError(t, b.UnmarshalBinary(bs))
+		must.True(t, a.Equal(b))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		s := New[int8](0)
+		must.ErrorIs(t, s.UnmarshalBinary(nil), errBinaryCorrupt)
+		must.ErrorContains(t, s.UnmarshalBinary([]byte{2, binaryVarint, 0}), "unsupported binary format version 2")
+		must.ErrorContains(t, s.UnmarshalBinary([]byte{1, binaryString, 0}), "does not match int8")
+		must.ErrorIs(t, s.UnmarshalBinary([]byte{1, binaryVarint}), errBinaryCorrupt)
+		must.ErrorIs(t, s.UnmarshalBinary([]byte{1, binaryVarint, 100, 2}), errBinaryCorrupt)
+		must.ErrorIs(t, s.UnmarshalBinary([]byte{1, binaryVarint, 1, 0x80, 0x04}), errBinaryCorrupt) // overflow
+		must.ErrorIs(t, s.UnmarshalBinary([]byte{1, binaryVarint, 1, 2, 2}), errBinaryCorrupt)       // trailing
+		must.ErrorIs(t, New[string](0).UnmarshalBinary([]byte{1, binaryString, 1, 5, 'a'}), errBinaryCorrupt)
+		must.ErrorIs(t, New[float64](0).UnmarshalBinary([]byte{1, binaryFloat, 1, 0}), errBinaryCorrupt)
+		must.Empty(t, s)
+	})
+}
@@ -8,7 +8,11 @@ package set
 
 import (
 	"fmt"
+	"iter"
+	"math"
+	"math/rand"
 	"sort"
+	"strings"
 )
 
 type nothing struct{}
@@ -35,7 +39,9 @@ func New[T comparable](size int) *Set[T] {
 	}
 }
 
-// From creates a new Set containing each item in items.
+// From creates a new Set containing each item in items, sized to hold items
+// without growing. To leave room for more elements, create the set with New
+// and insert items with InsertSlice instead.
 //
 // T must *not* be of pointer type, nor contain pointer fields, which are comparable
 // but not in the way you expect. For these types, use HashSet instead.
@@ -45,6 +51,113 @@ func From[T comparable](items []T) *Set[T] {
 	return s
 }
 
+// Of creates a new Set containing each item passed as an argument.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect. For these types, use HashSet instead.
+func Of[T comparable](items ...T) *Set[T] {
+	return From(items)
+}
+
+// Collect creates a new Set containing each item produced by seq, such as the
+// iterators returned by maps.Keys or slices.Values.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect. For these types, use HashSet instead.
+func Collect[T comparable](seq iter.Seq[T]) *Set[T] {
+	s := New[T](0)
+	for item := range seq {
+		s.Insert(item)
+	}
+	return s
+}
+
+// FromChannel creates a new Set containing each item received from ch.
+//
+// Items are received until ch is closed, or until limit items have been
+// received if limit is positive. Duplicate items count towards the limit. A
+// limit of zero or less means ch is drained until closed. The set grows as
+// items are received, and is not sized by limit.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect. For these types, use HashSet instead.
+func FromChannel[T comparable](ch <-chan T, limit int) *Set[T] {
+	s := New[T](0)
+	for received := 0; limit <= 0 || received < limit; received++ {
+		item, ok := <-ch
+		if !ok {
+			break
+		}
+		s.Insert(item)
+	}
+	return s
+}
+
+// FromRange creates a new Set containing the integers from lo up to but not
+// including hi, counting by step. A negative step counts down from lo to hi.
+//
+// The set is empty if step does not move lo towards hi. FromRange panics if
+// step is zero.
+func FromRange(lo, hi, step int) *Set[int] {
+	s := New[int](rangeSize(lo, hi, step))
+	for i := range intRange(lo, hi, step) {
+		s.Insert(i)
+	}
+	return s
+}
+
+// FromFields creates a new Set containing each field of s, where fields are
+// separated by one or more whitespace characters as defined by strings.Fields.
+func FromFields(s string) *Set[string] {
+	return From(strings.Fields(s))
+}
+
+// FromSplit creates a new Set containing each substring of s separated by sep,
+// e.g. the elements of a comma-separated environment variable. Leading and
+// trailing whitespace is trimmed from each element, and empty elements are
+// discarded.
+func FromSplit(s, sep string) *Set[string] {
+	parts := strings.Split(s, sep)
+	result := New[string](len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			result.Insert(part)
+		}
+	}
+	return result
+}
+
+// rangeSize returns the number of integers produced by intRange. Distances
+// are computed as uint so that they cannot overflow, even between the
+// extremes of int.
+func rangeSize(lo, hi, step int) int {
+	var distance, stride uint
+	switch {
+	case step == 0:
+		panic("range: step must not be zero")
+	case step > 0 && lo < hi:
+		distance, stride = uint(hi)-uint(lo), uint(step)
+	case step < 0 && lo > hi:
+		distance, stride = uint(lo)-uint(hi), -uint(step)
+	default:
+		return 0
+	}
+	return int(min((distance-1)/stride+1, math.MaxInt))
+}
+
+// intRange returns an iterator over the integers from lo up to but not
+// including hi, counting by step.
+func intRange(lo, hi, step int) iter.Seq[int] {
+	n := rangeSize(lo, hi, step)
+	return func(yield func(int) bool) {
+		for i := 0; i < n; i++ {
+			if !yield(lo + i*step) {
+				return
+			}
+		}
+	}
+}
+
 // FromFunc creates a new Set containing a conversion of each item in items.
 //
 // T must *not* be of pointer type, nor contain pointer fields, which are comparable
@@ -97,6 +210,13 @@ func (s *Set[T]) InsertSlice(items []T) bool {
 	return modified
 }
 
+// InsertMany will insert each item passed as an argument into s.
+//
+// Return true if s was modified (at least one item was not already in s), false otherwise.
+func (s *Set[T]) InsertMany(items ...T) bool {
+	return s.InsertSlice(items)
+}
+
 // InsertSet will insert each element of o into s.
 //
 // Return true if s was modified (at least one item of o was not already in s), false otherwise.
@@ -143,6 +263,13 @@ func (s *Set[T]) RemoveSlice(items []T) bool {
 	return modified
 }
 
+// RemoveMany will remove each item passed as an argument from s.
+//
+// Return true if s was modified (any item was present), false otherwise.
+func (s *Set[T]) RemoveMany(items ...T) bool {
+	return s.RemoveSlice(items)
+}
+
 // RemoveSet will remove each element of o from s.
 //
 // Return true if s was modified (any item of o was present in s), false otherwise.
@@ -156,6 +283,21 @@ func (s *Set[T]) RemoveSet(o *Set[T]) bool {
 	return modified
 }
 
+// RetainSet will remove each element of s that is not in o, leaving s as the
+// intersection of s and o.
+//
+// Return true if s was modified (any item of s was not present in o), false otherwise.
+func (s *Set[T]) RetainSet(o *Set[T]) bool {
+	modified := false
+	for item := range s.items {
+		if !o.Contains(item) {
+			delete(s.items, item)
+			modified = true
+		}
+	}
+	return modified
+}
+
 // RemoveFunc will remove each element from s that satisfies condition f.
 //
 // Return true if s was modified, false otherwise.
@@ -170,6 +312,18 @@ func (s *Set[T]) RemoveFunc(f func(item T) bool) bool {
 	return modified
 }
 
+// Filter returns a new set containing each element of s that satisfies
+// condition f. s is not modified.
+func (s *Set[T]) Filter(f func(item T) bool) *Set[T] {
+	result := New[T](0)
+	for item := range s.items {
+		if f(item) {
+			result.items[item] = sentinel
+		}
+	}
+	return result
+}
+
 // Contains returns whether item is present in s.
 func (s *Set[T]) Contains(item T) bool {
 	_, exists := s.items[item]
@@ -177,10 +331,7 @@ func (s *Set[T]) Contains(item T) bool {
 }
 
 // ContainsAll returns whether s contains at least every item in items.
-func (s *Set[T]) ContainsAll(items []T) bool {
-	if len(s.items) < len(items) {
-		return false
-	}
+func (s *Set[T]) ContainsAll(items ...T) bool {
 	for _, item := range items {
 		if !s.Contains(item) {
 			return false
@@ -189,13 +340,55 @@ func (s *Set[T]) ContainsAll(items []T) bool {
 	return true
 }
 
+// ContainsAny returns whether s contains at least one of the items passed as
+// an argument.
+func (s *Set[T]) ContainsAny(items ...T) bool {
+	for _, item := range items {
+		if s.Contains(item) {
+			return true
+		}
+	}
+	return false
+}
+
 // ContainsSlice returns whether s contains the same set of of elements
 // that are in items. The elements of items may contain duplicates.
 //
 // If the slice is known to be set-like (no duplicates), EqualSlice provides
-// a more efficient implementation.
+// a more efficient implementation. ContainsSlice allocates only once every
+// item is known to be an element of s.
 func (s *Set[T]) ContainsSlice(items []T) bool {
-	return s.Equal(From(items))
+	// probing s for each item rejects most slices without allocating
+	if len(items) < len(s.items) {
+		return false
+	}
+	for _, item := range items {
+		if !s.Contains(item) {
+			return false
+		}
+	}
+	if len(s.items) <= 1 {
+		return true
+	}
+
+	// items has only elements of s, and as many as s, so it is equal to s
+	// unless duplicates crowd out some element of s
+	seen := make(map[T]nothing, len(s.items))
+	for _, item := range items {
+		seen[item] = sentinel
+	}
+	return len(seen) == len(s.items)
+}
+
+// ContainsFunc returns whether s contains at least one element that satisfies
+// condition f.
+func (s *Set[T]) ContainsFunc(f func(item T) bool) bool {
+	for item := range s.items {
+		if f(item) {
+			return true
+		}
+	}
+	return false
 }
 
 // Subset returns whether o is a subset of s.
@@ -211,6 +404,36 @@ func (s *Set[T]) Subset(o *Set[T]) bool {
 	return true
 }
 
+// ProperSubset returns whether o is a proper subset of s, that is whether o is
+// a subset of s and s contains at least one element not in o.
+func (s *Set[T]) ProperSubset(o *Set[T]) bool {
+	if s.Size() <= o.Size() {
+		return false
+	}
+	return s.Subset(o)
+}
+
+// Intersects returns whether s and o have at least one element in common.
+//
+// Returns as soon as a common element is found.
+func (s *Set[T]) Intersects(o *Set[T]) bool {
+	small, big := s, o
+	if s.Size() > o.Size() {
+		small, big = o, s
+	}
+	for item := range small.items {
+		if big.Contains(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Disjoint returns whether s and o have no elements in common.
+func (s *Set[T]) Disjoint(o *Set[T]) bool {
+	return !s.Intersects(o)
+}
+
 // Size returns the cardinality of s.
 func (s *Set[T]) Size() int {
 	return len(s.items)
@@ -223,7 +446,7 @@ func (s *Set[T]) Empty() bool {
 
 // Union returns a set that contains all elements of s and o combined.
 func (s *Set[T]) Union(o *Set[T]) *Set[T] {
-	result := New[T](s.Size())
+	result := New[T](s.Size() + o.Size())
 	for item := range s.items {
 		result.items[item] = sentinel
 	}
@@ -235,7 +458,7 @@ func (s *Set[T]) Union(o *Set[T]) *Set[T] {
 
 // Difference returns a set that contains elements of s that are not in o.
 func (s *Set[T]) Difference(o *Set[T]) *Set[T] {
-	result := New[T](max(0, s.Size()-o.Size()))
+	result := New[T](s.Size())
 	for item := range s.items {
 		if !o.Contains(item) {
 			result.items[item] = sentinel
@@ -246,14 +469,145 @@ func (s *Set[T]) Difference(o *Set[T]) *Set[T] {
 
 // Intersect returns a set that contains elements that are present in both s and o.
 func (s *Set[T]) Intersect(o *Set[T]) *Set[T] {
-	result := New[T](0)
 	big, small := s, o
 	if s.Size() < o.Size() {
 		big, small = o, s
 	}
+	result := New[T](small.Size())
 	for item := range small.items {
 		if big.Contains(item) {
-			result.Insert(item)
+			result.items[item] = sentinel
+		}
+	}
+	return result
+}
+
+// SymmetricDifference returns a set that contains elements that are present in
+// either s or o, but not in both.
+func (s *Set[T]) SymmetricDifference(o *Set[T]) *Set[T] {
+	result := New[T](s.Size() + o.Size())
+	for item := range s.items {
+		if !o.Contains(item) {
+			result.items[item] = sentinel
+		}
+	}
+	for item := range o.items {
+		if !s.Contains(item) {
+			result.items[item] = sentinel
+		}
+	}
+	return result
+}
+
+// Grow increases the underlying capacity of s so that at least n more items
+// can be inserted without the set needing to grow again.
+//
+// The existing elements of s are copied into the resized storage, so Grow is
+// best used once before inserting a large batch of items.
+func (s *Set[T]) Grow(n int) {
+	if n <= 0 {
+		return
+	}
+	items := make(map[T]nothing, len(s.items)+n)
+	for item := range s.items {
+		items[item] = sentinel
+	}
+	s.items = items
+}
+
+// Compact rebuilds the underlying storage of s sized to its current number of
+// elements.
+//
+// The builtin map never shrinks, so a set that once held many more elements
+// than it does now continues to hold on to that memory. Compact releases it.
+func (s *Set[T]) Compact() {
+	items := make(map[T]nothing, len(s.items))
+	for item := range s.items {
+		items[item] = sentinel
+	}
+	s.items = items
+}
+
+// Diff compares before and after, returning the elements that were added
+// (present in after but not in before) and the elements that were removed
+// (present in before but not in after).
+//
+// Diff is useful for reconciliation, e.g. comparing a desired state with the
+// actual state to determine what must be created and what must be destroyed.
+func Diff[T comparable](before, after *Set[T]) (added, removed *Set[T]) {
+	return after.Difference(before), before.Difference(after)
+}
+
+// Apply modifies s by removing the elements of removed and inserting the
+// elements of added, as returned by Diff. Applying the result of Diff(a, b) to
+// a set equal to a makes it equal to b.
+//
+// Return true if s was modified, false otherwise.
+func (s *Set[T]) Apply(added, removed *Set[T]) bool {
+	modified := s.RemoveSet(removed)
+	if s.InsertSet(added) {
+		modified = true
+	}
+	return modified
+}
+
+// Revert is the inverse of Apply, modifying s by removing the elements of
+// added and inserting the elements of removed. Reverting the result of
+// Diff(a, b) from a set equal to b makes it equal to a.
+//
+// Return true if s was modified, false otherwise.
+func (s *Set[T]) Revert(added, removed *Set[T]) bool {
+	return s.Apply(removed, added)
+}
+
+// UnionOf returns a set that contains all elements of each of sets combined.
+//
+// The result is sized up front from the combined sizes of sets (exact when the
+// sets are disjoint, as with shards) and filled in a single pass, which is much
+// cheaper than folding many sets together with Union.
+func UnionOf[T comparable](sets ...*Set[T]) *Set[T] {
+	size := 0
+	for _, s := range sets {
+		size += s.Size()
+	}
+	result := New[T](size)
+	for _, s := range sets {
+		for item := range s.items {
+			result.items[item] = sentinel
+		}
+	}
+	return result
+}
+
+// IntersectionOf returns a set that contains elements that are present in every
+// one of sets. The intersection of no sets is the empty set.
+//
+// Elements of the smallest set are probed against each of the others, and
+// IntersectionOf returns as soon as the result is known to be empty.
+func IntersectionOf[T comparable](sets ...*Set[T]) *Set[T] {
+	if len(sets) == 0 {
+		return New[T](0)
+	}
+
+	smallest := 0
+	for i, s := range sets {
+		if s.Size() < sets[smallest].Size() {
+			smallest = i
+		}
+	}
+
+	result := sets[smallest].Copy()
+	for i, s := range sets {
+		if i == smallest {
+			continue
+		}
+		if result.Empty() {
+			break
+		}
+		for item := range result.items {
+			if !s.Contains(item) {
+				delete(result.items, item)
+			}
 		}
 	}
 	return result
@@ -268,6 +622,21 @@ func (s *Set[T]) Copy() *Set[T] {
 	return result
 }
 
+// CopyInto inserts each element of s into dst, without allocating a new set.
+//
+// Return true if dst was modified (at least one element of s was not already
+// in dst), false otherwise.
+func (s *Set[T]) CopyInto(dst *Set[T]) bool {
+	modified := false
+	for item := range s.items {
+		if _, exists := dst.items[item]; !exists {
+			dst.items[item] = sentinel
+			modified = true
+		}
+	}
+	return modified
+}
+
 // Slice creates a copy of s as a slice. Elements are in no particular order.
 func (s *Set[T]) Slice() []T {
 	result := make([]T, 0, s.Size())
@@ -277,6 +646,83 @@ func (s *Set[T]) Slice() []T {
 	return result
 }
 
+// ForEach calls visit for each element of s, in no particular order. Iteration
+// stops early if visit returns false.
+func (s *Set[T]) ForEach(visit func(item T) bool) {
+	for item := range s.items {
+		if !visit(item) {
+			return
+		}
+	}
+}
+
+// SortedSlice creates a copy of s as a slice, with elements sorted according
+// to compare.
+func (s *Set[T]) SortedSlice(compare Compare[T]) []T {
+	result := s.Slice()
+	sort.Slice(result, func(i, j int) bool {
+		return compare(result[i], result[j]) < 0
+	})
+	return result
+}
+
+// Min returns the smallest element of s according to compare, and true. If s
+// is empty the zero value of T and false are returned.
+//
+// Min is computed in a single pass over s, without sorting.
+func (s *Set[T]) Min(compare Compare[T]) (T, bool) {
+	return s.extreme(func(item, best T) bool {
+		return compare(item, best) < 0
+	})
+}
+
+// Max returns the largest element of s according to compare, and true. If s
+// is empty the zero value of T and false are returned.
+//
+// Max is computed in a single pass over s, without sorting.
+func (s *Set[T]) Max(compare Compare[T]) (T, bool) {
+	return s.extreme(func(item, best T) bool {
+		return compare(item, best) > 0
+	})
+}
+
+// extreme returns the element of s that is better than every other element.
+func (s *Set[T]) extreme(better func(item, best T) bool) (T, bool) {
+	var best T
+	found := false
+	for item := range s.items {
+		if !found || better(item, best) {
+			best = item
+			found = true
+		}
+	}
+	return best, found
+}
+
+// Sample returns n distinct elements of s chosen uniformly at random using rng,
+// in no particular order. If n is greater than the size of s, every element
+// of s is returned. If rng is nil, the default source of package math/rand
+// is used.
+//
+// Sample makes a single pass over s and allocates only the result.
+func (s *Set[T]) Sample(n int, rng *rand.Rand) []T {
+	intn := rand.Intn
+	if rng != nil {
+		intn = rng.Intn
+	}
+	result := make([]T, 0, max(0, min(n, s.Size())))
+	i := 0
+	for item := range s.items {
+		if len(result) < n {
+			result = append(result, item)
+		} else if j := intn(i + 1); j < n {
+			result[j] = item
+		}
+		i++
+	}
+	return result
+}
+
 // List creates a copy of s as a slice.
 //
 // Deprecated: use Slice() instead.
@@ -304,6 +750,17 @@ func (s *Set[T]) StringFunc(f func(element T) string) string {
 	return fmt.Sprintf("%s", l)
 }
 
+// StringSorted creates a string representation of s, using "%v" printf formatting
+// to transform each element into a string. Unlike String, the result contains
+// elements sorted according to compare rather than by their lexical string order.
+func (s *Set[T]) StringSorted(compare Compare[T]) string {
+	l := make([]string, 0, s.Size())
+	for _, item := range s.SortedSlice(compare) {
+		l = append(l, fmt.Sprintf("%v", item))
+	}
+	return fmt.Sprintf("%s", l)
+}
+
 // Equal returns whether s and o contain the same elements.
 func (s *Set[T]) Equal(o *Set[T]) bool {
 	if len(s.items) != len(o.items) {
@@ -328,7 +785,17 @@ func (s *Set[T]) EqualSlice(items []T) bool {
 	if len(s.items) != len(items) {
 		return false
 	}
-	return s.ContainsAll(items)
+	return s.ContainsAll(items...)
+}
+
+// Fingerprint returns a hash of the elements of s that does not depend on the
+// order of iteration, so that sets containing the same elements have the same
+// fingerprint. Sets with different elements have different fingerprints with
+// high probability.
+//
+// Fingerprints are only comparable within a single process.
+func (s *Set[T]) Fingerprint() uint64 {
+	return fingerprint(s.ForEach)
 }
 
 // MarshalJSON implements the json.Marshaler interface.
@@ -340,3 +807,34 @@ func (s *Set[T]) MarshalJSON() ([]byte, error) {
 func (s *Set[T]) UnmarshalJSON(data []byte) error {
 	return unmarshalJSON[T](s, data)
 }
+
+// GobEncode implements the gob.GobEncoder interface.
+func (s *Set[T]) GobEncode() ([]byte, error) {
+	return encodeGob[T](s)
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+//
+// A zero value Set (e.g. one allocated by the gob package for a struct field)
+// is initialized before decoding.
+func (s *Set[T]) GobDecode(data []byte) error {
+	if s.items == nil {
+		s.items = make(map[T]nothing)
+	}
+	return decodeGob[T](s, data)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s *Set[T]) MarshalBinary() ([]byte, error) {
+	return marshalBinary[T](s)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+//
+// A zero value Set is initialized before decoding.
+func (s *Set[T]) UnmarshalBinary(data []byte) error {
+	if s.items == nil {
+		s.items = make(map[T]nothing)
+	}
+	return unmarshalBinary[T](s, data)
+}
@@ -5,6 +5,11 @@ package set
 
 import (
 	"fmt"
+	"maps"
+	"math"
+	"math/rand"
+	"runtime"
+	"slices"
 	"testing"
 
 	"github.com/shoenig/test/must"
@@ -48,6 +53,171 @@ func TestSet_From(t *testing.T) {
 	})
 }
 
+func TestSet_Of(t *testing.T) {
+	t.Run("of none", func(t *testing.T) {
+		s := Of[string]()
+		must.MapEmpty(t, s.items)
+	})
+
+	t.Run("of one", func(t *testing.T) {
+		s := Of("apple")
+		must.MapContainsKeys(t, s.items, []string{"apple"})
+	})
+
+	t.Run("of some", func(t *testing.T) {
+		s := Of(1, 2, 3, 2, 1)
+		must.MapContainsKeys(t, s.items, []int{1, 2, 3})
+		must.Size(t, 3, s)
+	})
+}
+
+func TestSet_Collect(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := Collect(slices.Values([]int(nil)))
+		must.MapEmpty(t, s.items)
+	})
+
+	t.Run("slice values", func(t *testing.T) {
+		s := Collect(slices.Values([]int{3, 1, 2, 3}))
+		must.MapContainsKeys(t, s.items, []int{1, 2, 3})
+		must.Size(t, 3, s)
+	})
+
+	t.Run("map keys", func(t *testing.T) {
+		m := map[string]int{"a": 1, "b": 2}
+		s := Collect(maps.Keys(m))
+		must.MapContainsKeys(t, s.items, []string{"a", "b"})
+	})
+}
+
+func TestSet_FromChannel(t *testing.T) {
+	send := func(items ...int) <-chan int {
+		ch := make(chan int, len(items))
+		for _, item := range items {
+			ch <- item
+		}
+		close(ch)
+		return ch
+	}
+
+	t.Run("closed empty", func(t *testing.T) {
+		s := FromChannel(send(), 0)
+		must.Empty(t, s)
+	})
+
+	t.Run("drain", func(t *testing.T) {
+		s := FromChannel(send(1, 2, 2, 3, 1), 0)
+		must.MapContainsKeys(t, s.items, []int{1, 2, 3})
+		must.Size(t, 3, s)
+	})
+
+	t.Run("negative limit", func(t *testing.T) {
+		s := FromChannel(send(1, 2, 3), -1)
+		must.Size(t, 3, s)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		ch := send(1, 1, 2, 3, 4)
+		s := FromChannel(ch, 3)
+		must.MapContainsKeys(t, s.items, []int{1, 2})
+		must.Size(t, 2, s)
+
+		// remaining items are left in the channel
+		must.Eq(t, 3, <-ch)
+	})
+
+	t.Run("limit beyond close", func(t *testing.T) {
+		s := FromChannel(send(1, 2), 10)
+		must.Size(t, 2, s)
+	})
+
+	t.Run("huge limit", func(t *testing.T) {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		s := FromChannel(send(1, 2, 3), 1<<24)
+		runtime.ReadMemStats(&after)
+		must.Size(t, 3, s)
+		must.Less(t, 1<<16, after.TotalAlloc-before.TotalAlloc)
+	})
+
+	t.Run("workers", func(t *testing.T) {
+		ch := make(chan int)
+		go func() {
+			defer close(ch)
+			for i := 0; i < 100; i++ {
+				ch <- i%10 + 1
+			}
+		}()
+		s := FromChannel(ch, 0)
+		must.Eq(t, ints(10), s.SortedSlice(Cmp[int]))
+	})
+}
+
+func TestSet_FromRange(t *testing.T) {
+	cases := []struct {
+		name         string
+		lo, hi, step int
+		exp          []int
+	}{
+		{name: "empty", lo: 3, hi: 3, step: 1, exp: []int{}},
+		{name: "one", lo: 0, hi: 5, step: 1, exp: []int{0, 1, 2, 3, 4}},
+		{name: "step", lo: 1, hi: 10, step: 3, exp: []int{1, 4, 7}},
+		{name: "step exact", lo: 0, hi: 9, step: 3, exp: []int{0, 3, 6}},
+		{name: "negative", lo: -2, hi: 2, step: 1, exp: []int{-2, -1, 0, 1}},
+		{name: "down", lo: 10, hi: 0, step: -4, exp: []int{2, 6, 10}},
+		{name: "wrong way up", lo: 5, hi: 0, step: 1, exp: []int{}},
+		{name: "wrong way down", lo: 0, hi: 5, step: -1, exp: []int{}},
+		{name: "max step", lo: 0, hi: math.MaxInt, step: math.MaxInt / 2, exp: []int{0, math.MaxInt / 2, math.MaxInt - 1}},
+		{name: "max bounds", lo: math.MinInt, hi: math.MaxInt, step: math.MaxInt, exp: []int{math.MinInt, -1, math.MaxInt - 1}},
+		{name: "min step", lo: math.MaxInt, hi: math.MinInt, step: math.MinInt, exp: []int{-1, math.MaxInt}},
+		{name: "beyond hi", lo: math.MaxInt - 1, hi: math.MaxInt, step: math.MaxInt, exp: []int{math.MaxInt - 1}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := FromRange(tc.lo, tc.hi, tc.step)
+			must.Eq(t, tc.exp, s.SortedSlice(Cmp[int]))
+		})
+	}
+
+	t.Run("zero step", func(t *testing.T) {
+		defer func() {
+			must.NotNil(t, recover())
+		}()
+		FromRange(0, 10, 0)
+	})
+}
+
+func TestSet_FromFields(t *testing.T) {
+	must.Empty(t, FromFields(""))
+	must.Empty(t, FromFields(" \t\n "))
+
+	s := FromFields("  alpha beta\tgamma\nbeta  ")
+	must.True(t, s.EqualSlice([]string{"alpha", "beta", "gamma"}))
+}
+
+func TestSet_FromSplit(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		must.Empty(t, FromSplit("", ","))
+		must.Empty(t, FromSplit(" , ,, ", ","))
+	})
+
+	t.Run("comma", func(t *testing.T) {
+		s := FromSplit("us-east-1, us-west-2,,eu-west-1 ,us-east-1", ",")
+		must.True(t, s.EqualSlice([]string{"us-east-1", "us-west-2", "eu-west-1"}))
+	})
+
+	t.Run("multi char sep", func(t *testing.T) {
+		s := FromSplit("a::b:: c ::", "::")
+		must.True(t, s.EqualSlice([]string{"a", "b", "c"}))
+	})
+
+	t.Run("no sep", func(t *testing.T) {
+		s := FromSplit(" one ", ",")
+		must.True(t, s.EqualSlice([]string{"one"}))
+	})
+}
+
 func TestSet_FromFunc(t *testing.T) {
 	employees := []employee{
 		{"alice", 1}, {"bob", 2}, {"bob", 2}, {"carol", 3}, {"dave", 4},
@@ -123,6 +293,28 @@ func TestSet_InsertSlice(t *testing.T) {
 	})
 }
 
+func TestSet_InsertMany(t *testing.T) {
+	t.Run("insert none", func(t *testing.T) {
+		empty := New[int](0)
+		must.False(t, empty.InsertMany())
+		must.MapEmpty(t, empty.items)
+	})
+
+	t.Run("insert some", func(t *testing.T) {
+		s := New[string](0)
+		must.True(t, s.InsertMany("apple", "banana", "cherry"))
+		must.MapContainsKeys(t, s.items, []string{"apple", "banana", "cherry"})
+	})
+
+	t.Run("insert duplicates", func(t *testing.T) {
+		s := New[int](0)
+		must.True(t, s.InsertMany(2, 4, 6, 8))
+		must.True(t, s.InsertMany(4, 5, 6))
+		must.False(t, s.InsertMany(2, 5, 8))
+		must.MapContainsKeys(t, s.items, []int{2, 4, 5, 6, 8})
+	})
+}
+
 func TestSet_InsertSet(t *testing.T) {
 	t.Run("insert empty", func(t *testing.T) {
 		a := From[int]([]int{1, 2, 3, 4})
@@ -165,13 +357,41 @@ func TestSet_ContainsAll(t *testing.T) {
 	t.Run("contains subset", func(t *testing.T) {
 		s := New[int](10)
 		must.True(t, s.InsertSlice([]int{1, 2, 3, 4, 5}))
-		must.True(t, s.ContainsAll([]int{1, 3, 5}))
+		must.True(t, s.ContainsAll(1, 3, 5))
 	})
 
 	t.Run("contains missing", func(t *testing.T) {
 		s := New[int](10)
 		must.True(t, s.InsertSlice([]int{1, 2, 3, 4, 5}))
-		must.False(t, s.ContainsAll([]int{1, 3, 5, 7}))
+		must.False(t, s.ContainsAll(1, 3, 5, 7))
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		s := Of(1)
+		must.True(t, s.ContainsAll(1, 1))
+		must.False(t, s.ContainsAll(1, 1, 2))
+	})
+}
+
+func TestSet_ContainsAny(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := New[int](10)
+		must.False(t, s.ContainsAny(1, 2, 3))
+	})
+
+	t.Run("none given", func(t *testing.T) {
+		s := Of(1, 2, 3)
+		must.False(t, s.ContainsAny())
+	})
+
+	t.Run("contains one", func(t *testing.T) {
+		s := Of(1, 2, 3, 4, 5)
+		must.True(t, s.ContainsAny(9, 8, 7, 5))
+	})
+
+	t.Run("contains none", func(t *testing.T) {
+		s := Of(1, 2, 3, 4, 5)
+		must.False(t, s.ContainsAny(6, 7, 8))
 	})
 }
 
@@ -223,6 +443,44 @@ func TestSet_ContainsSlice(t *testing.T) {
 		b := []int{1, 2, 2, 3, 3, 4, 5}
 		must.True(t, a.ContainsSlice(b))
 	})
+
+	t.Run("duplicates missing", func(t *testing.T) {
+		a := From[int]([]int{1, 2, 3})
+		must.False(t, a.ContainsSlice([]int{1, 1, 2}))
+		must.False(t, a.ContainsSlice([]int{1, 1, 2, 2}))
+	})
+
+	t.Run("allocs", func(t *testing.T) {
+		a := From[int](ints(1000))
+		b := append(ints(999), 1001)
+		c := ints(10)
+		allocs := testing.AllocsPerRun(10, func() {
+			a.ContainsSlice(b)
+			a.ContainsSlice(c)
+		})
+		must.Eq(t, 0.0, allocs)
+	})
+}
+
+func TestSet_ContainsFunc(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := New[int](10)
+		must.False(t, s.ContainsFunc(func(int) bool { return true }))
+	})
+
+	t.Run("match", func(t *testing.T) {
+		s := Of(employee{"alice", 1}, employee{"bob", 2})
+		must.True(t, s.ContainsFunc(func(e employee) bool {
+			return e.name == "bob"
+		}))
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		s := Of(employee{"alice", 1}, employee{"bob", 2})
+		must.False(t, s.ContainsFunc(func(e employee) bool {
+			return e.id > 2
+		}))
+	})
 }
 
 func TestSet_Size(t *testing.T) {
@@ -315,6 +573,33 @@ func TestSet_Difference(t *testing.T) {
 	})
 }
 
+func TestSet_SymmetricDifference(t *testing.T) {
+	t.Run("empty △ empty", func(t *testing.T) {
+		a := New[int](0)
+		b := New[int](0)
+		must.Empty(t, a.SymmetricDifference(b))
+	})
+
+	t.Run("set △ empty", func(t *testing.T) {
+		a := Of(1, 2, 3)
+		b := New[int](0)
+		must.True(t, a.SymmetricDifference(b).EqualSlice([]int{1, 2, 3}))
+		must.True(t, b.SymmetricDifference(a).EqualSlice([]int{1, 2, 3}))
+	})
+
+	t.Run("overlap", func(t *testing.T) {
+		a := Of(1, 2, 3, 4)
+		b := Of(3, 4, 5, 6)
+		result := a.SymmetricDifference(b)
+		must.True(t, result.EqualSlice([]int{1, 2, 5, 6}))
+	})
+
+	t.Run("same", func(t *testing.T) {
+		a := Of(1, 2, 3)
+		must.Empty(t, a.SymmetricDifference(a.Copy()))
+	})
+}
+
 func TestSet_Intersect(t *testing.T) {
 	t.Run("empty ∩ empty", func(t *testing.T) {
 		a := New[int](10)
@@ -352,6 +637,148 @@ func TestSet_Intersect(t *testing.T) {
 	})
 }
 
+func TestDiff(t *testing.T) {
+	t.Run("empty empty", func(t *testing.T) {
+		added, removed := Diff(New[int](0), New[int](0))
+		must.Empty(t, added)
+		must.Empty(t, removed)
+	})
+
+	t.Run("empty before", func(t *testing.T) {
+		added, removed := Diff(New[int](0), Of(1, 2))
+		must.MapContainsKeys(t, added.items, []int{1, 2})
+		must.Empty(t, removed)
+	})
+
+	t.Run("empty after", func(t *testing.T) {
+		added, removed := Diff(Of(1, 2), New[int](0))
+		must.Empty(t, added)
+		must.MapContainsKeys(t, removed.items, []int{1, 2})
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		before := Of(1, 2, 3, 4)
+		after := Of(3, 4, 5, 6)
+		added, removed := Diff(before, after)
+		must.MapContainsKeys(t, added.items, []int{5, 6})
+		must.Size(t, 2, added)
+		must.MapContainsKeys(t, removed.items, []int{1, 2})
+		must.Size(t, 2, removed)
+	})
+
+	t.Run("same", func(t *testing.T) {
+		added, removed := Diff(Of(1, 2), Of(2, 1))
+		must.Empty(t, added)
+		must.Empty(t, removed)
+	})
+}
+
+func TestSet_Apply(t *testing.T) {
+	t.Run("apply diff", func(t *testing.T) {
+		before := Of(1, 2, 3, 4)
+		after := Of(3, 4, 5, 6)
+		added, removed := Diff(before, after)
+
+		s := before.Copy()
+		must.True(t, s.Apply(added, removed))
+		must.Eq(t, after, s)
+		must.False(t, s.Apply(added, removed))
+	})
+
+	t.Run("revert diff", func(t *testing.T) {
+		before := Of("a", "b")
+		after := Of("b", "c")
+		added, removed := Diff(before, after)
+
+		s := after.Copy()
+		must.True(t, s.Revert(added, removed))
+		must.Eq(t, before, s)
+		must.False(t, s.Revert(added, removed))
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		before := From(ints(20))
+		after := From(shuffle(ints(30))[:15])
+		added, removed := Diff(before, after)
+
+		s := before.Copy()
+		s.Apply(added, removed)
+		must.Eq(t, after, s)
+		s.Revert(added, removed)
+		must.Eq(t, before, s)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		s := Of(1)
+		must.False(t, s.Apply(New[int](0), New[int](0)))
+		must.False(t, s.Revert(New[int](0), New[int](0)))
+		must.Eq(t, Of(1), s)
+	})
+}
+
+func TestUnionOf(t *testing.T) {
+	t.Run("none", func(t *testing.T) {
+		must.Empty(t, UnionOf[int]())
+	})
+
+	t.Run("one", func(t *testing.T) {
+		a := Of(1, 2, 3)
+		union := UnionOf(a)
+		must.MapContainsKeys(t, union.items, []int{1, 2, 3})
+		must.True(t, union.Insert(4))
+		must.False(t, a.Contains(4))
+	})
+
+	t.Run("many", func(t *testing.T) {
+		shards := make([]*Set[int], 0, 50)
+		for i := 0; i < 50; i++ {
+			shards = append(shards, Of(i, i+1, i+2))
+		}
+		union := UnionOf(shards...)
+		must.Size(t, 52, union)
+		must.True(t, union.ContainsAll(ints(51)...))
+	})
+
+	t.Run("with empty", func(t *testing.T) {
+		union := UnionOf(New[int](0), Of(1), New[int](0), Of(2))
+		must.MapContainsKeys(t, union.items, []int{1, 2})
+		must.Size(t, 2, union)
+	})
+}
+
+func TestIntersectionOf(t *testing.T) {
+	t.Run("none", func(t *testing.T) {
+		must.Empty(t, IntersectionOf[int]())
+	})
+
+	t.Run("one", func(t *testing.T) {
+		a := Of(1, 2, 3)
+		intersection := IntersectionOf(a)
+		must.MapContainsKeys(t, intersection.items, []int{1, 2, 3})
+		must.True(t, intersection.Remove(1))
+		must.True(t, a.Contains(1))
+	})
+
+	t.Run("many", func(t *testing.T) {
+		a := From(ints(100))
+		b := Of(2, 4, 6, 8, 10, 12)
+		c := Of(12, 10, 8, 6, 5, 4, 3)
+		intersection := IntersectionOf(a, b, c)
+		must.MapContainsKeys(t, intersection.items, []int{4, 6, 8, 10, 12})
+		must.Size(t, 5, intersection)
+	})
+
+	t.Run("disjoint", func(t *testing.T) {
+		intersection := IntersectionOf(Of(1, 2), Of(3, 4), Of(1, 2, 3, 4))
+		must.Empty(t, intersection)
+	})
+
+	t.Run("with empty", func(t *testing.T) {
+		intersection := IntersectionOf(Of(1, 2), New[int](0), Of(1, 2))
+		must.Empty(t, intersection)
+	})
+}
+
 func TestSet_Remove(t *testing.T) {
 	t.Run("empty remove item", func(t *testing.T) {
 		s := New[int](10)
@@ -392,6 +819,26 @@ func TestSet_RemoveSlice(t *testing.T) {
 	})
 }
 
+func TestSet_RemoveMany(t *testing.T) {
+	t.Run("empty remove many", func(t *testing.T) {
+		s := New[int](10)
+		must.False(t, s.RemoveMany(1, 2, 3))
+		must.MapEmpty(t, s.items)
+	})
+
+	t.Run("set remove nothing", func(t *testing.T) {
+		s := Of(1, 2, 3)
+		must.False(t, s.RemoveMany())
+		must.MapContainsKeys(t, s.items, []int{1, 2, 3})
+	})
+
+	t.Run("set remove some", func(t *testing.T) {
+		s := Of(1, 2, 3, 4, 5, 6)
+		must.True(t, s.RemoveMany(5, 6, 7, 8, 9))
+		must.MapContainsKeys(t, s.items, []int{1, 2, 3, 4})
+	})
+}
+
 func TestSet_RemoveSet(t *testing.T) {
 	t.Run("empty remove empty", func(t *testing.T) {
 		a := New[int](0)
@@ -415,6 +862,51 @@ func TestSet_RemoveSet(t *testing.T) {
 	})
 }
 
+func TestSet_Filter(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := New[int](0)
+		must.Empty(t, s.Filter(func(int) bool { return true }))
+	})
+
+	t.Run("some", func(t *testing.T) {
+		s := From(ints(10))
+		even := s.Filter(func(i int) bool { return i%2 == 0 })
+		must.True(t, even.EqualSlice([]int{2, 4, 6, 8, 10}))
+		must.Size(t, 10, s)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		s := From(ints(10))
+		must.Empty(t, s.Filter(func(i int) bool { return i > 10 }))
+	})
+}
+
+func TestSet_RetainSet(t *testing.T) {
+	t.Run("empty empty", func(t *testing.T) {
+		a := New[int](0)
+		must.False(t, a.RetainSet(New[int](0)))
+		must.Empty(t, a)
+	})
+
+	t.Run("some empty", func(t *testing.T) {
+		a := Of(1, 2, 3)
+		must.True(t, a.RetainSet(New[int](0)))
+		must.Empty(t, a)
+	})
+
+	t.Run("overlap", func(t *testing.T) {
+		a := Of(1, 2, 3, 4)
+		must.True(t, a.RetainSet(Of(2, 4, 6)))
+		must.True(t, a.EqualSlice([]int{2, 4}))
+	})
+
+	t.Run("superset", func(t *testing.T) {
+		a := Of(1, 2)
+		must.False(t, a.RetainSet(Of(1, 2, 3)))
+		must.True(t, a.EqualSlice([]int{1, 2}))
+	})
+}
+
 func TestSet_RemoveFunc(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		a := New[int](10)
@@ -453,6 +945,77 @@ func TestSet_RemoveFunc(t *testing.T) {
 	})
 }
 
+func TestSet_CopyInto(t *testing.T) {
+	t.Run("empty into empty", func(t *testing.T) {
+		a := New[int](0)
+		dst := New[int](0)
+		must.False(t, a.CopyInto(dst))
+		must.MapEmpty(t, dst.items)
+	})
+
+	t.Run("some into some", func(t *testing.T) {
+		a := Of(1, 2, 3)
+		dst := Of(3, 4)
+		must.True(t, a.CopyInto(dst))
+		must.MapContainsKeys(t, dst.items, []int{1, 2, 3, 4})
+		must.MapContainsKeys(t, a.items, []int{1, 2, 3})
+		must.Size(t, 3, a)
+		must.False(t, a.CopyInto(dst))
+	})
+
+	t.Run("accumulate", func(t *testing.T) {
+		acc := New[int](10)
+		scratch := New[int](10)
+		for i := 0; i < 3; i++ {
+			scratch.InsertMany(i, i+10)
+			scratch.CopyInto(acc)
+			scratch.RemoveMany(i, i+10)
+		}
+		must.Empty(t, scratch)
+		must.MapContainsKeys(t, acc.items, []int{0, 1, 2, 10, 11, 12})
+	})
+}
+
+func TestSet_Grow(t *testing.T) {
+	t.Run("grow empty", func(t *testing.T) {
+		s := New[int](0)
+		s.Grow(100)
+		must.MapEmpty(t, s.items)
+		must.True(t, s.InsertSlice(ints(100)))
+		must.Size(t, 100, s)
+	})
+
+	t.Run("grow some", func(t *testing.T) {
+		s := Of(1, 2, 3)
+		s.Grow(1000)
+		must.MapContainsKeys(t, s.items, []int{1, 2, 3})
+		must.Size(t, 3, s)
+	})
+
+	t.Run("grow negative", func(t *testing.T) {
+		s := Of(1, 2, 3)
+		s.Grow(-1)
+		must.MapContainsKeys(t, s.items, []int{1, 2, 3})
+	})
+}
+
+func TestSet_Compact(t *testing.T) {
+	t.Run("compact empty", func(t *testing.T) {
+		s := New[int](1000)
+		s.Compact()
+		must.MapEmpty(t, s.items)
+	})
+
+	t.Run("compact after remove", func(t *testing.T) {
+		s := From(ints(1000))
+		s.RemoveFunc(func(i int) bool { return i > 3 })
+		s.Compact()
+		must.MapContainsKeys(t, s.items, []int{1, 2, 3})
+		must.Size(t, 3, s)
+		must.True(t, s.Insert(4))
+	})
+}
+
 func TestSet_Copy(t *testing.T) {
 	t.Run("copy empty", func(t *testing.T) {
 		a := New[int](0)
@@ -490,6 +1053,150 @@ func TestSet_Slice(t *testing.T) {
 	})
 }
 
+func TestSet_ForEach(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := New[int](10)
+		visits := 0
+		s.ForEach(func(int) bool {
+			visits++
+			return true
+		})
+		must.Zero(t, visits)
+	})
+
+	t.Run("visit all", func(t *testing.T) {
+		s := Of(1, 2, 3, 4, 5)
+		result := New[int](5)
+		s.ForEach(func(i int) bool {
+			result.Insert(i)
+			return true
+		})
+		must.Equal(t, s, result)
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		s := From(ints(100))
+		visits := 0
+		s.ForEach(func(int) bool {
+			visits++
+			return visits < 3
+		})
+		must.Eq(t, 3, visits)
+	})
+}
+
+func TestSet_SortedSlice(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		a := New[int](10)
+		l := a.SortedSlice(Cmp[int])
+		must.SliceEmpty(t, l)
+	})
+
+	t.Run("ints", func(t *testing.T) {
+		a := From([]int{10, 2, 33, 4, 1})
+		l := a.SortedSlice(Cmp[int])
+		must.Eq(t, []int{1, 2, 4, 10, 33}, l)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		a := From([]employee{{"bob", 2}, {"alice", 1}, {"carl", 3}})
+		l := a.SortedSlice(func(x, y employee) int {
+			return x.id - y.id
+		})
+		must.Eq(t, []employee{{"alice", 1}, {"bob", 2}, {"carl", 3}}, l)
+	})
+}
+
+func TestSet_MinMax(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		s := New[int](0)
+		minimum, ok := s.Min(Cmp[int])
+		must.False(t, ok)
+		must.Zero(t, minimum)
+		maximum, ok := s.Max(Cmp[int])
+		must.False(t, ok)
+		must.Zero(t, maximum)
+	})
+
+	t.Run("one", func(t *testing.T) {
+		s := Of(7)
+		minimum, ok := s.Min(Cmp[int])
+		must.True(t, ok)
+		must.Eq(t, 7, minimum)
+		maximum, ok := s.Max(Cmp[int])
+		must.True(t, ok)
+		must.Eq(t, 7, maximum)
+	})
+
+	t.Run("many", func(t *testing.T) {
+		s := From(shuffle(ints(100)))
+		minimum, ok := s.Min(Cmp[int])
+		must.True(t, ok)
+		must.Eq(t, 1, minimum)
+		maximum, ok := s.Max(Cmp[int])
+		must.True(t, ok)
+		must.Eq(t, 100, maximum)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		s := Of("ccc", "a", "bb")
+		byLength := func(a, b string) int { return len(a) - len(b) }
+		shortest, _ := s.Min(byLength)
+		must.Eq(t, "a", shortest)
+		longest, _ := s.Max(byLength)
+		must.Eq(t, "ccc", longest)
+	})
+}
+
+func TestSet_Sample(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+
+	t.Run("empty", func(t *testing.T) {
+		s := New[int](0)
+		must.SliceEmpty(t, s.Sample(3, rng))
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		s := From(ints(10))
+		must.SliceEmpty(t, s.Sample(0, rng))
+		must.SliceEmpty(t, s.Sample(-1, rng))
+	})
+
+	t.Run("more than size", func(t *testing.T) {
+		s := Of(1, 2, 3)
+		must.True(t, s.EqualSlice(s.Sample(5, rng)))
+	})
+
+	t.Run("distinct", func(t *testing.T) {
+		s := From(ints(100))
+		for i := 0; i < 100; i++ {
+			sample := s.Sample(10, rng)
+			must.Len(t, 10, sample)
+			must.True(t, s.ContainsAll(sample...))
+			must.Size(t, 10, From(sample))
+		}
+	})
+
+	t.Run("nil rng", func(t *testing.T) {
+		s := From(ints(100))
+		sample := s.Sample(10, nil)
+		must.Size(t, 10, From(sample))
+	})
+
+	t.Run("uniform", func(t *testing.T) {
+		s := From(ints(10))
+		counts := make(map[int]int)
+		for i := 0; i < 10_000; i++ {
+			for _, item := range s.Sample(2, rng) {
+				counts[item]++
+			}
+		}
+		for _, count := range counts {
+			must.Between(t, 1700, count, 2300)
+		}
+	})
+}
+
 func TestSet_List(t *testing.T) {
 	t.Run("list empty", func(t *testing.T) {
 		a := New[string](10)
@@ -549,6 +1256,27 @@ func TestSet_StringFunc(t *testing.T) {
 	})
 }
 
+func TestSet_StringSorted(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		a := New[int](10)
+		s := a.StringSorted(Cmp[int])
+		must.Eq(t, "[]", s)
+	})
+
+	t.Run("ints", func(t *testing.T) {
+		a := From([]int{100, 9, 20, 3})
+		must.Eq(t, "[100 20 3 9]", a.String())
+		must.Eq(t, "[3 9 20 100]", a.StringSorted(Cmp[int]))
+	})
+
+	t.Run("stable", func(t *testing.T) {
+		a := From([]int{5, 4, 3, 2, 1, 0, -1, -2, -3, -4, -5})
+		for i := 0; i < 10; i++ {
+			must.Eq(t, "[-5 -4 -3 -2 -1 0 1 2 3 4 5]", a.StringSorted(Cmp[int]))
+		}
+	})
+}
+
 func TestSet_Equal(t *testing.T) {
 	t.Run("empty empty", func(t *testing.T) {
 		a := New[int](0)
@@ -574,6 +1302,15 @@ func TestSet_Equal(t *testing.T) {
 		must.False(t, a.Equal(b))
 		must.False(t, b.Equal(a))
 	})
+
+	t.Run("allocs", func(t *testing.T) {
+		a := From[int](ints(1000))
+		b := From[int](ints(1000))
+		allocs := testing.AllocsPerRun(10, func() {
+			a.Equal(b)
+		})
+		must.Eq(t, 0.0, allocs)
+	})
 }
 
 func TestSet_Subset(t *testing.T) {
@@ -612,6 +1349,56 @@ func TestSet_Subset(t *testing.T) {
 		b := From[int]([]int{3, 1, 2, 4})
 		must.False(t, a.Subset(b))
 	})
+
+	t.Run("allocs", func(t *testing.T) {
+		a := From[int](ints(1000))
+		b := From[int](ints(500))
+		allocs := testing.AllocsPerRun(10, func() {
+			a.Subset(b)
+			a.ProperSubset(b)
+		})
+		must.Eq(t, 0.0, allocs)
+	})
+}
+
+func TestSet_ProperSubset(t *testing.T) {
+	must.False(t, New[int](0).ProperSubset(New[int](0)))
+	must.True(t, Of(1).ProperSubset(New[int](0)))
+	must.False(t, Of(1, 2, 3).ProperSubset(Of(3, 2, 1)))
+	must.True(t, Of(1, 2, 3).ProperSubset(Of(3, 1)))
+	must.False(t, Of(1, 2, 3).ProperSubset(Of(3, 4)))
+}
+
+func TestSet_Intersects(t *testing.T) {
+	t.Run("empty empty", func(t *testing.T) {
+		a := New[int](0)
+		b := New[int](0)
+		must.False(t, a.Intersects(b))
+		must.True(t, a.Disjoint(b))
+	})
+
+	t.Run("empty some", func(t *testing.T) {
+		a := New[int](0)
+		b := Of(1, 2, 3)
+		must.False(t, a.Intersects(b))
+		must.False(t, b.Intersects(a))
+		must.True(t, a.Disjoint(b))
+	})
+
+	t.Run("common", func(t *testing.T) {
+		a := From(ints(100))
+		b := Of(200, 300, 50)
+		must.True(t, a.Intersects(b))
+		must.True(t, b.Intersects(a))
+		must.False(t, a.Disjoint(b))
+	})
+
+	t.Run("nothing common", func(t *testing.T) {
+		a := Of(1, 2, 3)
+		b := Of(4, 5, 6)
+		must.False(t, a.Intersects(b))
+		must.True(t, b.Disjoint(a))
+	})
 }
 
 func TestSet_EqualSlice(t *testing.T) {
@@ -662,4 +1449,13 @@ func TestSet_EqualSlice(t *testing.T) {
 		b := []int{1, 2, 2, 3, 3, 4, 5}
 		must.False(t, a.EqualSlice(b))
 	})
+
+	t.Run("allocs", func(t *testing.T) {
+		a := From[int](ints(1000))
+		b := ints(1000)
+		allocs := testing.AllocsPerRun(10, func() {
+			a.EqualSlice(b)
+		})
+		must.Eq(t, 0.0, allocs)
+	})
 }
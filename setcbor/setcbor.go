@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package setcbor provides CBOR encoding of sets, using the
+// github.com/fxamacker/cbor/v2 package.
+//
+// A set is encoded as an array of its elements, wrapped in tag 258, which is
+// registered with IANA for a mathematical finite set. Both tagged and untagged
+// arrays are accepted when decoding.
+//
+// This package is a module of its own, separate from the set package, so that
+// programs not using CBOR do not depend on a CBOR implementation.
+package setcbor
+
+import (
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/hashicorp/go-set"
+)
+
+// TagSet is the CBOR tag number for a mathematical finite set.
+const TagSet = 258
+
+// majorTypeTag is the CBOR major type of a tagged data item, found in the
+// high 3 bits of its initial byte.
+const majorTypeTag = 6
+
+// null is the CBOR encoding of null.
+const null = 0xf6
+
+// Marshal returns the CBOR encoding of s.
+func Marshal[T any](s set.ReadOnly[T]) ([]byte, error) {
+	return cbor.Marshal(cbor.Tag{
+		Number:  TagSet,
+		Content: s.Slice(),
+	})
+}
+
+// Unmarshal decodes the CBOR encoded set in data, inserting each element into
+// s. data may be a tagged or untagged array, or null.
+func Unmarshal[T any](data []byte, s set.Collection[T]) error {
+	content := data
+	if len(data) > 0 && data[0]>>5 == majorTypeTag {
+		var tag cbor.RawTag
+		if err := cbor.Unmarshal(data, &tag); err != nil {
+			return err
+		}
+		if tag.Number != TagSet {
+			return fmt.Errorf("setcbor: unexpected tag %d", tag.Number)
+		}
+		content = tag.Content
+	}
+	var items []T
+	if err := cbor.Unmarshal(content, &items); err != nil {
+		return err
+	}
+	set.InsertSliceInto(s, items)
+	return nil
+}
+
+// Set wraps a set.Set so that it is encoded as a CBOR set when used with the
+// cbor package, e.g. as the field of a struct.
+type Set[T comparable] struct {
+	*set.Set[T]
+}
+
+// MarshalCBOR implements the cbor.Marshaler interface. A nil Set is encoded
+// as null.
+func (s Set[T]) MarshalCBOR() ([]byte, error) {
+	if s.Set == nil {
+		return cbor.Marshal(nil)
+	}
+	return Marshal[T](s.Set)
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface, allocating the
+// underlying set.Set if necessary. Decoding null leaves s unchanged.
+func (s *Set[T]) UnmarshalCBOR(data []byte) error {
+	if len(data) == 1 && data[0] == null {
+		return nil
+	}
+	if s.Set == nil {
+		s.Set = set.New[T](0)
+	}
+	return Unmarshal[T](data, s.Set)
+}
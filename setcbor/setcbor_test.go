@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package setcbor
+
+import (
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/hashicorp/go-set"
+	"github.com/shoenig/test/must"
+)
+
+func TestMarshal(t *testing.T) {
+	t.Run("tagged", func(t *testing.T) {
+		bs, err := Marshal[int](set.Of(7))
+		must.NoError(t, err)
+		// tag 258, array of 1, unsigned 7
+		must.Eq(t, []byte{0xd9, 0x01, 0x02, 0x81, 0x07}, bs)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		a := set.TreeSetFrom[string, set.Compare[string]]([]string{"c", "a", "b"}, set.Cmp[string])
+		bs, err := Marshal[string](a)
+		must.NoError(t, err)
+
+		b := set.NewTreeSet[string, set.Compare[string]](set.Cmp[string])
+		must.NoError(t, Unmarshal[string](bs, b))
+		must.Eq(t, []string{"a", "b", "c"}, b.Slice())
+	})
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Run("untagged", func(t *testing.T) {
+		bs, err := cbor.Marshal([]int{1, 2, 2})
+		must.NoError(t, err)
+		s := set.New[int](0)
+		must.NoError(t, Unmarshal[int](bs, s))
+		must.Eq(t, set.Of(1, 2), s)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		s := set.Of(1)
+		must.NoError(t, Unmarshal[int]([]byte{0xf6}, s))
+		must.Eq(t, set.Of(1), s)
+	})
+
+	t.Run("wrong tag", func(t *testing.T) {
+		bs, err := cbor.Marshal(cbor.Tag{Number: 259, Content: []int{1}})
+		must.NoError(t, err)
+		must.ErrorContains(t, Unmarshal[int](bs, set.New[int](0)), "unexpected tag 259")
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		bs, err := Marshal[string](set.Of("a"))
+		must.NoError(t, err)
+		must.Error(t, Unmarshal[int](bs, set.New[int](0)))
+	})
+}
+
+func TestSet(t *testing.T) {
+	type record struct {
+		Name string
+		Tags Set[string]
+		More Set[int]
+	}
+
+	in := record{Name: "r1", Tags: Set[string]{set.Of("x", "y")}}
+	bs, err := cbor.Marshal(in)
+	must.NoError(t, err)
+
+	var out record
+	must.NoError(t, cbor.Unmarshal(bs, &out))
+	must.Eq(t, "r1", out.Name)
+	must.Eq(t, set.Of("x", "y"), out.Tags.Set)
+	must.Nil(t, out.More.Set)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package setmsgpack provides MessagePack encoding of sets, using the
+// github.com/vmihailenco/msgpack/v5 package.
+//
+// A set is encoded as an array of its elements.
+//
+// This package is a module of its own, separate from the set package, so that
+// programs not using MessagePack do not depend on a MessagePack implementation.
+package setmsgpack
+
+import (
+	"github.com/hashicorp/go-set"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Marshal returns the MessagePack encoding of s.
+func Marshal[T any](s set.ReadOnly[T]) ([]byte, error) {
+	return msgpack.Marshal(s.Slice())
+}
+
+// Unmarshal decodes the MessagePack encoded set in data, inserting each
+// element into s. data may be an array or nil.
+func Unmarshal[T any](data []byte, s set.Collection[T]) error {
+	var items []T
+	if err := msgpack.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	set.InsertSliceInto(s, items)
+	return nil
+}
+
+// Set wraps a set.Set so that it is encoded as a MessagePack array when used
+// with the msgpack package, e.g. as the field of a struct.
+type Set[T comparable] struct {
+	*set.Set[T]
+}
+
+// MarshalMsgpack implements the msgpack.Marshaler interface. A nil Set is
+// encoded as nil.
+func (s Set[T]) MarshalMsgpack() ([]byte, error) {
+	if s.Set == nil {
+		return msgpack.Marshal(nil)
+	}
+	return Marshal[T](s.Set)
+}
+
+// UnmarshalMsgpack implements the msgpack.Unmarshaler interface, allocating
+// the underlying set.Set if necessary.
+func (s *Set[T]) UnmarshalMsgpack(data []byte) error {
+	if s.Set == nil {
+		s.Set = set.New[T](0)
+	}
+	return Unmarshal[T](data, s.Set)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface,
+// allocating the underlying set.Set if necessary.
+//
+// Defining it on *Set hides the method promoted from the embedded set.Set
+// from the value type Set, which the msgpack package would otherwise prefer
+// over UnmarshalMsgpack.
+func (s *Set[T]) UnmarshalBinary(data []byte) error {
+	if s.Set == nil {
+		s.Set = set.New[T](0)
+	}
+	return s.Set.UnmarshalBinary(data)
+}
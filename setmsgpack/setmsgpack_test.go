@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package setmsgpack
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-set"
+	"github.com/shoenig/test/must"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestMarshal(t *testing.T) {
+	t.Run("array", func(t *testing.T) {
+		bs, err := Marshal[int](set.Of(7))
+		must.NoError(t, err)
+		// fixarray of 1, positive fixint 7
+		must.Eq(t, []byte{0x91, 0x07}, bs)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		a := set.OrderedSetOf("c", "a", "b")
+		bs, err := Marshal[string](a)
+		must.NoError(t, err)
+
+		b := set.NewOrderedSet[string](0)
+		must.NoError(t, Unmarshal[string](bs, b))
+		must.Eq(t, []string{"c", "a", "b"}, b.Slice())
+	})
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Run("duplicates", func(t *testing.T) {
+		bs, err := msgpack.Marshal([]int{1, 2, 2})
+		must.NoError(t, err)
+		s := set.New[int](0)
+		must.NoError(t, Unmarshal[int](bs, s))
+		must.Eq(t, set.Of(1, 2), s)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		s := set.Of(1)
+		must.NoError(t, Unmarshal[int]([]byte{0xc0}, s))
+		must.Eq(t, set.Of(1), s)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		bs, err := Marshal[string](set.Of("a"))
+		must.NoError(t, err)
+		must.Error(t, Unmarshal[int](bs, set.New[int](0)))
+	})
+}
+
+func TestSet(t *testing.T) {
+	type record struct {
+		Name string
+		Tags Set[string]
+		More Set[int]
+	}
+
+	in := record{Name: "r1", Tags: Set[string]{set.Of("x", "y")}}
+	bs, err := msgpack.Marshal(in)
+	must.NoError(t, err)
+
+	var out record
+	must.NoError(t, msgpack.Unmarshal(bs, &out))
+	must.Eq(t, "r1", out.Name)
+	must.Eq(t, set.Of("x", "y"), out.Tags.Set)
+	must.Nil(t, out.More.Set)
+}
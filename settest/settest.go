@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package settest provides test assertions that work against any of the set
+// types of the set package, or anything else implementing set.ReadOnly.
+//
+// Each assertion reports a failure through t.Errorf, describing the elements
+// that were missing or unexpected, and returns whether the assertion held.
+package settest
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-set"
+)
+
+// ContainsExactly asserts that s contains each of items and no other element.
+// Duplicates in items are ignored.
+func ContainsExactly[T comparable](t testing.TB, s set.ReadOnly[T], items ...T) bool {
+	t.Helper()
+	return same(t, set.ToSet(s), set.From(items))
+}
+
+// SameElements asserts that a and b contain the same elements, regardless of
+// their underlying set types.
+func SameElements[T comparable](t testing.TB, a, b set.ReadOnly[T]) bool {
+	t.Helper()
+	return same(t, set.ToSet(a), set.ToSet(b))
+}
+
+// IsSubset asserts that every element of sub is also an element of super.
+func IsSubset[T comparable](t testing.TB, sub, super set.ReadOnly[T]) bool {
+	t.Helper()
+	missing := set.ToSet(sub).Difference(set.ToSet(super))
+	if !missing.Empty() {
+		t.Errorf("settest: expected subset; elements not in superset: %s", missing)
+		return false
+	}
+	return true
+}
+
+// Contains asserts that s contains each of items.
+func Contains[T comparable](t testing.TB, s set.ReadOnly[T], items ...T) bool {
+	t.Helper()
+	missing := set.New[T](0)
+	for _, item := range items {
+		if !s.Contains(item) {
+			missing.Insert(item)
+		}
+	}
+	if !missing.Empty() {
+		t.Errorf("settest: missing elements: %s", missing)
+		return false
+	}
+	return true
+}
+
+// NotContains asserts that s contains none of items.
+func NotContains[T comparable](t testing.TB, s set.ReadOnly[T], items ...T) bool {
+	t.Helper()
+	unexpected := set.New[T](0)
+	for _, item := range items {
+		if s.Contains(item) {
+			unexpected.Insert(item)
+		}
+	}
+	if !unexpected.Empty() {
+		t.Errorf("settest: unexpected elements: %s", unexpected)
+		return false
+	}
+	return true
+}
+
+// Empty asserts that s contains no elements.
+func Empty[T any](t testing.TB, s set.ReadOnly[T]) bool {
+	t.Helper()
+	if !s.Empty() {
+		t.Errorf("settest: expected empty set; got %d elements", s.Size())
+		return false
+	}
+	return true
+}
+
+// Size asserts that s contains exactly size elements.
+func Size[T any](t testing.TB, s set.ReadOnly[T], size int) bool {
+	t.Helper()
+	if n := s.Size(); n != size {
+		t.Errorf("settest: expected size %d; got %d", size, n)
+		return false
+	}
+	return true
+}
+
+// same reports a failure if actual and expected do not contain the same
+// elements.
+func same[T comparable](t testing.TB, actual, expected *set.Set[T]) bool {
+	t.Helper()
+	missing := expected.Difference(actual)
+	unexpected := actual.Difference(expected)
+	switch {
+	case !missing.Empty() && !unexpected.Empty():
+		t.Errorf("settest: missing elements: %s; unexpected elements: %s", missing, unexpected)
+	case !missing.Empty():
+		t.Errorf("settest: missing elements: %s", missing)
+	case !unexpected.Empty():
+		t.Errorf("settest: unexpected elements: %s", unexpected)
+	default:
+		return true
+	}
+	return false
+}
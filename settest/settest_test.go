@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package settest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-set"
+	"github.com/shoenig/test/must"
+)
+
+// recorder is a testing.TB that records failures instead of reporting them.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func identity(i int) int { return i }
+
+func TestContainsExactly(t *testing.T) {
+	t.Run("pass", func(t *testing.T) {
+		r := new(recorder)
+		must.True(t, ContainsExactly(r, set.Of(1, 2, 3), 3, 2, 1, 1))
+		must.True(t, ContainsExactly(r, set.NewTreeSet[int, set.Compare[int]](set.Cmp[int])))
+		must.SliceEmpty(t, r.errors)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		r := new(recorder)
+		must.False(t, ContainsExactly(r, set.OrderedSetOf(1, 2, 4, 5), 1, 2, 3))
+		must.Eq(t, []string{"settest: missing elements: [3]; unexpected elements: [4 5]"}, r.errors)
+	})
+}
+
+func TestSameElements(t *testing.T) {
+	t.Run("pass", func(t *testing.T) {
+		r := new(recorder)
+		hs := set.NewHashSetFunc(identity, 0)
+		hs.InsertSlice([]int{1, 2, 3})
+		must.True(t, SameElements(r, set.Of(3, 2, 1), hs))
+		must.SliceEmpty(t, r.errors)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		r := new(recorder)
+		must.False(t, SameElements(r, set.Of(1), set.Of(1, 2)))
+		must.Eq(t, []string{"settest: missing elements: [2]"}, r.errors)
+	})
+
+	t.Run("unexpected", func(t *testing.T) {
+		r := new(recorder)
+		must.False(t, SameElements(r, set.Of(1, 2), set.Of(1)))
+		must.Eq(t, []string{"settest: unexpected elements: [2]"}, r.errors)
+	})
+}
+
+func TestIsSubset(t *testing.T) {
+	r := new(recorder)
+	must.True(t, IsSubset(r, set.Of(1, 2), set.Of(1, 2, 3)))
+	must.True(t, IsSubset(r, set.New[int](0), set.Of(1)))
+	must.SliceEmpty(t, r.errors)
+
+	must.False(t, IsSubset(r, set.Of(1, 4, 5), set.Of(1, 2, 3)))
+	must.Eq(t, []string{"settest: expected subset; elements not in superset: [4 5]"}, r.errors)
+}
+
+func TestContains(t *testing.T) {
+	r := new(recorder)
+	s := set.Freeze[string](set.Of("a", "b"))
+	must.True(t, Contains(r, s, "a", "b"))
+	must.True(t, NotContains(r, s, "c"))
+	must.SliceEmpty(t, r.errors)
+
+	must.False(t, Contains(r, s, "a", "c"))
+	must.False(t, NotContains(r, s, "a", "c"))
+	must.Eq(t, []string{
+		"settest: missing elements: [c]",
+		"settest: unexpected elements: [a]",
+	}, r.errors)
+}
+
+func TestEmptySize(t *testing.T) {
+	r := new(recorder)
+	must.True(t, Empty(r, set.New[int](0)))
+	must.True(t, Size(r, set.Of(1, 2), 2))
+	must.SliceEmpty(t, r.errors)
+
+	must.False(t, Empty(r, set.Of(1)))
+	must.False(t, Size(r, set.Of(1, 2), 3))
+	must.Eq(t, []string{
+		"settest: expected empty set; got 1 elements",
+		"settest: expected size 3; got 2",
+	}, r.errors)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+// Unique returns a new slice containing the elements of items with duplicates
+// removed. Elements appear in the order they are first seen in items.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func Unique[T comparable](items []T) []T {
+	seen := New[T](len(items))
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		if seen.Insert(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// SliceDifference returns a new slice containing the elements of a that are
+// not in b. Like a set operation the result contains no duplicates, and
+// elements appear in the order they are first seen in a.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func SliceDifference[T comparable](a, b []T) []T {
+	exclude := From(b)
+	result := make([]T, 0, len(a))
+	for _, item := range a {
+		if exclude.Insert(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// SliceIntersection returns a new slice containing the elements of a that are
+// also in b. Like a set operation the result contains no duplicates, and
+// elements appear in the order they are first seen in a.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func SliceIntersection[T comparable](a, b []T) []T {
+	include := From(b)
+	result := make([]T, 0, min(len(a), include.Size()))
+	for _, item := range a {
+		if include.Remove(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
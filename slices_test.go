@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestUnique(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		result := Unique[int](nil)
+		must.SliceEmpty(t, result)
+	})
+
+	t.Run("no duplicates", func(t *testing.T) {
+		result := Unique([]int{3, 1, 2})
+		must.Eq(t, []int{3, 1, 2}, result)
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		result := Unique([]string{"b", "a", "b", "c", "a", "b"})
+		must.Eq(t, []string{"b", "a", "c"}, result)
+	})
+
+	t.Run("input unchanged", func(t *testing.T) {
+		items := []int{1, 1, 2}
+		_ = Unique(items)
+		must.Eq(t, []int{1, 1, 2}, items)
+	})
+}
+
+func TestSliceDifference(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		must.SliceEmpty(t, SliceDifference[int](nil, nil))
+		must.SliceEmpty(t, SliceDifference(nil, []int{1, 2}))
+		must.Eq(t, []int{2, 1}, SliceDifference([]int{2, 1}, nil))
+	})
+
+	t.Run("some", func(t *testing.T) {
+		result := SliceDifference([]int{5, 1, 2, 3, 4}, []int{2, 4, 6})
+		must.Eq(t, []int{5, 1, 3}, result)
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		result := SliceDifference([]string{"c", "a", "c", "b", "a"}, []string{"b", "b"})
+		must.Eq(t, []string{"c", "a"}, result)
+	})
+
+	t.Run("all", func(t *testing.T) {
+		must.SliceEmpty(t, SliceDifference([]int{1, 2}, []int{2, 1, 3}))
+	})
+}
+
+func TestSliceIntersection(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		must.SliceEmpty(t, SliceIntersection[int](nil, nil))
+		must.SliceEmpty(t, SliceIntersection(nil, []int{1, 2}))
+		must.SliceEmpty(t, SliceIntersection([]int{1, 2}, nil))
+	})
+
+	t.Run("some", func(t *testing.T) {
+		result := SliceIntersection([]int{5, 4, 1, 2, 3}, []int{2, 4, 6})
+		must.Eq(t, []int{4, 2}, result)
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		result := SliceIntersection([]string{"c", "a", "c", "b", "a"}, []string{"a", "c", "a"})
+		must.Eq(t, []string{"c", "a"}, result)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		must.SliceEmpty(t, SliceIntersection([]int{1, 2}, []int{3, 4}))
+	})
+}
@@ -0,0 +1,298 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+	"strconv"
+)
+
+// SparseBitSet is a set of uint64 integers stored as a map from the index of
+// each 64 bit word to the word itself, omitting words that are zero. It is a
+// middle ground between a Set[uint64], which costs a map entry per element,
+// and a dense bit vector, which costs a bit per possible element.
+//
+// A SparseBitSet suits ID spaces that are huge but mostly empty, where
+// elements that are present tend to be near one another. For large runs of
+// elements, Bitmap is more compact still.
+//
+// Set operations are performed 64 elements at a time. Iteration produces
+// elements in ascending order.
+//
+// Not thread safe, and not safe for concurrent modification.
+type SparseBitSet struct {
+	words map[uint64]uint64
+	size  int
+}
+
+// NewSparseBitSet creates a new SparseBitSet with initial underlying capacity
+// of size words.
+func NewSparseBitSet(size int) *SparseBitSet {
+	return &SparseBitSet{
+		words: make(map[uint64]uint64, max(0, size)),
+	}
+}
+
+// SparseBitSetFrom creates a new SparseBitSet containing each item in items.
+func SparseBitSetFrom(items []uint64) *SparseBitSet {
+	s := NewSparseBitSet(0)
+	s.InsertSlice(items)
+	return s
+}
+
+// SparseBitSetOf creates a new SparseBitSet containing each item passed as an
+// argument.
+func SparseBitSetOf(items ...uint64) *SparseBitSet {
+	return SparseBitSetFrom(items)
+}
+
+// Insert item into s.
+//
+// Return true if s was modified (item was not already in s), false otherwise.
+func (s *SparseBitSet) Insert(item uint64) bool {
+	index, bit := item/64, uint64(1)<<(item%64)
+	word := s.words[index]
+	if word&bit != 0 {
+		return false
+	}
+	s.words[index] = word | bit
+	s.size++
+	return true
+}
+
+// InsertSlice will insert each item in items into s.
+//
+// Return true if s was modified (at least one item was not already in s), false otherwise.
+func (s *SparseBitSet) InsertSlice(items []uint64) bool {
+	modified := false
+	for _, item := range items {
+		if s.Insert(item) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// InsertSet will insert each element of o into s.
+//
+// Return true if s was modified (at least one item of o was not already in s), false otherwise.
+func (s *SparseBitSet) InsertSet(o *SparseBitSet) bool {
+	before := s.size
+	for index, word := range o.words {
+		s.store(index, s.words[index]|word)
+	}
+	return s.size != before
+}
+
+// Remove will remove item from s.
+//
+// Return true if s was modified (item was present), false otherwise.
+func (s *SparseBitSet) Remove(item uint64) bool {
+	index, bit := item/64, uint64(1)<<(item%64)
+	word := s.words[index]
+	if word&bit == 0 {
+		return false
+	}
+	s.store(index, word&^bit)
+	return true
+}
+
+// RemoveSlice will remove each item in items from s.
+//
+// Return true if s was modified (any item was present), false otherwise.
+func (s *SparseBitSet) RemoveSlice(items []uint64) bool {
+	modified := false
+	for _, item := range items {
+		if s.Remove(item) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// RemoveSet will remove each element of o from s.
+//
+// Return true if s was modified (any item of o was present in s), false otherwise.
+func (s *SparseBitSet) RemoveSet(o *SparseBitSet) bool {
+	before := s.size
+	for index, word := range o.words {
+		if current, exists := s.words[index]; exists {
+			s.store(index, current&^word)
+		}
+	}
+	return s.size != before
+}
+
+// RetainSet will remove each element of s that is not in o.
+//
+// Return true if s was modified (any item of s was not present in o), false otherwise.
+func (s *SparseBitSet) RetainSet(o *SparseBitSet) bool {
+	before := s.size
+	for index, word := range s.words {
+		s.store(index, word&o.words[index])
+	}
+	return s.size != before
+}
+
+// store sets the word of s at index, keeping the size of s up to date and
+// removing the word if it is zero.
+func (s *SparseBitSet) store(index, word uint64) {
+	s.size += bits.OnesCount64(word) - bits.OnesCount64(s.words[index])
+	if word == 0 {
+		delete(s.words, index)
+		return
+	}
+	s.words[index] = word
+}
+
+// Contains returns whether item is present in s.
+func (s *SparseBitSet) Contains(item uint64) bool {
+	return s.words[item/64]&(1<<(item%64)) != 0
+}
+
+// Subset returns whether o is a subset of s.
+func (s *SparseBitSet) Subset(o *SparseBitSet) bool {
+	if s.size < o.size {
+		return false
+	}
+	for index, word := range o.words {
+		if s.words[index]&word != word {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersects returns whether s and o have at least one element in common.
+func (s *SparseBitSet) Intersects(o *SparseBitSet) bool {
+	small, big := s, o
+	if len(s.words) > len(o.words) {
+		small, big = o, s
+	}
+	for index, word := range small.words {
+		if big.words[index]&word != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Disjoint returns whether s and o have no elements in common.
+func (s *SparseBitSet) Disjoint(o *SparseBitSet) bool {
+	return !s.Intersects(o)
+}
+
+// Size returns the cardinality of s.
+func (s *SparseBitSet) Size() int {
+	return s.size
+}
+
+// Empty returns true if s contains no elements, false otherwise.
+func (s *SparseBitSet) Empty() bool {
+	return s.size == 0
+}
+
+// Union returns a set that contains all elements of s and o combined.
+func (s *SparseBitSet) Union(o *SparseBitSet) *SparseBitSet {
+	result := s.Copy()
+	result.InsertSet(o)
+	return result
+}
+
+// Difference returns a set that contains elements of s that are not in o.
+func (s *SparseBitSet) Difference(o *SparseBitSet) *SparseBitSet {
+	result := NewSparseBitSet(len(s.words))
+	for index, word := range s.words {
+		result.store(index, word&^o.words[index])
+	}
+	return result
+}
+
+// Intersect returns a set that contains elements that are present in both s and o.
+func (s *SparseBitSet) Intersect(o *SparseBitSet) *SparseBitSet {
+	small, big := s, o
+	if len(s.words) > len(o.words) {
+		small, big = o, s
+	}
+	result := NewSparseBitSet(0)
+	for index, word := range small.words {
+		result.store(index, word&big.words[index])
+	}
+	return result
+}
+
+// SymmetricDifference returns a set that contains elements that are present
+// in either s or o, but not both.
+func (s *SparseBitSet) SymmetricDifference(o *SparseBitSet) *SparseBitSet {
+	result := s.Copy()
+	for index, word := range o.words {
+		result.store(index, result.words[index]^word)
+	}
+	return result
+}
+
+// Copy creates a copy of s.
+func (s *SparseBitSet) Copy() *SparseBitSet {
+	result := NewSparseBitSet(len(s.words))
+	for index, word := range s.words {
+		result.words[index] = word
+	}
+	result.size = s.size
+	return result
+}
+
+// Equal returns whether s and o contain the same elements.
+func (s *SparseBitSet) Equal(o *SparseBitSet) bool {
+	if s.size != o.size || len(s.words) != len(o.words) {
+		return false
+	}
+	for index, word := range s.words {
+		if o.words[index] != word {
+			return false
+		}
+	}
+	return true
+}
+
+// Slice creates a copy of s as a slice. Elements are in ascending order.
+func (s *SparseBitSet) Slice() []uint64 {
+	result := make([]uint64, 0, s.size)
+	s.ForEach(func(item uint64) bool {
+		result = append(result, item)
+		return true
+	})
+	return result
+}
+
+// ForEach calls visit for each element of s, in ascending order. Iteration
+// stops early if visit returns false.
+//
+// The word indexes of s are sorted before iteration begins.
+func (s *SparseBitSet) ForEach(visit func(item uint64) bool) {
+	indexes := make([]uint64, 0, len(s.words))
+	for index := range s.words {
+		indexes = append(indexes, index)
+	}
+	slices.Sort(indexes)
+	for _, index := range indexes {
+		for word := s.words[index]; word != 0; word &= word - 1 {
+			if !visit(index*64 + uint64(bits.TrailingZeros64(word))) {
+				return
+			}
+		}
+	}
+}
+
+// String creates a string representation of s. The result contains elements
+// in ascending order.
+func (s *SparseBitSet) String() string {
+	l := make([]string, 0, s.size)
+	s.ForEach(func(item uint64) bool {
+		l = append(l, strconv.FormatUint(item, 10))
+		return true
+	})
+	return fmt.Sprintf("%s", l)
+}
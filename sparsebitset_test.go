@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestSparseBitSet_Insert(t *testing.T) {
+	s := NewSparseBitSet(0)
+	must.True(t, s.Empty())
+	must.True(t, s.Insert(3))
+	must.False(t, s.Insert(3))
+	must.True(t, s.Insert(64))
+	must.True(t, s.Insert(math.MaxUint64))
+	must.True(t, s.InsertSlice([]uint64{3, 63}))
+	must.False(t, s.InsertSlice([]uint64{63, 64}))
+	must.Eq(t, 4, s.Size())
+	must.MapLen(t, 3, s.words)
+	must.Eq(t, []uint64{3, 63, 64, math.MaxUint64}, s.Slice())
+	must.Eq(t, "[3 63 64 18446744073709551615]", s.String())
+}
+
+func TestSparseBitSet_Remove(t *testing.T) {
+	s := SparseBitSetOf(1, 2, 1<<50)
+	must.True(t, s.Remove(1<<50))
+	must.False(t, s.Remove(1<<50))
+	must.MapLen(t, 1, s.words)
+	must.True(t, s.RemoveSlice([]uint64{1, 9}))
+	must.False(t, s.RemoveSlice([]uint64{1, 9}))
+	must.True(t, s.Remove(2))
+	must.True(t, s.Empty())
+	must.MapEmpty(t, s.words)
+}
+
+func TestSparseBitSet_Contains(t *testing.T) {
+	s := SparseBitSetOf(0, 127, 1<<40)
+	must.True(t, s.Contains(0))
+	must.True(t, s.Contains(127))
+	must.True(t, s.Contains(1<<40))
+	must.False(t, s.Contains(1))
+	must.False(t, s.Contains(126))
+	must.False(t, s.Contains(1<<40+1))
+}
+
+func TestSparseBitSet_Algebra(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	for _, n := range []int{0, 10, 1000, 10000} {
+		x, y := clustered(rng, n), clustered(rng, n/2)
+		a, b := SparseBitSetFrom(x), SparseBitSetFrom(y)
+		sa, sb := From(x), From(y)
+
+		must.Eq(t, sortedSlice(sa.Union(sb)), a.Union(b).Slice())
+		must.Eq(t, sortedSlice(sa.Intersect(sb)), a.Intersect(b).Slice())
+		must.Eq(t, sortedSlice(sa.Difference(sb)), a.Difference(b).Slice())
+		must.Eq(t, sortedSlice(sb.Difference(sa)), b.Difference(a).Slice())
+		must.Eq(t, sortedSlice(sa.SymmetricDifference(sb)), a.SymmetricDifference(b).Slice())
+		must.Eq(t, sa.Intersects(sb), a.Intersects(b))
+		must.Eq(t, sa.Size(), a.Size())
+
+		c := a.Copy()
+		must.Eq(t, !sa.Subset(sb), c.InsertSet(b))
+		must.True(t, c.Equal(a.Union(b)))
+		must.True(t, c.Subset(a))
+		must.True(t, c.Subset(b))
+		c.RemoveSet(b)
+		must.True(t, c.Equal(a.Difference(b)))
+		c.RetainSet(b)
+		must.True(t, c.Empty())
+
+		d := a.Copy()
+		d.RetainSet(b)
+		must.True(t, d.Equal(a.Intersect(b)))
+	}
+}
+
+func TestSparseBitSet_Subset(t *testing.T) {
+	a := SparseBitSetOf(1, 2, 3, 1<<40)
+	must.True(t, a.Subset(SparseBitSetOf(2, 1<<40)))
+	must.True(t, a.Subset(NewSparseBitSet(0)))
+	must.False(t, a.Subset(SparseBitSetOf(2, 4)))
+	must.True(t, a.Disjoint(SparseBitSetOf(4, 5)))
+	must.False(t, a.Disjoint(SparseBitSetOf(4, 3)))
+}
+
+func TestSparseBitSet_Equal(t *testing.T) {
+	a := SparseBitSetOf(1, 2, 1<<40)
+	must.True(t, a.Equal(SparseBitSetOf(1<<40, 2, 1)))
+	must.False(t, a.Equal(SparseBitSetOf(1, 2)))
+	must.False(t, a.Equal(SparseBitSetOf(1, 3, 1<<40)))
+
+	b := a.Copy()
+	b.Insert(7)
+	must.False(t, a.Contains(7))
+	must.False(t, a.Equal(b))
+}
+
+func TestSparseBitSet_ForEach(t *testing.T) {
+	s := SparseBitSetOf(1<<60, 5, 1<<20, 6, 7)
+	var visited []uint64
+	s.ForEach(func(item uint64) bool {
+		visited = append(visited, item)
+		return len(visited) < 4
+	})
+	must.Eq(t, []uint64{5, 6, 7, 1 << 20}, visited)
+}
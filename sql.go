@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Value implements the driver.Valuer interface, so that a Set may be written
+// to a database column. The elements of s are encoded as a JSON array, sorted
+// by their lexical string order so that equal sets produce equal values. A nil
+// Set is written as NULL.
+func (s *Set[T]) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	// format each element once, rather than on every comparison
+	type keyed struct {
+		key  string
+		item T
+	}
+	entries := make([]keyed, 0, len(s.items))
+	for item := range s.items {
+		entries = append(entries, keyed{key: fmt.Sprint(item), item: item})
+	}
+	slices.SortFunc(entries, func(a, b keyed) int {
+		return strings.Compare(a.key, b.key)
+	})
+	items := make([]T, len(entries))
+	for i, entry := range entries {
+		items[i] = entry.item
+	}
+	bs, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	return string(bs), nil
+}
+
+// Scan implements the sql.Scanner interface, so that a Set may be read from a
+// database column. The elements of s are replaced by those of src, which may
+// be NULL, a JSON array as written by Value, or a one-dimensional PostgreSQL
+// array literal such as {a,b,"c d"}.
+func (s *Set[T]) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case nil:
+		s.clear()
+		return nil
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return fmt.Errorf("set: cannot scan %T into Set", src)
+	}
+
+	var items []T
+	var err error
+	switch text = strings.TrimSpace(text); {
+	case strings.HasPrefix(text, "["):
+		err = json.Unmarshal([]byte(text), &items)
+	case strings.HasPrefix(text, "{"):
+		items, err = scanArray[T](text)
+	default:
+		err = fmt.Errorf("set: cannot scan %q into Set", text)
+	}
+	if err != nil {
+		return err
+	}
+	s.clear()
+	s.InsertSlice(items)
+	return nil
+}
+
+// clear removes every element from s, initializing s if necessary.
+func (s *Set[T]) clear() {
+	if s.items == nil {
+		s.items = make(map[T]nothing)
+		return
+	}
+	clear(s.items)
+}
+
+// scanArray parses the elements of a one-dimensional PostgreSQL array literal.
+func scanArray[T any](text string) ([]T, error) {
+	elements, err := splitArray(text)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]T, 0, len(elements))
+	for _, element := range elements {
+		var item T
+		if v := reflect.ValueOf(&item).Elem(); v.Kind() == reflect.String {
+			v.SetString(element)
+		} else if err := json.Unmarshal([]byte(element), &item); err != nil {
+			return nil, fmt.Errorf("set: cannot scan array element %q: %w", element, err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// splitArray splits a one-dimensional PostgreSQL array literal into the text of
+// its elements, removing quotes and escapes.
+func splitArray(text string) ([]string, error) {
+	if !strings.HasPrefix(text, "{") || !strings.HasSuffix(text, "}") {
+		return nil, fmt.Errorf("set: invalid array %q", text)
+	}
+	body := text[1 : len(text)-1]
+	if body == "" {
+		return nil, nil
+	}
+
+	var (
+		elements []string
+		element  strings.Builder
+		quoted   bool // element contains quoted or escaped text
+		inQuotes bool // currently within quotes
+	)
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\\':
+			i++
+			if i == len(body) {
+				return nil, fmt.Errorf("set: invalid array %q", text)
+			}
+			element.WriteByte(body[i])
+			quoted = true
+		case c == '"':
+			inQuotes = !inQuotes
+			quoted = true
+		case inQuotes:
+			element.WriteByte(c)
+		case c == '{' || c == '}':
+			return nil, fmt.Errorf("set: multi-dimensional array %q not supported", text)
+		case c == ',':
+			if err := appendElement(&elements, element.String(), quoted); err != nil {
+				return nil, err
+			}
+			element.Reset()
+			quoted = false
+		case c == ' ' && (element.Len() == 0 || quoted):
+			// whitespace around an element is not significant
+		default:
+			element.WriteByte(c)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("set: invalid array %q", text)
+	}
+	if err := appendElement(&elements, element.String(), quoted); err != nil {
+		return nil, err
+	}
+	return elements, nil
+}
+
+// appendElement appends element to elements, trimming whitespace from and
+// rejecting NULL for elements that are not quoted.
+func appendElement(elements *[]string, element string, quoted bool) error {
+	if !quoted {
+		element = strings.TrimSpace(element)
+		if strings.EqualFold(element, "NULL") {
+			return fmt.Errorf("set: NULL array element not supported")
+		}
+	}
+	*elements = append(*elements, element)
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+var (
+	_ driver.Valuer = (*Set[string])(nil)
+	_ sql.Scanner   = (*Set[string])(nil)
+)
+
+func TestSet_Value(t *testing.T) {
+	t.Run("strings", func(t *testing.T) {
+		v, err := Of("b", "c", "a").Value()
+		must.NoError(t, err)
+		must.Eq[driver.Value](t, `["a","b","c"]`, v)
+	})
+
+	t.Run("ints", func(t *testing.T) {
+		v, err := Of(3, 1, 2).Value()
+		must.NoError(t, err)
+		must.Eq[driver.Value](t, `[1,2,3]`, v)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		v, err := New[int](0).Value()
+		must.NoError(t, err)
+		must.Eq[driver.Value](t, `[]`, v)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var s *Set[int]
+		v, err := s.Value()
+		must.NoError(t, err)
+		must.Nil(t, v)
+	})
+
+	t.Run("lexical order", func(t *testing.T) {
+		v, err := Of(10, 9, 100).Value()
+		must.NoError(t, err)
+		must.Eq[driver.Value](t, `[10,100,9]`, v)
+	})
+}
+
+func TestSet_Scan(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		a := Of("x", "y z", `q"uote`)
+		v, err := a.Value()
+		must.NoError(t, err)
+
+		b := New[string](0)
+		must.NoError(t, b.Scan(v))
+		must.Eq(t, a, b)
+	})
+
+	t.Run("bytes", func(t *testing.T) {
+		s := New[int](0)
+		must.NoError(t, s.Scan([]byte("[1, 2, 2]")))
+		must.Eq(t, Of(1, 2), s)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		s := Of(7, 8, 9)
+		must.NoError(t, s.Scan("[1]"))
+		must.Eq(t, Of(1), s)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		s := Of(1, 2)
+		must.NoError(t, s.Scan(nil))
+		must.Empty(t, s)
+
+		var z Set[int]
+		must.NoError(t, z.Scan(nil))
+		must.True(t, z.Insert(1))
+	})
+
+	t.Run("postgres strings", func(t *testing.T) {
+		s := New[string](0)
+		must.NoError(t, s.Scan(`{a,"b c","d,e","f\"g",h\\i,"NULL",42}`))
+		must.True(t, s.EqualSlice([]string{"a", "b c", "d,e", `f"g`, `h\i`, "NULL", "42"}))
+	})
+
+	t.Run("postgres json-like strings", func(t *testing.T) {
+		s := New[string](0)
+		must.NoError(t, s.Scan(`{"\"hi\"", [1], " x "}`))
+		must.True(t, s.EqualSlice([]string{`"hi"`, "[1]", " x "}))
+	})
+
+	t.Run("postgres ints", func(t *testing.T) {
+		s := New[int](0)
+		must.NoError(t, s.Scan("{3,1,2,3}"))
+		must.True(t, s.EqualSlice([]int{1, 2, 3}))
+	})
+
+	t.Run("postgres empty", func(t *testing.T) {
+		s := Of("a")
+		must.NoError(t, s.Scan("{}"))
+		must.Empty(t, s)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		s := Of(1)
+		must.ErrorContains(t, s.Scan(42), "cannot scan int into Set")
+		must.ErrorContains(t, s.Scan("1,2"), `cannot scan "1,2" into Set`)
+		must.ErrorContains(t, s.Scan("{1,x}"), `cannot scan array element "x"`)
+		must.ErrorContains(t, s.Scan("{1,NULL}"), "NULL array element not supported")
+		must.ErrorContains(t, s.Scan("{{1},{2}}"), "multi-dimensional array")
+		must.ErrorContains(t, s.Scan(`{"1}`), "invalid array")
+		must.ErrorContains(t, s.Scan(`["a"]`), "cannot unmarshal")
+		must.Eq(t, Of(1), s)
+	})
+}
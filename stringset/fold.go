@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package stringset
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-set"
+)
+
+// Fold returns a canonical case-folded form of s, such that Fold(a) == Fold(b)
+// if and only if strings.EqualFold(a, b).
+//
+// The result is intended for use as a hash or lookup key, not for display.
+func Fold(s string) string {
+	return strings.Map(foldRune, s)
+}
+
+// foldRune returns the smallest rune that is equivalent to r under simple
+// Unicode case folding.
+func foldRune(r rune) rune {
+	smallest := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < smallest {
+			smallest = f
+		}
+	}
+	return smallest
+}
+
+// NewCaseInsensitive creates a new HashSet of strings with initial underlying
+// capacity of size, in which elements are compared case-insensitively.
+//
+// An element retains the casing with which it was first inserted; inserting
+// the same element again with different casing does not modify the set. Use
+// InsertOrReplace to update the casing of an existing element.
+func NewCaseInsensitive(size int) *set.HashSet[string, string] {
+	return NewNormalized(Fold, size)
+}
+
+// CaseInsensitiveFrom creates a new case-insensitive HashSet of strings
+// containing each item in items. When items contains several casings of the
+// same element, the first is retained.
+func CaseInsensitiveFrom(items []string) *set.HashSet[string, string] {
+	s := NewCaseInsensitive(len(items))
+	s.InsertSlice(items)
+	return s
+}
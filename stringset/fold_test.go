@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package stringset
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestFold(t *testing.T) {
+	cases := []struct {
+		a, b string
+	}{
+		{"example.com", "EXAMPLE.COM"},
+		{"Content-Type", "content-type"},
+		{"straße", "STRASSE"},
+		{"Σίσυφος", "ΣΊΣΥΦΟΣ"},
+		{"k", "\u212a"}, // kelvin sign
+		{"", ""},
+		{"abc", "abd"},
+	}
+	for _, tc := range cases {
+		must.Eq(t, strings.EqualFold(tc.a, tc.b), Fold(tc.a) == Fold(tc.b), must.Sprintf(
+			"%q vs %q", tc.a, tc.b,
+		))
+	}
+}
+
+func TestNewCaseInsensitive(t *testing.T) {
+	s := NewCaseInsensitive(0)
+	must.True(t, s.Insert("Example.com"))
+	must.False(t, s.Insert("EXAMPLE.COM"))
+	must.True(t, s.Contains("example.COM"))
+	must.Eq(t, []string{"Example.com"}, s.Slice())
+
+	must.False(t, s.InsertOrReplace("example.com"))
+	must.Eq(t, []string{"example.com"}, s.Slice())
+
+	must.True(t, s.Remove("EXAMPLE.com"))
+	must.Empty(t, s)
+}
+
+func TestCaseInsensitiveFrom(t *testing.T) {
+	s := CaseInsensitiveFrom([]string{"Accept", "accept", "ACCEPT", "Host", "host"})
+	must.Size(t, 2, s)
+	must.True(t, s.EqualSlice([]string{"ACCEPT", "HOST"}))
+
+	original, exists := s.Get("accept")
+	must.True(t, exists)
+	must.Eq(t, "Accept", original)
+}
@@ -0,0 +1,17 @@
+module github.com/hashicorp/go-set/stringset/norm
+
+go 1.24
+
+require (
+	github.com/hashicorp/go-set v0.0.0-00010101000000-000000000000
+	github.com/shoenig/test v0.6.4
+	golang.org/x/text v0.21.0
+)
+
+require github.com/google/go-cmp v0.5.9 // indirect
+
+// The replace directive only applies when building this module itself; it
+// lets the tests run against the go-set in this repository. Consumers see
+// the placeholder requirement above, so it must be bumped to a released
+// go-set version before this module is tagged.
+replace github.com/hashicorp/go-set => ../../
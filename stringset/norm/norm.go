@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package norm provides sets of strings whose elements are compared by their
+// Unicode normalization form, using the golang.org/x/text/unicode/norm
+// package.
+//
+// This package is a module of its own, separate from the set package, so that
+// programs not normalizing strings do not depend on golang.org/x/text.
+package norm
+
+import (
+	"github.com/hashicorp/go-set"
+	"github.com/hashicorp/go-set/stringset"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NewNFC creates a new HashSet of strings with initial underlying capacity of
+// size, in which elements are considered equal if they have the same Unicode
+// canonical composition (NFC). For example "café" spelled with a precomposed
+// é and spelled with an e followed by a combining acute accent are the same
+// element.
+func NewNFC(size int) *set.HashSet[string, string] {
+	return stringset.NewNormalized(norm.NFC.String, size)
+}
+
+// NFCFrom creates a new HashSet of strings containing each item in items, in
+// which elements are considered equal if they have the same NFC form. When
+// items contains several forms of the same element, the first is retained.
+func NFCFrom(items []string) *set.HashSet[string, string] {
+	return stringset.NormalizedFrom(items, norm.NFC.String)
+}
+
+// NewNFKC creates a new HashSet of strings with initial underlying capacity of
+// size, in which elements are considered equal if they have the same Unicode
+// compatibility composition (NFKC). In addition to the equivalences of NFC,
+// compatibility variants such as ligatures and fullwidth forms are the same
+// element as their plain counterparts, e.g. "ﬁle" and "file".
+func NewNFKC(size int) *set.HashSet[string, string] {
+	return stringset.NewNormalized(norm.NFKC.String, size)
+}
+
+// NFKCFrom creates a new HashSet of strings containing each item in items, in
+// which elements are considered equal if they have the same NFKC form. When
+// items contains several forms of the same element, the first is retained.
+func NFKCFrom(items []string) *set.HashSet[string, string] {
+	return stringset.NormalizedFrom(items, norm.NFKC.String)
+}
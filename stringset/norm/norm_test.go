@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package norm
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+const (
+	composed   = "caf\u00e9"                            // é as a single code point
+	decomposed = "cafe\u0301"                           // e followed by a combining acute accent
+	ligature   = "\ufb01le"                             // ﬁ ligature
+	fullwidth  = "\uff21\uff22\uff23"                   // ＡＢＣ
+	syllables  = "\ud55c\uae00"                         // 한글 as precomposed syllables
+	jamo       = "\u1112\u1161\u11ab\u1100\u1173\u11af" // 한글 as conjoining jamo
+)
+
+func TestNewNFC(t *testing.T) {
+	t.Run("composition", func(t *testing.T) {
+		s := NewNFC(10)
+		must.True(t, s.Insert(decomposed))
+		must.False(t, s.Insert(composed))
+		must.Contains[string](t, composed, s)
+		must.Size(t, 1, s)
+		must.Eq(t, []string{decomposed}, s.Slice())
+	})
+
+	t.Run("hangul", func(t *testing.T) {
+		s := NewNFC(10)
+		must.True(t, s.Insert(jamo))
+		must.Contains[string](t, syllables, s)
+		must.Size(t, 1, s)
+	})
+
+	t.Run("compatibility", func(t *testing.T) {
+		s := NewNFC(10)
+		must.True(t, s.Insert(ligature))
+		must.True(t, s.Insert("file"))
+		must.True(t, s.Insert(fullwidth))
+		must.True(t, s.Insert("ABC"))
+		must.Size(t, 4, s)
+	})
+}
+
+func TestNFCFrom(t *testing.T) {
+	s := NFCFrom([]string{composed, decomposed, jamo, syllables, ligature, "file"})
+	must.Size(t, 4, s)
+	must.Contains[string](t, decomposed, s)
+	must.Contains[string](t, syllables, s)
+	must.Contains[string](t, "file", s)
+}
+
+func TestNewNFKC(t *testing.T) {
+	t.Run("composition", func(t *testing.T) {
+		s := NewNFKC(10)
+		must.True(t, s.Insert(composed))
+		must.False(t, s.Insert(decomposed))
+		must.Size(t, 1, s)
+		must.Eq(t, []string{composed}, s.Slice())
+	})
+
+	t.Run("compatibility", func(t *testing.T) {
+		s := NewNFKC(10)
+		must.True(t, s.Insert(ligature))
+		must.False(t, s.Insert("file"))
+		must.True(t, s.Insert(fullwidth))
+		must.False(t, s.Insert("ABC"))
+		must.Size(t, 2, s)
+	})
+
+	t.Run("hangul", func(t *testing.T) {
+		s := NewNFKC(10)
+		must.True(t, s.Insert(syllables))
+		must.Contains[string](t, jamo, s)
+	})
+}
+
+func TestNFKCFrom(t *testing.T) {
+	s := NFKCFrom([]string{ligature, "file", fullwidth, "ABC", composed, decomposed})
+	must.Size(t, 3, s)
+	must.Contains[string](t, "file", s)
+	must.Contains[string](t, "ABC", s)
+	must.Contains[string](t, decomposed, s)
+}
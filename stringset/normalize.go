@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package stringset
+
+import (
+	"github.com/hashicorp/go-set"
+)
+
+// NewNormalized creates a new HashSet of strings with initial underlying
+// capacity of size, in which elements are considered equal if they have the
+// same normalized form. normalize is applied to each element on insert and
+// lookup.
+//
+// Several normalizations may be combined, e.g.
+//
+//	func(s string) string { return stringset.Fold(strings.TrimSpace(s)) }
+//
+// For Unicode normalization forms, such as NFC, see the
+// github.com/hashicorp/go-set/stringset/norm module, so that visually
+// identical strings are not treated as distinct elements.
+//
+// An element retains the form with which it was first inserted; use
+// InsertOrReplace to update the form of an existing element.
+func NewNormalized(normalize func(string) string, size int) *set.HashSet[string, string] {
+	return set.NewHashSetFunc(normalize, size)
+}
+
+// NormalizedFrom creates a new HashSet of strings containing each item in
+// items, in which elements are considered equal if they have the same form
+// after applying normalize. When items contains several forms of the same
+// element, the first is retained.
+func NormalizedFrom(items []string, normalize func(string) string) *set.HashSet[string, string] {
+	s := NewNormalized(normalize, len(items))
+	s.InsertSlice(items)
+	return s
+}
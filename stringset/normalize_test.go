@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package stringset
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestNewNormalized(t *testing.T) {
+	t.Run("lower", func(t *testing.T) {
+		s := NewNormalized(strings.ToLower, 0)
+		must.True(t, s.Insert("Nomad"))
+		must.False(t, s.Insert("nomad"))
+		must.True(t, s.Contains("NOMAD"))
+		must.Eq(t, []string{"Nomad"}, s.Slice())
+
+		must.False(t, s.InsertOrReplace("nomad"))
+		must.Eq(t, []string{"nomad"}, s.Slice())
+	})
+
+	t.Run("trim space", func(t *testing.T) {
+		s := NewNormalized(strings.TrimSpace, 0)
+		must.True(t, s.Insert(" consul\n"))
+		must.True(t, s.Contains("consul"))
+		must.False(t, s.Contains("Consul"))
+	})
+
+	t.Run("combined", func(t *testing.T) {
+		s := NewNormalized(func(s string) string {
+			return Fold(strings.TrimSpace(s))
+		}, 0)
+		must.True(t, s.Insert(" Vault "))
+		must.True(t, s.Contains("VAULT"))
+		must.False(t, s.Insert("vault"))
+		must.Size(t, 1, s)
+	})
+}
+
+func TestNormalizedFrom(t *testing.T) {
+	s := NormalizedFrom([]string{"Nomad", "nomad", "consul"}, strings.ToLower)
+	must.Size(t, 2, s)
+	must.True(t, s.EqualSlice([]string{"Nomad", "consul"}))
+}
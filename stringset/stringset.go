@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package stringset provides helpers for working with sets of strings, such
+// as filtering by prefix, suffix, or glob pattern, and joining the elements
+// of a set into a single string.
+package stringset
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-set"
+)
+
+// WithPrefix returns a new set containing the elements of s that begin with
+// prefix.
+func WithPrefix(s *set.Set[string], prefix string) *set.Set[string] {
+	return filter(s, func(element string) bool {
+		return strings.HasPrefix(element, prefix)
+	})
+}
+
+// WithSuffix returns a new set containing the elements of s that end with
+// suffix.
+func WithSuffix(s *set.Set[string], suffix string) *set.Set[string] {
+	return filter(s, func(element string) bool {
+		return strings.HasSuffix(element, suffix)
+	})
+}
+
+// MatchGlob returns a new set containing the elements of s that match the
+// shell pattern. The pattern syntax is that of path.Match.
+//
+// Returns path.ErrBadPattern if pattern is malformed.
+func MatchGlob(s *set.Set[string], pattern string) (*set.Set[string], error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	return filter(s, func(element string) bool {
+		matched, _ := path.Match(pattern, element)
+		return matched
+	}), nil
+}
+
+// Join concatenates the elements of s into a single string, separated by sep.
+// Elements are sorted in lexical order so the result is deterministic.
+func Join(s *set.Set[string], sep string) string {
+	elements := s.Slice()
+	sort.Strings(elements)
+	return strings.Join(elements, sep)
+}
+
+// filter returns a new set containing the elements of s that satisfy keep.
+func filter(s *set.Set[string], keep func(element string) bool) *set.Set[string] {
+	result := set.New[string](0)
+	s.ForEach(func(element string) bool {
+		if keep(element) {
+			result.Insert(element)
+		}
+		return true
+	})
+	return result
+}
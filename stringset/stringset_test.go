@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package stringset
+
+import (
+	"path"
+	"testing"
+
+	"github.com/hashicorp/go-set"
+	"github.com/shoenig/test/must"
+)
+
+var services = set.From([]string{
+	"api-west.service",
+	"api-east.service",
+	"db-west.service",
+	"db-west.socket",
+	"cache",
+})
+
+func TestWithPrefix(t *testing.T) {
+	must.True(t, WithPrefix(services, "api-").EqualSlice([]string{
+		"api-west.service", "api-east.service",
+	}))
+	must.Empty(t, WithPrefix(services, "web-"))
+	must.Size(t, 5, WithPrefix(services, ""))
+}
+
+func TestWithSuffix(t *testing.T) {
+	must.True(t, WithSuffix(services, ".socket").EqualSlice([]string{
+		"db-west.socket",
+	}))
+	must.Empty(t, WithSuffix(services, ".timer"))
+	must.Size(t, 5, WithSuffix(services, ""))
+}
+
+func TestMatchGlob(t *testing.T) {
+	t.Run("match", func(t *testing.T) {
+		result, err := MatchGlob(services, "*-west.*")
+		must.NoError(t, err)
+		must.True(t, result.EqualSlice([]string{
+			"api-west.service", "db-west.service", "db-west.socket",
+		}))
+	})
+
+	t.Run("character class", func(t *testing.T) {
+		result, err := MatchGlob(services, "[ad][bp]-*.s?????")
+		must.NoError(t, err)
+		must.True(t, result.EqualSlice([]string{"db-west.socket"}))
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		result, err := MatchGlob(services, "web-*")
+		must.NoError(t, err)
+		must.Empty(t, result)
+	})
+
+	t.Run("bad pattern", func(t *testing.T) {
+		_, err := MatchGlob(services, "[a-")
+		must.ErrorIs(t, err, path.ErrBadPattern)
+	})
+}
+
+func TestJoin(t *testing.T) {
+	must.Eq(t, "", Join(set.New[string](0), ","))
+	must.Eq(t, "a", Join(set.From([]string{"a"}), ","))
+	must.Eq(t, "a, b, c", Join(set.From([]string{"c", "a", "b"}), ", "))
+}
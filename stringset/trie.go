@@ -0,0 +1,305 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package stringset
+
+import (
+	"fmt"
+	"iter"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-set"
+)
+
+// Trie is a set of strings supporting efficient prefix queries, such as
+// whether any element begins with a prefix, every element beginning with a
+// prefix, and the longest element that is a prefix of a string.
+//
+// The underlying data structure is a radix tree, in which each edge is
+// labelled with a substring and nodes with a single child are merged with
+// their child. Operations take time proportional to the length of the string
+// involved, rather than the size of the set.
+//
+// https://en.wikipedia.org/wiki/Radix_tree
+//
+// Elements are ordered by their bytes, the same order as sort.Strings.
+//
+// Not thread safe, and not safe for concurrent modification.
+type Trie struct {
+	root trieNode
+	size int
+}
+
+var _ set.Collection[string] = (*Trie)(nil)
+
+// trieNode is a node of a Trie, reached from its parent by the edge label.
+// Children are kept in order of the first byte of their labels, which are
+// distinct.
+type trieNode struct {
+	label    string
+	terminal bool
+	children []*trieNode
+}
+
+// NewTrie creates a new empty Trie.
+func NewTrie() *Trie {
+	return new(Trie)
+}
+
+// TrieFrom creates a new Trie containing each item in items.
+func TrieFrom(items []string) *Trie {
+	t := NewTrie()
+	t.InsertSlice(items)
+	return t
+}
+
+// TrieOf creates a new Trie containing each item passed as an argument.
+func TrieOf(items ...string) *Trie {
+	return TrieFrom(items)
+}
+
+// Insert item into t.
+//
+// Return true if t was modified (item was not already in t), false otherwise.
+func (t *Trie) Insert(item string) bool {
+	n := &t.root
+	for item != "" {
+		i, c := n.child(item[0])
+		if c == nil {
+			n.children = append(n.children, nil)
+			copy(n.children[i+1:], n.children[i:])
+			n.children[i] = &trieNode{label: item, terminal: true}
+			t.size++
+			return true
+		}
+		l := commonPrefix(c.label, item)
+		if l < len(c.label) {
+			// split the edge to c where item diverges from it
+			mid := &trieNode{label: c.label[:l], children: []*trieNode{c}}
+			c.label = c.label[l:]
+			n.children[i] = mid
+			c = mid
+		}
+		n, item = c, item[l:]
+	}
+	if n.terminal {
+		return false
+	}
+	n.terminal = true
+	t.size++
+	return true
+}
+
+// InsertSlice will insert each item in items into t.
+//
+// Return true if t was modified (at least one item was not already in t), false otherwise.
+func (t *Trie) InsertSlice(items []string) bool {
+	modified := false
+	for _, item := range items {
+		if t.Insert(item) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// Remove item from t.
+//
+// Return true if t was modified (item was in t), false otherwise.
+func (t *Trie) Remove(item string) bool {
+	if !t.root.remove(item) {
+		return false
+	}
+	t.size--
+	return true
+}
+
+// RemoveSlice will remove each item in items from t.
+//
+// Return true if t was modified (any item was in t), false otherwise.
+func (t *Trie) RemoveSlice(items []string) bool {
+	modified := false
+	for _, item := range items {
+		if t.Remove(item) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// remove item from the subtree of n, which is relative to n, and restore the
+// invariants of the tree below n.
+func (n *trieNode) remove(item string) bool {
+	if item == "" {
+		if !n.terminal {
+			return false
+		}
+		n.terminal = false
+		return true
+	}
+	i, c := n.child(item[0])
+	if c == nil || !strings.HasPrefix(item, c.label) {
+		return false
+	}
+	if !c.remove(item[len(c.label):]) {
+		return false
+	}
+	switch {
+	case !c.terminal && len(c.children) == 0:
+		// c is now empty, and is dropped
+		n.children = append(n.children[:i], n.children[i+1:]...)
+	case !c.terminal && len(c.children) == 1:
+		// c only leads to its child, and is merged with it
+		grandchild := c.children[0]
+		grandchild.label = c.label + grandchild.label
+		n.children[i] = grandchild
+	}
+	return true
+}
+
+// Contains returns whether item is present in t.
+func (t *Trie) Contains(item string) bool {
+	n, rest := t.find(item)
+	return n != nil && rest == "" && n.terminal
+}
+
+// ContainsPrefix returns whether any element of t begins with prefix. Every
+// element begins with the empty prefix.
+func (t *Trie) ContainsPrefix(prefix string) bool {
+	if t.size == 0 {
+		return false
+	}
+	n, _ := t.find(prefix)
+	return n != nil
+}
+
+// WithPrefix returns an iterator over the elements of t that begin with
+// prefix, in lexical order.
+func (t *Trie) WithPrefix(prefix string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		n, rest := t.find(prefix)
+		if n == nil {
+			return
+		}
+		// prefix may end part way along the edge to n
+		n.each(prefix+rest, yield)
+	}
+}
+
+// LongestPrefix returns the longest element of t that is a prefix of s, and
+// true. If no element of t is a prefix of s, the empty string and false are
+// returned.
+func (t *Trie) LongestPrefix(s string) (string, bool) {
+	n := &t.root
+	depth := 0
+	longest, found := 0, n.terminal
+	for depth < len(s) {
+		_, c := n.child(s[depth])
+		if c == nil || !strings.HasPrefix(s[depth:], c.label) {
+			break
+		}
+		n, depth = c, depth+len(c.label)
+		if n.terminal {
+			longest, found = depth, true
+		}
+	}
+	return s[:longest], found
+}
+
+// find returns the node at or below which elements beginning with prefix are
+// found, and the part of the label of that node which extends beyond prefix.
+// If no element begins with prefix, nil is returned.
+func (t *Trie) find(prefix string) (*trieNode, string) {
+	n := &t.root
+	for prefix != "" {
+		_, c := n.child(prefix[0])
+		if c == nil {
+			return nil, ""
+		}
+		l := commonPrefix(c.label, prefix)
+		switch {
+		case l == len(prefix):
+			return c, c.label[l:]
+		case l < len(c.label):
+			return nil, ""
+		}
+		n, prefix = c, prefix[l:]
+	}
+	return n, ""
+}
+
+// Size returns the number of elements in t.
+func (t *Trie) Size() int {
+	return t.size
+}
+
+// Empty returns true if t contains no elements, false otherwise.
+func (t *Trie) Empty() bool {
+	return t.size == 0
+}
+
+// Slice creates a copy of t as a slice, in lexical order.
+func (t *Trie) Slice() []string {
+	result := make([]string, 0, t.size)
+	t.ForEach(func(item string) bool {
+		result = append(result, item)
+		return true
+	})
+	return result
+}
+
+// ForEach calls visit for each element of t, in lexical order. Iteration
+// stops early if visit returns false.
+func (t *Trie) ForEach(visit func(item string) bool) {
+	t.root.each("", visit)
+}
+
+// Set creates a Set containing the elements of t.
+func (t *Trie) Set() *set.Set[string] {
+	return set.From(t.Slice())
+}
+
+// String creates a string representation of t. The result contains elements
+// in lexical order.
+func (t *Trie) String() string {
+	return fmt.Sprintf("%s", t.Slice())
+}
+
+// each calls visit for each element in the subtree of n in order, where path
+// is the string leading to n, including its label.
+func (n *trieNode) each(path string, visit func(string) bool) bool {
+	if n.terminal && !visit(path) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.each(path+c.label, visit) {
+			return false
+		}
+	}
+	return true
+}
+
+// child returns the index of the child of n whose label begins with first,
+// and that child. If there is no such child, the index at which it would be
+// inserted and nil are returned.
+func (n *trieNode) child(first byte) (int, *trieNode) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].label[0] >= first
+	})
+	if i < len(n.children) && n.children[i].label[0] == first {
+		return i, n.children[i]
+	}
+	return i, nil
+}
+
+// commonPrefix returns the length of the longest common prefix of a and b.
+func commonPrefix(a, b string) int {
+	l := min(len(a), len(b))
+	for i := 0; i < l; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return l
+}
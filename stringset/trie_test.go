@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package stringset
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-set"
+	"github.com/shoenig/test/must"
+)
+
+var routes = []string{
+	"/",
+	"/api",
+	"/api/v1",
+	"/api/v1/jobs",
+	"/api/v1/job",
+	"/api/v2/nodes",
+	"/ui",
+}
+
+func TestTrie_Insert(t *testing.T) {
+	tr := NewTrie()
+	must.True(t, tr.Empty())
+	must.True(t, tr.InsertSlice(routes))
+	must.False(t, tr.InsertSlice(routes))
+	must.Eq(t, len(routes), tr.Size())
+
+	// the empty string is an element like any other
+	must.False(t, tr.Contains(""))
+	must.True(t, tr.Insert(""))
+	must.True(t, tr.Contains(""))
+
+	for _, route := range routes {
+		must.True(t, tr.Contains(route))
+	}
+	for _, missing := range []string{"/a", "/api/", "/api/v1/jo", "/api/v1/jobs/1", "ui"} {
+		must.False(t, tr.Contains(missing))
+	}
+
+	sorted := slices.Clone(routes)
+	sort.Strings(sorted)
+	must.Eq(t, append([]string{""}, sorted...), tr.Slice())
+}
+
+func TestTrie_Remove(t *testing.T) {
+	tr := TrieFrom(routes)
+	must.False(t, tr.Remove("/api/v"))
+	must.True(t, tr.Remove("/api/v1"))
+	must.False(t, tr.Remove("/api/v1"))
+	must.True(t, tr.Contains("/api/v1/job"))
+	must.True(t, tr.Contains("/api/v1/jobs"))
+
+	must.True(t, tr.RemoveSlice([]string{"/api/v1/job", "/api/v1/jobs", "/nope"}))
+	must.False(t, tr.ContainsPrefix("/api/v1"))
+	must.True(t, tr.ContainsPrefix("/api/v"))
+	must.Eq(t, []string{"/", "/api", "/api/v2/nodes", "/ui"}, tr.Slice())
+
+	must.True(t, tr.RemoveSlice(tr.Slice()))
+	must.True(t, tr.Empty())
+	must.False(t, tr.ContainsPrefix(""))
+}
+
+func TestTrie_ContainsPrefix(t *testing.T) {
+	tr := TrieFrom(routes)
+	for _, prefix := range []string{"", "/", "/a", "/api/v", "/api/v1/jobs", "/u"} {
+		must.True(t, tr.ContainsPrefix(prefix), must.Sprint(prefix))
+	}
+	for _, prefix := range []string{"a", "/api/v3", "/api/v1/jobs/", "/uix"} {
+		must.False(t, tr.ContainsPrefix(prefix), must.Sprint(prefix))
+	}
+}
+
+func TestTrie_WithPrefix(t *testing.T) {
+	tr := TrieFrom(routes)
+	must.Eq(t, []string{"/api/v1", "/api/v1/job", "/api/v1/jobs", "/api/v2/nodes"},
+		slices.Collect(tr.WithPrefix("/api/v")))
+	must.Eq(t, []string{"/api/v1/job", "/api/v1/jobs"},
+		slices.Collect(tr.WithPrefix("/api/v1/jo")))
+	must.Eq(t, []string{"/ui"}, slices.Collect(tr.WithPrefix("/ui")))
+	must.SliceEmpty(t, slices.Collect(tr.WithPrefix("/x")))
+	must.Eq(t, tr.Slice(), slices.Collect(tr.WithPrefix("")))
+
+	// stops early
+	var first []string
+	for route := range tr.WithPrefix("/api") {
+		first = append(first, route)
+		if len(first) == 2 {
+			break
+		}
+	}
+	must.Eq(t, []string{"/api", "/api/v1"}, first)
+}
+
+func TestTrie_LongestPrefix(t *testing.T) {
+	tr := TrieFrom(routes)
+	cases := map[string]string{
+		"/api/v1/jobs/example": "/api/v1/jobs",
+		"/api/v1/jobx":         "/api/v1/job",
+		"/api/v1/":             "/api/v1",
+		"/api/v3":              "/api",
+		"/uix":                 "/ui",
+		"/metrics":             "/",
+	}
+	for s, exp := range cases {
+		prefix, ok := tr.LongestPrefix(s)
+		must.True(t, ok)
+		must.Eq(t, exp, prefix)
+	}
+
+	_, ok := tr.LongestPrefix("api")
+	must.False(t, ok)
+
+	tr.Insert("")
+	prefix, ok := tr.LongestPrefix("api")
+	must.True(t, ok)
+	must.Eq(t, "", prefix)
+}
+
+func TestTrie_random(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	word := func() string {
+		b := make([]byte, rng.Intn(6))
+		for i := range b {
+			b[i] = "abc"[rng.Intn(3)]
+		}
+		return string(b)
+	}
+
+	tr := NewTrie()
+	expect := set.New[string](0)
+	for range 5000 {
+		w := word()
+		if rng.Intn(3) == 0 {
+			must.Eq(t, expect.Remove(w), tr.Remove(w))
+		} else {
+			must.Eq(t, expect.Insert(w), tr.Insert(w))
+		}
+	}
+	must.Eq(t, expect.Size(), tr.Size())
+	must.Eq(t, expect.SortedSlice(strings.Compare), tr.Slice())
+	must.True(t, tr.Set().Equal(expect))
+
+	for range 500 {
+		prefix := word()
+		matched := WithPrefix(expect, prefix).SortedSlice(strings.Compare)
+		must.Eq(t, matched, slices.AppendSeq([]string{}, tr.WithPrefix(prefix)))
+		must.Eq(t, len(matched) > 0, tr.ContainsPrefix(prefix))
+	}
+}
+
+func TestTrie_String(t *testing.T) {
+	must.Eq(t, "[]", NewTrie().String())
+	must.Eq(t, "[/ /api /ui]", TrieOf("/ui", "/api", "/").String())
+}
@@ -0,0 +1,450 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"sync"
+)
+
+// SyncHashSet is a thread-safe wrapper around HashSet. Each method acquires an
+// internal read-write lock, so a SyncHashSet may be shared and modified
+// concurrently by many goroutines.
+//
+// Methods comparing s with another SyncHashSet hold the read locks of both,
+// acquired in a consistent order. Other methods that accept another
+// SyncHashSet take a snapshot of it first, so that only one lock is held at a
+// time.
+//
+// A SyncHashSet must not be copied after first use; use Copy to create an
+// independent SyncHashSet with the same elements.
+type SyncHashSet[T any, H Hash] struct {
+	lock sync.RWMutex
+	set  *HashSet[T, H]
+}
+
+// NewSyncHashSet creates a new SyncHashSet with initial underlying capacity of
+// size.
+//
+// T must implement HashFunc[H], where H is of Hash type. This allows custom types
+// that include non-comparable fields to provide their own hash algorithm.
+func NewSyncHashSet[T HashFunc[H], H Hash](size int) *SyncHashSet[T, H] {
+	return &SyncHashSet[T, H]{
+		set: NewHashSet[T, H](size),
+	}
+}
+
+// NewSyncHashSetFunc creates a new SyncHashSet with initial underlying capacity
+// of size, using hash to make hash values from elements.
+//
+// Unlike NewSyncHashSet, T may be any type, as with NewHashSetFunc.
+func NewSyncHashSetFunc[T any, H Hash](hash func(T) H, size int) *SyncHashSet[T, H] {
+	return &SyncHashSet[T, H]{
+		set: NewHashSetFunc(hash, size),
+	}
+}
+
+// SyncHashSetFrom creates a new SyncHashSet containing each item in items.
+//
+// T must implement HashFunc[H], where H is of Hash type. This allows custom types
+// that include non-comparable fields to provide their own hash algorithm.
+func SyncHashSetFrom[T HashFunc[H], H Hash](items []T) *SyncHashSet[T, H] {
+	return &SyncHashSet[T, H]{
+		set: HashSetFrom[T, H](items),
+	}
+}
+
+// WrapHashSet creates a new SyncHashSet guarding s, which keeps the hash and
+// equality functions of s.
+//
+// s must not be used directly after being wrapped.
+func WrapHashSet[T any, H Hash](s *HashSet[T, H]) *SyncHashSet[T, H] {
+	return &SyncHashSet[T, H]{
+		set: s,
+	}
+}
+
+// snapshot returns a copy of the underlying HashSet of s.
+func (s *SyncHashSet[T, H]) snapshot() *HashSet[T, H] {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Copy()
+}
+
+// Insert item into s.
+//
+// Return true if s was modified (item was not already in s), false otherwise.
+// Equivalently, the result reports whether item was absent before the call.
+func (s *SyncHashSet[T, H]) Insert(item T) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.Insert(item)
+}
+
+// InsertIfAbsent inserts item into s if it is not already present, checking
+// and inserting under a single lock.
+//
+// Return true if item was present in s before the call, false otherwise.
+func (s *SyncHashSet[T, H]) InsertIfAbsent(item T) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return !s.set.Insert(item)
+}
+
+// InsertOrReplace inserts item into s, replacing the element of s equal to item
+// if there is one.
+//
+// Return true if item was not already in s, false if an element was replaced.
+func (s *SyncHashSet[T, H]) InsertOrReplace(item T) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.InsertOrReplace(item)
+}
+
+// InsertSlice will insert each item in items into s, under a single lock.
+//
+// Return true if s was modified (at least one item was not already in s), false otherwise.
+func (s *SyncHashSet[T, H]) InsertSlice(items []T) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.InsertSlice(items)
+}
+
+// InsertMany will insert each item passed as an argument into s, under a single lock.
+//
+// Return true if s was modified (at least one item was not already in s), false otherwise.
+func (s *SyncHashSet[T, H]) InsertMany(items ...T) bool {
+	return s.InsertSlice(items)
+}
+
+// InsertSet will insert each element of o into s.
+//
+// Return true if s was modified (at least one item of o was not already in s), false otherwise.
+func (s *SyncHashSet[T, H]) InsertSet(o *SyncHashSet[T, H]) bool {
+	other := o.snapshot()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.InsertSet(other)
+}
+
+// Remove will remove item from s.
+//
+// Return true if s was modified (item was present), false otherwise.
+func (s *SyncHashSet[T, H]) Remove(item T) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.Remove(item)
+}
+
+// RemoveSlice will remove each item in items from s, under a single lock.
+//
+// Return true if s was modified (any item was present), false otherwise.
+func (s *SyncHashSet[T, H]) RemoveSlice(items []T) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.RemoveSlice(items)
+}
+
+// RemoveMany will remove each item passed as an argument from s, under a single lock.
+//
+// Return true if s was modified (any item was present), false otherwise.
+func (s *SyncHashSet[T, H]) RemoveMany(items ...T) bool {
+	return s.RemoveSlice(items)
+}
+
+// RemoveSet will remove each element of o from s.
+//
+// Return true if s was modified (any item of o was present in s), false otherwise.
+func (s *SyncHashSet[T, H]) RemoveSet(o *SyncHashSet[T, H]) bool {
+	other := o.snapshot()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.RemoveSet(other)
+}
+
+// RemoveFunc will remove each element from s that satisfies condition f, under
+// a single lock. f must not call methods on s.
+//
+// Return true if s was modified, false otherwise.
+func (s *SyncHashSet[T, H]) RemoveFunc(f func(item T) bool) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.RemoveFunc(f)
+}
+
+// RetainSet will remove each element of s that is not in o.
+//
+// Return true if s was modified (any item of s was not present in o), false otherwise.
+func (s *SyncHashSet[T, H]) RetainSet(o *SyncHashSet[T, H]) bool {
+	other := o.snapshot()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.RetainSet(other)
+}
+
+// Contains returns whether item is present in s.
+func (s *SyncHashSet[T, H]) Contains(item T) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Contains(item)
+}
+
+// Get returns the element of s equal to item, and true. If there is no such
+// element the zero value of T and false are returned.
+func (s *SyncHashSet[T, H]) Get(item T) (T, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Get(item)
+}
+
+// ContainsAll returns whether s contains at least every item in items.
+func (s *SyncHashSet[T, H]) ContainsAll(items ...T) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.ContainsAll(items...)
+}
+
+// ContainsAny returns whether s contains at least one of the items passed as
+// an argument.
+func (s *SyncHashSet[T, H]) ContainsAny(items ...T) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.ContainsAny(items...)
+}
+
+// ContainsSlice returns whether s contains the same set of of elements
+// that are in items. The elements of items may contain duplicates.
+func (s *SyncHashSet[T, H]) ContainsSlice(items []T) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.ContainsSlice(items)
+}
+
+// ContainsFunc returns whether s contains at least one element that satisfies
+// condition f. f must not call methods on s.
+func (s *SyncHashSet[T, H]) ContainsFunc(f func(item T) bool) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.ContainsFunc(f)
+}
+
+// Subset returns whether o is a subset of s.
+func (s *SyncHashSet[T, H]) Subset(o *SyncHashSet[T, H]) bool {
+	rlockPair(&s.lock, &o.lock)
+	defer runlockPair(&s.lock, &o.lock)
+	return s.set.Subset(o.set)
+}
+
+// ProperSubset returns whether o is a proper subset of s.
+func (s *SyncHashSet[T, H]) ProperSubset(o *SyncHashSet[T, H]) bool {
+	rlockPair(&s.lock, &o.lock)
+	defer runlockPair(&s.lock, &o.lock)
+	return s.set.ProperSubset(o.set)
+}
+
+// Intersects returns whether s and o have at least one element in common.
+func (s *SyncHashSet[T, H]) Intersects(o *SyncHashSet[T, H]) bool {
+	rlockPair(&s.lock, &o.lock)
+	defer runlockPair(&s.lock, &o.lock)
+	return s.set.Intersects(o.set)
+}
+
+// Disjoint returns whether s and o have no elements in common.
+func (s *SyncHashSet[T, H]) Disjoint(o *SyncHashSet[T, H]) bool {
+	return !s.Intersects(o)
+}
+
+// Size returns the cardinality of s.
+func (s *SyncHashSet[T, H]) Size() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Size()
+}
+
+// Empty returns true if s contains no elements, false otherwise.
+func (s *SyncHashSet[T, H]) Empty() bool {
+	return s.Size() == 0
+}
+
+// Union returns a set that contains all elements of s and o combined.
+func (s *SyncHashSet[T, H]) Union(o *SyncHashSet[T, H]) *SyncHashSet[T, H] {
+	other := o.snapshot()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return &SyncHashSet[T, H]{set: s.set.Union(other)}
+}
+
+// Difference returns a set that contains elements of s that are not in o.
+func (s *SyncHashSet[T, H]) Difference(o *SyncHashSet[T, H]) *SyncHashSet[T, H] {
+	other := o.snapshot()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return &SyncHashSet[T, H]{set: s.set.Difference(other)}
+}
+
+// Intersect returns a set that contains elements that are present in both s and o.
+func (s *SyncHashSet[T, H]) Intersect(o *SyncHashSet[T, H]) *SyncHashSet[T, H] {
+	other := o.snapshot()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return &SyncHashSet[T, H]{set: s.set.Intersect(other)}
+}
+
+// SymmetricDifference returns a set that contains elements that are present in
+// either s or o, but not in both.
+func (s *SyncHashSet[T, H]) SymmetricDifference(o *SyncHashSet[T, H]) *SyncHashSet[T, H] {
+	other := o.snapshot()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return &SyncHashSet[T, H]{set: s.set.SymmetricDifference(other)}
+}
+
+// Copy creates an independent copy of s, with its own lock.
+func (s *SyncHashSet[T, H]) Copy() *SyncHashSet[T, H] {
+	return &SyncHashSet[T, H]{set: s.snapshot()}
+}
+
+// Slice creates a copy of s as a slice. Elements are in no particular order.
+func (s *SyncHashSet[T, H]) Slice() []T {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Slice()
+}
+
+// SortedSlice creates a copy of s as a slice, with elements sorted according
+// to compare.
+func (s *SyncHashSet[T, H]) SortedSlice(compare Compare[T]) []T {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.SortedSlice(compare)
+}
+
+// ForEach calls visit for each element of s, in no particular order. Iteration
+// stops early if visit returns false.
+//
+// The read lock of s is held for the duration of the iteration, so visit must
+// not modify s.
+func (s *SyncHashSet[T, H]) ForEach(visit func(item T) bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.set.ForEach(visit)
+}
+
+// Capacity returns the number of elements the underlying storage of s has been
+// sized for.
+func (s *SyncHashSet[T, H]) Capacity() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Capacity()
+}
+
+// Grow increases the underlying capacity of s so that at least n more items
+// can be inserted without the set needing to grow again.
+func (s *SyncHashSet[T, H]) Grow(n int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.set.Grow(n)
+}
+
+// Compact rebuilds the underlying storage of s sized to its current number of
+// elements.
+func (s *SyncHashSet[T, H]) Compact() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.set.Compact()
+}
+
+// Update calls f with the underlying HashSet of s while holding the write lock,
+// so that any number of operations may be applied atomically.
+//
+// The HashSet must not be retained or used after f returns.
+func (s *SyncHashSet[T, H]) Update(f func(s *HashSet[T, H])) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	f(s.set)
+}
+
+// View calls f with the underlying HashSet of s while holding the read lock, so
+// that any number of queries observe a consistent state.
+//
+// The HashSet must not be modified, retained, or used after f returns.
+func (s *SyncHashSet[T, H]) View(f func(s *HashSet[T, H])) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	f(s.set)
+}
+
+// String creates a string representation of s, using "%v" printf formatting to transform
+// each element into a string. The result contains elements sorted by their lexical
+// string order.
+func (s *SyncHashSet[T, H]) String() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.String()
+}
+
+// StringFunc creates a string representation of s, using f to transform each element
+// into a string. The result contains elements sorted by their lexical string order.
+func (s *SyncHashSet[T, H]) StringFunc(f func(element T) string) string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.StringFunc(f)
+}
+
+// Equal returns whether s and o contain the same elements.
+func (s *SyncHashSet[T, H]) Equal(o *SyncHashSet[T, H]) bool {
+	rlockPair(&s.lock, &o.lock)
+	defer runlockPair(&s.lock, &o.lock)
+	return s.set.Equal(o.set)
+}
+
+// EqualSlice returns whether s and items contain the same elements.
+func (s *SyncHashSet[T, H]) EqualSlice(items []T) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.EqualSlice(items)
+}
+
+// Fingerprint returns a hash of the elements of s that does not depend on the
+// order of iteration. See HashSet.Fingerprint.
+func (s *SyncHashSet[T, H]) Fingerprint() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Fingerprint()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s *SyncHashSet[T, H]) MarshalJSON() ([]byte, error) {
+	return marshalJSON[T](s)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// As with HashSet, a zero value SyncHashSet is initialized to use the Hash()
+// method of T, which must then implement HashFunc[H].
+func (s *SyncHashSet[T, H]) UnmarshalJSON(data []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.set == nil {
+		s.set = new(HashSet[T, H])
+	}
+	return s.set.UnmarshalJSON(data)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s *SyncHashSet[T, H]) MarshalBinary() ([]byte, error) {
+	return marshalBinary[T](s)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+//
+// As with HashSet, a zero value SyncHashSet is initialized to use the Hash()
+// method of T, which must then implement HashFunc[H].
+func (s *SyncHashSet[T, H]) UnmarshalBinary(data []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.set == nil {
+		s.set = new(HashSet[T, H])
+	}
+	return s.set.UnmarshalBinary(data)
+}
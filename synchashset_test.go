@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestSyncHashSet_New(t *testing.T) {
+	must.Empty(t, NewSyncHashSet[*company, string](10))
+	must.Empty(t, SyncHashSetFrom[*company, string](nil))
+	must.Size(t, 3, SyncHashSetFrom[*company, string]([]*company{c1, c2, c3, c2}))
+
+	s := NewSyncHashSetFunc(func(s string) int { return len(s) }, 0)
+	must.True(t, s.InsertMany("a", "bb"))
+	must.False(t, s.Insert("c"))
+
+	w := WrapHashSet(HashSetOf[*company, string](c1, c2))
+	must.Size(t, 2, w)
+}
+
+func TestSyncHashSet_Insert(t *testing.T) {
+	s := NewSyncHashSet[*company, string](0)
+	must.True(t, s.Insert(c1))
+	must.False(t, s.Insert(c1))
+	must.True(t, s.InsertIfAbsent(c1))
+	must.False(t, s.InsertIfAbsent(c2))
+	must.True(t, s.InsertMany(c2, c3))
+	must.True(t, s.InsertSlice([]*company{c3, c4}))
+	must.False(t, s.InsertSlice([]*company{c1, c4}))
+	must.True(t, s.InsertSet(SyncHashSetFrom[*company, string]([]*company{c5, c6})))
+	must.False(t, s.InsertSet(s))
+	must.Eq(t, "[<street 1> <street 2> <street 3> <street 4> <street 5> <street 6>]", s.String())
+}
+
+func TestSyncHashSet_Remove(t *testing.T) {
+	s := SyncHashSetFrom[*company, string]([]*company{c1, c2, c3, c4, c5, c6, c7, c8})
+	must.True(t, s.Remove(c1))
+	must.False(t, s.Remove(c1))
+	must.True(t, s.RemoveMany(c2, c3))
+	must.True(t, s.RemoveSlice([]*company{c4, c10}))
+	must.True(t, s.RemoveSet(SyncHashSetFrom[*company, string]([]*company{c5})))
+	must.True(t, s.RemoveFunc(func(c *company) bool { return c.floor == 8 }))
+	must.True(t, s.RetainSet(SyncHashSetFrom[*company, string]([]*company{c6})))
+	must.True(t, s.EqualSlice([]*company{c6}))
+	must.True(t, s.RemoveSet(s))
+	must.Empty(t, s)
+}
+
+func TestSyncHashSet_Contains(t *testing.T) {
+	s := SyncHashSetFrom[*company, string]([]*company{c1, c2, c3})
+	must.True(t, s.Contains(c1))
+	must.False(t, s.Contains(c4))
+	must.True(t, s.ContainsAll(c1, c3))
+	must.True(t, s.ContainsAny(c4, c3))
+	must.True(t, s.ContainsSlice([]*company{c1, c2, c3, c3}))
+	must.True(t, s.ContainsFunc(func(c *company) bool { return c.floor > 2 }))
+	must.True(t, s.Subset(SyncHashSetFrom[*company, string]([]*company{c2, c3})))
+	must.True(t, s.ProperSubset(SyncHashSetFrom[*company, string]([]*company{c2, c3})))
+	must.False(t, s.Subset(SyncHashSetFrom[*company, string]([]*company{c3, c4})))
+	must.True(t, s.Intersects(SyncHashSetFrom[*company, string]([]*company{c3, c4})))
+	must.True(t, s.Disjoint(SyncHashSetFrom[*company, string]([]*company{c4, c5})))
+	must.True(t, s.Equal(SyncHashSetFrom[*company, string]([]*company{c3, c2, c1})))
+}
+
+func TestSyncHashSet_GetReplace(t *testing.T) {
+	s := NewSyncHashSet[*lease, string](0)
+	must.True(t, s.InsertOrReplace(&lease{id: "a", expires: 1}))
+	must.False(t, s.InsertOrReplace(&lease{id: "a", expires: 2}))
+	result, exists := s.Get(&lease{id: "a"})
+	must.True(t, exists)
+	must.Eq(t, 2, result.expires)
+}
+
+func TestSyncHashSet_Algebra(t *testing.T) {
+	a := SyncHashSetFrom[*company, string]([]*company{c1, c2, c3, c4})
+	b := SyncHashSetFrom[*company, string]([]*company{c3, c4, c5})
+	must.True(t, a.Union(b).EqualSlice([]*company{c1, c2, c3, c4, c5}))
+	must.True(t, a.Difference(b).EqualSlice([]*company{c1, c2}))
+	must.True(t, a.Intersect(b).EqualSlice([]*company{c3, c4}))
+	must.True(t, a.SymmetricDifference(b).EqualSlice([]*company{c1, c2, c5}))
+}
+
+func TestSyncHashSet_Copy(t *testing.T) {
+	a := SyncHashSetFrom[*company, string]([]*company{c1, c2})
+	b := a.Copy()
+	must.True(t, b.Insert(c3))
+	must.False(t, a.Contains(c3))
+	must.True(t, b.Contains(c3))
+}
+
+func TestSyncHashSet_UpdateView(t *testing.T) {
+	s := SyncHashSetFrom[*company, string]([]*company{c1, c2})
+	s.Update(func(u *HashSet[*company, string]) {
+		if u.Contains(c2) {
+			u.Remove(c2)
+			u.Insert(c10)
+		}
+	})
+	s.View(func(v *HashSet[*company, string]) {
+		must.True(t, v.EqualSlice([]*company{c1, c10}))
+	})
+}
+
+func TestSyncHashSet_JSON(t *testing.T) {
+	type payload struct {
+		Offices *SyncHashSet[*company, string] `json:"offices"`
+	}
+
+	src := payload{Offices: SyncHashSetFrom[*company, string]([]*company{c1, c2})}
+	bs, err := json.Marshal(src)
+	must.NoError(t, err)
+
+	var dst payload
+	must.NoError(t, json.Unmarshal(bs, &dst))
+	must.True(t, src.Offices.Equal(dst.Offices))
+
+	t.Run("zero value", func(t *testing.T) {
+		bs, err := json.Marshal(src.Offices)
+		must.NoError(t, err)
+		var c SyncHashSet[*company, string]
+		must.NoError(t, json.Unmarshal(bs, &c))
+		must.True(t, src.Offices.Equal(&c))
+	})
+}
+
+func TestSyncHashSet_concurrent(t *testing.T) {
+	const workers = 8
+	const each = 250
+
+	s := NewSyncHashSet[*coded, int](0)
+	o := NewSyncHashSet[*coded, int](0)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < each; i++ {
+				item := &coded{i: w*each + i}
+				s.Insert(item)
+				if i%50 == 0 {
+					o.InsertSet(s)
+					s.Subset(o)
+					o.Equal(s)
+				}
+				_ = s.Contains(item)
+				_ = s.Size()
+				if i%10 == 0 {
+					s.Remove(item)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	o.InsertSet(s)
+	must.Size(t, workers*each*9/10, s)
+	must.True(t, o.Subset(s))
+}
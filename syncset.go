@@ -0,0 +1,405 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// SyncSet is a thread-safe wrapper around Set. Each method acquires an internal
+// read-write lock, so a SyncSet may be shared and modified concurrently by
+// many goroutines.
+//
+// Methods comparing s with another SyncSet hold the read locks of both,
+// acquired in a consistent order. Other methods that accept another SyncSet
+// take a snapshot of it first, so that only one lock is held at a time.
+//
+// A SyncSet must not be copied after first use; use Copy to create an
+// independent SyncSet with the same elements.
+type SyncSet[T comparable] struct {
+	lock sync.RWMutex
+	set  *Set[T]
+}
+
+// NewSyncSet creates a new SyncSet with initial underlying capacity of size.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect. For these types, use HashSet instead.
+func NewSyncSet[T comparable](size int) *SyncSet[T] {
+	return &SyncSet[T]{
+		set: New[T](size),
+	}
+}
+
+// SyncSetFrom creates a new SyncSet containing each item in items.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect. For these types, use HashSet instead.
+func SyncSetFrom[T comparable](items []T) *SyncSet[T] {
+	return &SyncSet[T]{
+		set: From(items),
+	}
+}
+
+// SyncSetOf creates a new SyncSet containing each item passed as an argument.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect. For these types, use HashSet instead.
+func SyncSetOf[T comparable](items ...T) *SyncSet[T] {
+	return SyncSetFrom(items)
+}
+
+// snapshot returns a copy of the underlying Set of s.
+func (s *SyncSet[T]) snapshot() *Set[T] {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Copy()
+}
+
+// rlockPair acquires the read locks a and b in order of their addresses, so
+// that goroutines comparing the same two sets from either side cannot deadlock
+// with a waiting writer. The locks are released by runlockPair.
+func rlockPair(a, b *sync.RWMutex) {
+	if uintptr(unsafe.Pointer(a)) > uintptr(unsafe.Pointer(b)) {
+		a, b = b, a
+	}
+	a.RLock()
+	if a != b {
+		b.RLock()
+	}
+}
+
+// runlockPair releases the read locks acquired by rlockPair.
+func runlockPair(a, b *sync.RWMutex) {
+	a.RUnlock()
+	if a != b {
+		b.RUnlock()
+	}
+}
+
+// Insert item into s.
+//
+// Return true if s was modified (item was not already in s), false otherwise.
+// Equivalently, the result reports whether item was absent before the call.
+func (s *SyncSet[T]) Insert(item T) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.Insert(item)
+}
+
+// InsertIfAbsent inserts item into s if it is not already present, checking
+// and inserting under a single lock.
+//
+// Return true if item was present in s before the call, false otherwise.
+func (s *SyncSet[T]) InsertIfAbsent(item T) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return !s.set.Insert(item)
+}
+
+// InsertSlice will insert each item in items into s, under a single lock.
+//
+// Return true if s was modified (at least one item was not already in s), false otherwise.
+func (s *SyncSet[T]) InsertSlice(items []T) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.InsertSlice(items)
+}
+
+// InsertMany will insert each item passed as an argument into s, under a single lock.
+//
+// Return true if s was modified (at least one item was not already in s), false otherwise.
+func (s *SyncSet[T]) InsertMany(items ...T) bool {
+	return s.InsertSlice(items)
+}
+
+// InsertSet will insert each element of o into s.
+//
+// Return true if s was modified (at least one item of o was not already in s), false otherwise.
+func (s *SyncSet[T]) InsertSet(o *SyncSet[T]) bool {
+	other := o.snapshot()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.InsertSet(other)
+}
+
+// Remove will remove item from s.
+//
+// Return true if s was modified (item was present), false otherwise.
+func (s *SyncSet[T]) Remove(item T) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.Remove(item)
+}
+
+// RemoveSlice will remove each item in items from s, under a single lock.
+//
+// Return true if s was modified (any item was present), false otherwise.
+func (s *SyncSet[T]) RemoveSlice(items []T) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.RemoveSlice(items)
+}
+
+// RemoveMany will remove each item passed as an argument from s, under a single lock.
+//
+// Return true if s was modified (any item was present), false otherwise.
+func (s *SyncSet[T]) RemoveMany(items ...T) bool {
+	return s.RemoveSlice(items)
+}
+
+// RemoveSet will remove each element of o from s.
+//
+// Return true if s was modified (any item of o was present in s), false otherwise.
+func (s *SyncSet[T]) RemoveSet(o *SyncSet[T]) bool {
+	other := o.snapshot()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.RemoveSet(other)
+}
+
+// RemoveFunc will remove each element from s that satisfies condition f, under
+// a single lock. f must not call methods on s.
+//
+// Return true if s was modified, false otherwise.
+func (s *SyncSet[T]) RemoveFunc(f func(item T) bool) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set.RemoveFunc(f)
+}
+
+// Contains returns whether item is present in s.
+func (s *SyncSet[T]) Contains(item T) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Contains(item)
+}
+
+// ContainsAll returns whether s contains at least every item in items.
+func (s *SyncSet[T]) ContainsAll(items ...T) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.ContainsAll(items...)
+}
+
+// ContainsAny returns whether s contains at least one of the items passed as
+// an argument.
+func (s *SyncSet[T]) ContainsAny(items ...T) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.ContainsAny(items...)
+}
+
+// ContainsSlice returns whether s contains the same set of of elements
+// that are in items. The elements of items may contain duplicates.
+func (s *SyncSet[T]) ContainsSlice(items []T) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.ContainsSlice(items)
+}
+
+// ContainsFunc returns whether s contains at least one element that satisfies
+// condition f. f must not call methods on s.
+func (s *SyncSet[T]) ContainsFunc(f func(item T) bool) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.ContainsFunc(f)
+}
+
+// Subset returns whether o is a subset of s.
+func (s *SyncSet[T]) Subset(o *SyncSet[T]) bool {
+	rlockPair(&s.lock, &o.lock)
+	defer runlockPair(&s.lock, &o.lock)
+	return s.set.Subset(o.set)
+}
+
+// Intersects returns whether s and o have at least one element in common.
+func (s *SyncSet[T]) Intersects(o *SyncSet[T]) bool {
+	rlockPair(&s.lock, &o.lock)
+	defer runlockPair(&s.lock, &o.lock)
+	return s.set.Intersects(o.set)
+}
+
+// Disjoint returns whether s and o have no elements in common.
+func (s *SyncSet[T]) Disjoint(o *SyncSet[T]) bool {
+	return !s.Intersects(o)
+}
+
+// Size returns the cardinality of s.
+func (s *SyncSet[T]) Size() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Size()
+}
+
+// Empty returns true if s contains no elements, false otherwise.
+func (s *SyncSet[T]) Empty() bool {
+	return s.Size() == 0
+}
+
+// Union returns a set that contains all elements of s and o combined.
+func (s *SyncSet[T]) Union(o *SyncSet[T]) *SyncSet[T] {
+	other := o.snapshot()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return &SyncSet[T]{set: s.set.Union(other)}
+}
+
+// Difference returns a set that contains elements of s that are not in o.
+func (s *SyncSet[T]) Difference(o *SyncSet[T]) *SyncSet[T] {
+	other := o.snapshot()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return &SyncSet[T]{set: s.set.Difference(other)}
+}
+
+// Intersect returns a set that contains elements that are present in both s and o.
+func (s *SyncSet[T]) Intersect(o *SyncSet[T]) *SyncSet[T] {
+	other := o.snapshot()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return &SyncSet[T]{set: s.set.Intersect(other)}
+}
+
+// Copy creates an independent copy of s, with its own lock.
+func (s *SyncSet[T]) Copy() *SyncSet[T] {
+	return &SyncSet[T]{set: s.snapshot()}
+}
+
+// Slice creates a copy of s as a slice. Elements are in no particular order.
+func (s *SyncSet[T]) Slice() []T {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Slice()
+}
+
+// SortedSlice creates a copy of s as a slice, with elements sorted according
+// to compare.
+func (s *SyncSet[T]) SortedSlice(compare Compare[T]) []T {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.SortedSlice(compare)
+}
+
+// ForEach calls visit for each element of s, in no particular order. Iteration
+// stops early if visit returns false.
+//
+// The read lock of s is held for the duration of the iteration, so visit must
+// not modify s.
+func (s *SyncSet[T]) ForEach(visit func(item T) bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.set.ForEach(visit)
+}
+
+// Grow increases the underlying capacity of s so that at least n more items
+// can be inserted without the set needing to grow again.
+func (s *SyncSet[T]) Grow(n int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.set.Grow(n)
+}
+
+// Compact rebuilds the underlying storage of s sized to its current number of
+// elements.
+func (s *SyncSet[T]) Compact() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.set.Compact()
+}
+
+// Update calls f with the underlying Set of s while holding the write lock,
+// so that any number of operations may be applied atomically.
+//
+// The Set must not be retained or used after f returns.
+func (s *SyncSet[T]) Update(f func(s *Set[T])) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	f(s.set)
+}
+
+// View calls f with the underlying Set of s while holding the read lock, so
+// that any number of queries observe a consistent state.
+//
+// The Set must not be modified, retained, or used after f returns.
+func (s *SyncSet[T]) View(f func(s *Set[T])) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	f(s.set)
+}
+
+// String creates a string representation of s, using "%v" printf formatting to transform
+// each element into a string. The result contains elements sorted by their lexical
+// string order.
+func (s *SyncSet[T]) String() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.String()
+}
+
+// StringFunc creates a string representation of s, using f to transform each element
+// into a string. The result contains elements sorted by their lexical string order.
+func (s *SyncSet[T]) StringFunc(f func(element T) string) string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.StringFunc(f)
+}
+
+// Equal returns whether s and o contain the same elements.
+func (s *SyncSet[T]) Equal(o *SyncSet[T]) bool {
+	rlockPair(&s.lock, &o.lock)
+	defer runlockPair(&s.lock, &o.lock)
+	return s.set.Equal(o.set)
+}
+
+// EqualSlice returns whether s and items contain the same elements.
+func (s *SyncSet[T]) EqualSlice(items []T) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.EqualSlice(items)
+}
+
+// Fingerprint returns a hash of the elements of s that does not depend on the
+// order of iteration. See Set.Fingerprint.
+func (s *SyncSet[T]) Fingerprint() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set.Fingerprint()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s *SyncSet[T]) MarshalJSON() ([]byte, error) {
+	return marshalJSON[T](s)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// A zero value SyncSet (e.g. the field of a struct) is initialized before
+// decoding.
+func (s *SyncSet[T]) UnmarshalJSON(data []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.set == nil {
+		s.set = New[T](0)
+	}
+	return unmarshalJSON[T](s.set, data)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s *SyncSet[T]) MarshalBinary() ([]byte, error) {
+	return marshalBinary[T](s)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+//
+// As with UnmarshalJSON, a zero value SyncSet is initialized before decoding.
+func (s *SyncSet[T]) UnmarshalBinary(data []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.set == nil {
+		s.set = New[T](0)
+	}
+	return unmarshalBinary[T](s.set, data)
+}
@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestSyncSet_New(t *testing.T) {
+	s := NewSyncSet[int](10)
+	must.Empty(t, s)
+	must.Empty(t, SyncSetFrom[int](nil))
+	must.Size(t, 3, SyncSetOf(1, 2, 3, 2))
+}
+
+func TestSyncSet_Insert(t *testing.T) {
+	s := NewSyncSet[int](0)
+	must.True(t, s.Insert(1))
+	must.False(t, s.Insert(1))
+	must.True(t, s.InsertIfAbsent(1))
+	must.False(t, s.InsertIfAbsent(7))
+	must.True(t, s.Contains(7))
+	must.True(t, s.Remove(7))
+	must.True(t, s.InsertMany(2, 3))
+	must.True(t, s.InsertSlice([]int{3, 4}))
+	must.False(t, s.InsertSlice([]int{1, 4}))
+	must.True(t, s.InsertSet(SyncSetOf(5, 6)))
+	must.False(t, s.InsertSet(s))
+	must.Eq(t, []int{1, 2, 3, 4, 5, 6}, s.SortedSlice(Cmp[int]))
+}
+
+func TestSyncSet_Remove(t *testing.T) {
+	s := SyncSetFrom(ints(10))
+	must.True(t, s.Remove(1))
+	must.False(t, s.Remove(1))
+	must.True(t, s.RemoveMany(2, 3))
+	must.True(t, s.RemoveSlice([]int{4, 11}))
+	must.True(t, s.RemoveSet(SyncSetOf(5)))
+	must.True(t, s.RemoveFunc(func(i int) bool { return i%2 == 0 }))
+	must.Eq(t, []int{7, 9}, s.SortedSlice(Cmp[int]))
+	must.True(t, s.RemoveSet(s))
+	must.Empty(t, s)
+}
+
+func TestSyncSet_Contains(t *testing.T) {
+	s := SyncSetOf(1, 2, 3)
+	must.True(t, s.Contains(1))
+	must.False(t, s.Contains(4))
+	must.True(t, s.ContainsAll(1, 3))
+	must.True(t, s.ContainsAny(4, 3))
+	must.True(t, s.ContainsSlice([]int{1, 2, 3, 3}))
+	must.True(t, s.ContainsFunc(func(i int) bool { return i > 2 }))
+	must.True(t, s.Subset(SyncSetOf(2, 3)))
+	must.False(t, s.Subset(SyncSetOf(3, 4)))
+	must.True(t, s.Equal(SyncSetOf(3, 2, 1)))
+	must.True(t, s.Equal(s))
+	must.True(t, s.EqualSlice([]int{3, 2, 1}))
+
+	t.Run("allocs", func(t *testing.T) {
+		o := SyncSetOf(3, 2, 1)
+		allocs := testing.AllocsPerRun(10, func() {
+			s.Subset(o)
+			s.Intersects(o)
+			s.Equal(o)
+		})
+		must.Eq(t, 0.0, allocs)
+	})
+}
+
+func TestSyncSet_Intersects(t *testing.T) {
+	a := SyncSetOf(1, 2, 3)
+	must.True(t, a.Intersects(SyncSetOf(3, 4)))
+	must.True(t, a.Intersects(a))
+	must.True(t, a.Disjoint(SyncSetOf(4, 5)))
+}
+
+func TestSyncSet_Algebra(t *testing.T) {
+	a := SyncSetOf(1, 2, 3, 4)
+	b := SyncSetOf(3, 4, 5)
+	must.Eq(t, []int{1, 2, 3, 4, 5}, a.Union(b).SortedSlice(Cmp[int]))
+	must.Eq(t, []int{1, 2}, a.Difference(b).SortedSlice(Cmp[int]))
+	must.Eq(t, []int{3, 4}, a.Intersect(b).SortedSlice(Cmp[int]))
+	must.Eq(t, "[1 2 3 4]", a.String())
+}
+
+func TestSyncSet_Copy(t *testing.T) {
+	a := SyncSetOf(1, 2, 3)
+	b := a.Copy()
+	must.True(t, b.Insert(4))
+	must.False(t, a.Contains(4))
+	must.True(t, b.Contains(4))
+}
+
+func TestSyncSet_UpdateView(t *testing.T) {
+	s := SyncSetOf(1, 2, 3)
+	s.Update(func(u *Set[int]) {
+		if u.Contains(2) {
+			u.Remove(2)
+			u.Insert(20)
+		}
+	})
+	s.View(func(v *Set[int]) {
+		must.True(t, v.EqualSlice([]int{1, 3, 20}))
+	})
+}
+
+func TestSyncSet_JSON(t *testing.T) {
+	a := SyncSetOf(1, 2, 3)
+	bs, err := json.Marshal(a)
+	must.NoError(t, err)
+
+	b := NewSyncSet[int](0)
+	must.NoError(t, json.Unmarshal(bs, b))
+	must.True(t, a.Equal(b))
+
+	t.Run("zero value", func(t *testing.T) {
+		var c struct{ S SyncSet[int] }
+		must.NoError(t, json.Unmarshal([]byte(`{"S":[1,2,3]}`), &c))
+		must.True(t, a.Equal(&c.S))
+
+		bs, err := a.MarshalBinary()
+		must.NoError(t, err)
+		var d SyncSet[int]
+		must.NoError(t, d.UnmarshalBinary(bs))
+		must.True(t, a.Equal(&d))
+	})
+}
+
+func TestSyncSet_concurrent(t *testing.T) {
+	const workers = 8
+	const each = 500
+
+	s := NewSyncSet[int](0)
+	o := NewSyncSet[int](0)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < each; i++ {
+				item := w*each + i
+				s.Insert(item)
+				if i%50 == 0 {
+					o.InsertSet(s)
+					s.Subset(o)
+					o.Equal(s)
+				}
+				_ = s.Contains(item)
+				_ = s.Size()
+				if i%10 == 0 {
+					s.Remove(item)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	o.InsertSet(s)
+	must.Size(t, workers*each*9/10, s)
+	must.True(t, o.Subset(s))
+}
@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"slices"
+)
+
+// TopK keeps the k largest elements of a stream according to a Compare
+// function, using memory proportional to k regardless of the length of the
+// stream. Once k elements are kept, inserting a larger element evicts the
+// smallest element kept.
+//
+// Elements are not de-duplicated; each inserted element competes for a place
+// on its own, so that elements which compare as equal (such as two players
+// with the same score) may all be kept. Between equal elements, the one kept
+// first is preferred.
+//
+// The underlying data structure is a binary min-heap of the kept elements, so
+// Insert takes O(log k) time.
+//
+// Not thread safe, and not safe for concurrent modification.
+type TopK[T any] struct {
+	compare  Compare[T]
+	capacity int
+	heap     []T
+}
+
+// NewTopK creates a TopK that keeps the k largest elements according to
+// compare. A k of zero or less keeps nothing.
+func NewTopK[T any](k int, compare Compare[T]) *TopK[T] {
+	k = max(0, k)
+	return &TopK[T]{
+		compare:  compare,
+		capacity: k,
+		heap:     make([]T, 0, k),
+	}
+}
+
+// NewBottomK creates a TopK that keeps the k smallest elements according to
+// compare, evicting the largest element kept on overflow. A k of zero or less
+// keeps nothing.
+func NewBottomK[T any](k int, compare Compare[T]) *TopK[T] {
+	return NewTopK(k, func(a, b T) int {
+		return compare(b, a)
+	})
+}
+
+// Insert item into t, if it is among the best k elements seen so far.
+//
+// Return true if item was kept, false otherwise.
+func (t *TopK[T]) Insert(item T) bool {
+	if len(t.heap) < t.capacity {
+		t.heap = append(t.heap, item)
+		t.up(len(t.heap) - 1)
+		return true
+	}
+	if t.capacity == 0 || t.compare(item, t.heap[0]) <= 0 {
+		return false
+	}
+	t.heap[0] = item
+	t.down(0)
+	return true
+}
+
+// InsertSlice will insert each item in items into t.
+//
+// Return true if at least one item was kept, false otherwise.
+func (t *TopK[T]) InsertSlice(items []T) bool {
+	modified := false
+	for _, item := range items {
+		if t.Insert(item) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// Threshold returns the element of t that would be evicted next, which an
+// element must beat to be kept once t is full, and true. If t is empty, the
+// zero value and false are returned.
+func (t *TopK[T]) Threshold() (T, bool) {
+	if len(t.heap) == 0 {
+		var zero T
+		return zero, false
+	}
+	return t.heap[0], true
+}
+
+// Contains returns whether an element comparing as equal to item is kept in t.
+func (t *TopK[T]) Contains(item T) bool {
+	return slices.ContainsFunc(t.heap, func(element T) bool {
+		return t.compare(element, item) == 0
+	})
+}
+
+// Size returns the number of elements kept in t.
+func (t *TopK[T]) Size() int {
+	return len(t.heap)
+}
+
+// Empty returns true if t contains no elements, false otherwise.
+func (t *TopK[T]) Empty() bool {
+	return len(t.heap) == 0
+}
+
+// Capacity returns k, the maximum number of elements kept in t.
+func (t *TopK[T]) Capacity() int {
+	return t.capacity
+}
+
+// Full returns whether t holds k elements, so that inserting another evicts one.
+func (t *TopK[T]) Full() bool {
+	return len(t.heap) == t.capacity
+}
+
+// Clear removes every element from t.
+func (t *TopK[T]) Clear() {
+	clear(t.heap)
+	t.heap = t.heap[:0]
+}
+
+// Slice creates a copy of the elements kept in t as a slice, best first; that
+// is, in descending order for a TopK created with NewTopK, and in ascending
+// order for a TopK created with NewBottomK.
+func (t *TopK[T]) Slice() []T {
+	result := slices.Clone(t.heap)
+	slices.SortStableFunc(result, func(a, b T) int {
+		return t.compare(b, a)
+	})
+	return result
+}
+
+// ForEach calls visit for each element kept in t, in no particular order.
+// Iteration stops early if visit returns false.
+func (t *TopK[T]) ForEach(visit func(item T) bool) {
+	for _, item := range t.heap {
+		if !visit(item) {
+			return
+		}
+	}
+}
+
+// String creates a string representation of t, using "%v" printf formatting
+// to transform each element into a string. The result contains elements best
+// first.
+func (t *TopK[T]) String() string {
+	items := t.Slice()
+	l := make([]string, 0, len(items))
+	for _, item := range items {
+		l = append(l, fmt.Sprintf("%v", item))
+	}
+	return fmt.Sprintf("%s", l)
+}
+
+// up restores the heap property by moving the element at i towards the root.
+func (t *TopK[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if t.compare(t.heap[i], t.heap[parent]) >= 0 {
+			return
+		}
+		t.heap[i], t.heap[parent] = t.heap[parent], t.heap[i]
+		i = parent
+	}
+}
+
+// down restores the heap property by moving the element at i away from the root.
+func (t *TopK[T]) down(i int) {
+	n := len(t.heap)
+	for {
+		smallest := i
+		if left := 2*i + 1; left < n && t.compare(t.heap[left], t.heap[smallest]) < 0 {
+			smallest = left
+		}
+		if right := 2*i + 2; right < n && t.compare(t.heap[right], t.heap[smallest]) < 0 {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		t.heap[i], t.heap[smallest] = t.heap[smallest], t.heap[i]
+		i = smallest
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestTopK_Insert(t *testing.T) {
+	top := NewTopK(3, Cmp[int])
+	must.True(t, top.Empty())
+	must.Eq(t, 3, top.Capacity())
+
+	must.True(t, top.Insert(5))
+	must.True(t, top.Insert(1))
+	must.True(t, top.Insert(3))
+	must.True(t, top.Full())
+
+	// too small to be kept
+	must.False(t, top.Insert(0))
+	must.False(t, top.Insert(1))
+
+	// evicts the smallest kept
+	must.True(t, top.Insert(4))
+	must.Eq(t, []int{5, 4, 3}, top.Slice())
+	threshold, ok := top.Threshold()
+	must.True(t, ok)
+	must.Eq(t, 3, threshold)
+
+	must.True(t, top.InsertSlice([]int{9, 2}))
+	must.False(t, top.InsertSlice([]int{2, 3}))
+	must.Eq(t, "[9 5 4]", top.String())
+}
+
+func TestTopK_BottomK(t *testing.T) {
+	bottom := NewBottomK(2, Cmp[string])
+	bottom.InsertSlice([]string{"m", "c", "x", "a", "q"})
+	must.Eq(t, []string{"a", "c"}, bottom.Slice())
+	threshold, _ := bottom.Threshold()
+	must.Eq(t, "c", threshold)
+}
+
+func TestTopK_zero(t *testing.T) {
+	for _, k := range []int{0, -1} {
+		top := NewTopK(k, Cmp[int])
+		must.False(t, top.Insert(1))
+		must.True(t, top.Empty())
+		must.True(t, top.Full())
+		_, ok := top.Threshold()
+		must.False(t, ok)
+	}
+}
+
+func TestTopK_duplicates(t *testing.T) {
+	type player struct {
+		name  string
+		score int
+	}
+	byScore := func(a, b player) int { return Cmp(a.score, b.score) }
+
+	top := NewTopK(3, byScore)
+	top.InsertSlice([]player{{"a", 10}, {"b", 10}, {"c", 10}, {"d", 10}, {"e", 5}})
+	must.Eq(t, 3, top.Size())
+
+	// equal elements compete on their own, and the earliest are preferred
+	names := New[string](3)
+	top.ForEach(func(p player) bool {
+		names.Insert(p.name)
+		return true
+	})
+	must.True(t, names.EqualSlice([]string{"a", "b", "c"}))
+	must.True(t, top.Contains(player{score: 10}))
+	must.False(t, top.Contains(player{score: 5}))
+}
+
+func TestTopK_stream(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	stream := make([]int, 10_000)
+	for i := range stream {
+		stream[i] = rng.Intn(1_000_000)
+	}
+
+	top := NewTopK(25, Cmp[int])
+	top.InsertSlice(stream)
+
+	sorted := slices.Clone(stream)
+	slices.Sort(sorted)
+	slices.Reverse(sorted)
+	must.Eq(t, sorted[:25], top.Slice())
+
+	top.Clear()
+	must.True(t, top.Empty())
+	must.True(t, top.Insert(1))
+}
@@ -0,0 +1,327 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+)
+
+// TreeBag is a sorted multiset, in which each element may be present more than
+// once. Elements that compare as equal are stored together in a single node of
+// an underlying TreeSet, along with their number of occurrences.
+//
+// Because duplicates are counted rather than stored, the occurrences of an
+// element are indistinguishable; the first element inserted is the one that
+// is retained and returned by queries.
+//
+// Not thread safe, and not safe for concurrent modification.
+type TreeBag[T any, C Compare[T]] struct {
+	comparison C
+	tree       *TreeSet[*bagEntry[T], Compare[*bagEntry[T]]]
+	size       int
+}
+
+// bagEntry is an element of a TreeBag along with its number of occurrences.
+type bagEntry[T any] struct {
+	element T
+	count   int
+}
+
+// NewTreeBag creates a TreeBag of type T, comparing elements via C.
+//
+// T may be any type.
+//
+// C is an implementation of Compare[T]. For builtin types, Cmp provides a
+// convenient Compare implementation.
+func NewTreeBag[T any, C Compare[T]](compare C) *TreeBag[T, C] {
+	return &TreeBag[T, C]{
+		comparison: compare,
+		tree: NewTreeSet[*bagEntry[T], Compare[*bagEntry[T]]](func(a, b *bagEntry[T]) int {
+			return compare(a.element, b.element)
+		}),
+	}
+}
+
+// TreeBagFrom creates a new TreeBag containing each item in items. An item
+// that appears in items more than once is counted once per appearance.
+//
+// T may be any type.
+//
+// C is an implementation of Compare[T]. For builtin types, Cmp provides a
+// convenient Compare implementation.
+func TreeBagFrom[T any, C Compare[T]](items []T, compare C) *TreeBag[T, C] {
+	b := NewTreeBag[T](compare)
+	for _, item := range items {
+		b.Add(item)
+	}
+	return b
+}
+
+// entry returns the entry of b for item, or nil if item is not present.
+//
+// The tree is searched by comparing item with the element of each entry
+// directly, rather than creating an entry to look up.
+func (b *TreeBag[T, C]) entry(item T) *bagEntry[T] {
+	n := b.tree.root
+	for n != nil {
+		cmp := b.comparison(n.element.element, item)
+		switch {
+		case cmp < 0:
+			n = n.right
+		case cmp > 0:
+			n = n.left
+		default:
+			return n.element
+		}
+	}
+	return nil
+}
+
+// Add one occurrence of item to b.
+//
+// Returns the number of occurrences of item in b after the call.
+func (b *TreeBag[T, C]) Add(item T) int {
+	return b.AddN(item, 1)
+}
+
+// AddN adds n occurrences of item to b. If n is zero or less, b is not
+// modified.
+//
+// Returns the number of occurrences of item in b after the call.
+func (b *TreeBag[T, C]) AddN(item T, n int) int {
+	e := b.entry(item)
+	if n <= 0 {
+		if e == nil {
+			return 0
+		}
+		return e.count
+	}
+	if e == nil {
+		e = &bagEntry[T]{element: item}
+		b.tree.Insert(e)
+	}
+	e.count += n
+	b.size += n
+	return e.count
+}
+
+// Remove one occurrence of item from b.
+//
+// Return true if b was modified (item was present), false otherwise.
+func (b *TreeBag[T, C]) Remove(item T) bool {
+	return b.RemoveN(item, 1) > 0
+}
+
+// RemoveN removes up to n occurrences of item from b. If n is zero or less, b
+// is not modified.
+//
+// Returns the number of occurrences actually removed.
+func (b *TreeBag[T, C]) RemoveN(item T, n int) int {
+	e := b.entry(item)
+	if e == nil || n <= 0 {
+		return 0
+	}
+	if n >= e.count {
+		b.tree.Remove(e)
+		b.size -= e.count
+		return e.count
+	}
+	e.count -= n
+	b.size -= n
+	return n
+}
+
+// RemoveAll removes every occurrence of item from b.
+//
+// Returns the number of occurrences removed.
+func (b *TreeBag[T, C]) RemoveAll(item T) int {
+	e := b.entry(item)
+	if e == nil {
+		return 0
+	}
+	b.tree.Remove(e)
+	b.size -= e.count
+	return e.count
+}
+
+// Count returns the number of occurrences of item in b.
+func (b *TreeBag[T, C]) Count(item T) int {
+	if e := b.entry(item); e != nil {
+		return e.count
+	}
+	return 0
+}
+
+// Contains returns whether at least one occurrence of item is present in b.
+func (b *TreeBag[T, C]) Contains(item T) bool {
+	return b.entry(item) != nil
+}
+
+// Size returns the total number of occurrences of all elements in b.
+func (b *TreeBag[T, C]) Size() int {
+	return b.size
+}
+
+// DistinctSize returns the number of distinct elements in b.
+func (b *TreeBag[T, C]) DistinctSize() int {
+	return b.tree.Size()
+}
+
+// Empty returns true if b contains no elements, false otherwise.
+func (b *TreeBag[T, C]) Empty() bool {
+	return b.size == 0
+}
+
+// Min returns the smallest item in b.
+//
+// Must not be called on an empty bag.
+func (b *TreeBag[T, C]) Min() T {
+	if b.tree.root == nil {
+		panic("min: bag is empty")
+	}
+	return b.tree.Min().element
+}
+
+// Max returns the largest item in b.
+//
+// Must not be called on an empty bag.
+func (b *TreeBag[T, C]) Max() T {
+	if b.tree.root == nil {
+		panic("max: bag is empty")
+	}
+	return b.tree.Max().element
+}
+
+// Range returns each occurrence of the elements of b that are ≥ lo and < hi,
+// in ascending order.
+func (b *TreeBag[T, C]) Range(lo, hi T) []T {
+	var result []T
+	b.ForEachRange(lo, hi, func(item T, count int) bool {
+		for i := 0; i < count; i++ {
+			result = append(result, item)
+		}
+		return true
+	})
+	return result
+}
+
+// CountRange returns the number of occurrences of the elements of b that are
+// ≥ lo and < hi.
+func (b *TreeBag[T, C]) CountRange(lo, hi T) int {
+	total := 0
+	b.ForEachRange(lo, hi, func(_ T, count int) bool {
+		total += count
+		return true
+	})
+	return total
+}
+
+// ForEachRange calls visit for each distinct element of b that is ≥ lo and
+// < hi along with its number of occurrences, in ascending order. Iteration
+// stops early if visit returns false.
+//
+// Only the parts of the underlying tree that may contain such elements are
+// traversed.
+func (b *TreeBag[T, C]) ForEachRange(lo, hi T, visit func(item T, count int) bool) {
+	b.walkRange(b.tree.root, lo, hi, visit)
+}
+
+func (b *TreeBag[T, C]) walkRange(n *node[*bagEntry[T]], lo, hi T, visit func(T, int) bool) bool {
+	if n == nil {
+		return true
+	}
+	aboveLo := b.comparison(n.element.element, lo) >= 0
+	belowHi := b.comparison(n.element.element, hi) < 0
+	if aboveLo {
+		if !b.walkRange(n.left, lo, hi, visit) {
+			return false
+		}
+	}
+	if aboveLo && belowHi {
+		if !visit(n.element.element, n.element.count) {
+			return false
+		}
+	}
+	if belowHi {
+		return b.walkRange(n.right, lo, hi, visit)
+	}
+	return true
+}
+
+// Copy creates a copy of b.
+func (b *TreeBag[T, C]) Copy() *TreeBag[T, C] {
+	result := NewTreeBag[T](b.comparison)
+	b.ForEachCount(func(item T, count int) bool {
+		result.AddN(item, count)
+		return true
+	})
+	return result
+}
+
+// Equal returns whether b and o contain the same elements with the same counts.
+func (b *TreeBag[T, C]) Equal(o *TreeBag[T, C]) bool {
+	if b.size != o.size || b.DistinctSize() != o.DistinctSize() {
+		return false
+	}
+	equal := true
+	b.ForEachCount(func(item T, count int) bool {
+		equal = o.Count(item) == count
+		return equal
+	})
+	return equal
+}
+
+// Slice creates a copy of b as a slice, in which each element appears once
+// per occurrence. Elements are in ascending order.
+func (b *TreeBag[T, C]) Slice() []T {
+	result := make([]T, 0, b.size)
+	b.ForEach(func(item T) bool {
+		result = append(result, item)
+		return true
+	})
+	return result
+}
+
+// ForEach calls visit once for each occurrence of each element of b, in
+// ascending order. Iteration stops early if visit returns false.
+func (b *TreeBag[T, C]) ForEach(visit func(item T) bool) {
+	b.ForEachCount(func(item T, count int) bool {
+		for i := 0; i < count; i++ {
+			if !visit(item) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// ForEachCount calls visit once for each distinct element of b along with its
+// number of occurrences, in ascending order. Iteration stops early if visit
+// returns false.
+func (b *TreeBag[T, C]) ForEachCount(visit func(item T, count int) bool) {
+	b.tree.ForEach(func(e *bagEntry[T]) bool {
+		return visit(e.element, e.count)
+	})
+}
+
+// String creates a string representation of b, using "%v" printf formatting to
+// transform each element into a string, followed by its count. The result
+// contains elements in ascending order.
+func (b *TreeBag[T, C]) String() string {
+	return b.StringFunc(func(element T) string {
+		return fmt.Sprintf("%v", element)
+	})
+}
+
+// StringFunc creates a string representation of b, using f to transform each
+// element into a string, followed by its count. The result contains elements
+// in ascending order.
+func (b *TreeBag[T, C]) StringFunc(f func(element T) string) string {
+	l := make([]string, 0, b.DistinctSize())
+	b.ForEachCount(func(item T, count int) bool {
+		l = append(l, fmt.Sprintf("%s:%d", f(item), count))
+		return true
+	})
+	return fmt.Sprintf("%s", l)
+}
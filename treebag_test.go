@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestTreeBag_New(t *testing.T) {
+	b := NewTreeBag[int, Compare[int]](Cmp[int])
+	must.True(t, b.Empty())
+	must.Eq(t, 0, b.Size())
+
+	b = TreeBagFrom[int, Compare[int]]([]int{3, 1, 3, 2, 3}, Cmp[int])
+	must.Eq(t, 5, b.Size())
+	must.Eq(t, 3, b.DistinctSize())
+	must.Eq(t, "[1:1 2:1 3:3]", b.String())
+}
+
+func TestTreeBag_Add(t *testing.T) {
+	b := NewTreeBag[string, Compare[string]](Cmp[string])
+	must.Eq(t, 1, b.Add("b"))
+	must.Eq(t, 2, b.Add("b"))
+	must.Eq(t, 4, b.AddN("b", 2))
+	must.Eq(t, 4, b.AddN("b", 0))
+	must.Eq(t, 0, b.AddN("a", -1))
+	must.False(t, b.Contains("a"))
+	must.Eq(t, 1, b.Add("a"))
+	must.Eq(t, 5, b.Size())
+	must.Eq(t, []string{"a", "b", "b", "b", "b"}, b.Slice())
+}
+
+func TestTreeBag_Remove(t *testing.T) {
+	b := TreeBagFrom[int, Compare[int]]([]int{1, 1, 1, 2, 3, 3}, Cmp[int])
+
+	must.True(t, b.Remove(1))
+	must.Eq(t, 2, b.Count(1))
+	must.False(t, b.Remove(9))
+
+	must.Eq(t, 0, b.RemoveN(3, 0))
+	must.Eq(t, 2, b.RemoveN(3, 10))
+	must.False(t, b.Contains(3))
+
+	must.Eq(t, 2, b.RemoveAll(1))
+	must.Eq(t, 0, b.RemoveAll(1))
+	must.Eq(t, 1, b.Size())
+	must.Eq(t, 1, b.DistinctSize())
+
+	must.True(t, b.Remove(2))
+	must.True(t, b.Empty())
+}
+
+func TestTreeBag_allocs(t *testing.T) {
+	b := TreeBagFrom[int, Compare[int]](ints(100), Cmp[int])
+	b.Add(50)
+	allocs := testing.AllocsPerRun(100, func() {
+		b.Contains(50)
+		b.Count(101)
+		b.Add(50)
+		b.Remove(50)
+		b.Remove(101)
+	})
+	must.Eq(t, 0.0, allocs)
+}
+
+func TestTreeBag_MinMax(t *testing.T) {
+	b := TreeBagFrom[int, Compare[int]]([]int{5, 2, 8, 2, 8}, Cmp[int])
+	must.Eq(t, 2, b.Min())
+	must.Eq(t, 8, b.Max())
+
+	// removing one of several duplicates leaves the extreme in place
+	b.Remove(2)
+	must.Eq(t, 2, b.Min())
+	b.Remove(2)
+	must.Eq(t, 5, b.Min())
+
+	t.Run("empty", func(t *testing.T) {
+		defer func() {
+			must.NotNil(t, recover())
+		}()
+		NewTreeBag[int, Compare[int]](Cmp[int]).Min()
+	})
+}
+
+func TestTreeBag_Range(t *testing.T) {
+	b := TreeBagFrom[int, Compare[int]]([]int{1, 2, 2, 3, 4, 4, 4, 5, 6, 7, 8, 9, 10}, Cmp[int])
+
+	must.Eq(t, []int{2, 2, 3, 4, 4, 4}, b.Range(2, 5))
+	must.Eq(t, 6, b.CountRange(2, 5))
+	must.Eq(t, 13, b.CountRange(0, 100))
+	must.SliceEmpty(t, b.Range(5, 5))
+	must.SliceEmpty(t, b.Range(7, 3))
+	must.Eq(t, 0, b.CountRange(11, 20))
+
+	t.Run("stop early", func(t *testing.T) {
+		var visited []int
+		b.ForEachRange(3, 9, func(item, _ int) bool {
+			visited = append(visited, item)
+			return len(visited) < 3
+		})
+		must.Eq(t, []int{3, 4, 5}, visited)
+	})
+
+	t.Run("sliding window", func(t *testing.T) {
+		samples := []int{5, 1, 5, 3, 9, 1, 1, 7}
+		window := NewTreeBag[int, Compare[int]](Cmp[int])
+		var mins, maxs []int
+		for i, sample := range samples {
+			window.Add(sample)
+			if i >= 3 {
+				window.Remove(samples[i-3])
+			}
+			mins = append(mins, window.Min())
+			maxs = append(maxs, window.Max())
+		}
+		must.Eq(t, []int{5, 1, 1, 1, 3, 1, 1, 1}, mins)
+		must.Eq(t, []int{5, 5, 5, 5, 9, 9, 9, 7}, maxs)
+	})
+}
+
+func TestTreeBag_Equal(t *testing.T) {
+	a := TreeBagFrom[int, Compare[int]]([]int{1, 1, 2}, Cmp[int])
+	must.True(t, a.Equal(TreeBagFrom[int, Compare[int]]([]int{2, 1, 1}, Cmp[int])))
+	must.False(t, a.Equal(TreeBagFrom[int, Compare[int]]([]int{1, 2, 2}, Cmp[int])))
+	must.False(t, a.Equal(TreeBagFrom[int, Compare[int]]([]int{1, 2}, Cmp[int])))
+
+	b := a.Copy()
+	must.True(t, a.Equal(b))
+	b.Add(1)
+	must.False(t, a.Equal(b))
+	must.Eq(t, 2, a.Count(1))
+}
+
+func TestTreeBag_ForEach(t *testing.T) {
+	b := TreeBagFrom[int, Compare[int]]([]int{3, 1, 3, 2, 3}, Cmp[int])
+
+	var result []int
+	b.ForEach(func(item int) bool {
+		result = append(result, item)
+		return len(result) < 4
+	})
+	must.Eq(t, []int{1, 2, 3, 3}, result)
+
+	counts := make(map[int]int)
+	b.ForEachCount(func(item, count int) bool {
+		counts[item] = count
+		return true
+	})
+	must.MapEq(t, map[int]int{1: 1, 2: 1, 3: 3}, counts)
+}
+
+func TestTreeBag_comparatorEqual(t *testing.T) {
+	// readings of the same value compare as equal and are counted together,
+	// with the first one inserted retained
+	type reading struct {
+		value  int
+		sensor string
+	}
+	compare := func(a, b reading) int { return Cmp(a.value, b.value) }
+
+	b := NewTreeBag[reading, Compare[reading]](compare)
+	b.Add(reading{value: 1, sensor: "a"})
+	b.Add(reading{value: 1, sensor: "b"})
+	b.Add(reading{value: 2, sensor: "c"})
+	must.Eq(t, 3, b.Size())
+	must.Eq(t, 2, b.DistinctSize())
+	must.Eq(t, 2, b.Count(reading{value: 1}))
+	must.Eq(t, "a", b.Min().sensor)
+}
@@ -4,8 +4,12 @@
 package set
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
 )
 
 // Compare represents a function that compares two elements.
@@ -18,21 +22,19 @@ type Compare[T any] func(T, T) int
 
 // BuiltIn types compatible with Cmp
 type BuiltIn interface {
-	~string | ~int | ~uint | ~int64 | ~uint64 | ~int32 | ~uint32 | ~int16 | ~uint16 | ~int8 | ~uint8
+	~string | ~int | ~uint | ~int64 | ~uint64 | ~int32 | ~uint32 | ~int16 | ~uint16 | ~int8 | ~uint8 |
+		~float32 | ~float64
 }
 
 // Cmp is a Compare function for the specified builtin type B.
 //
-// Common to use with string, int, etc.
+// Common to use with string, int, float64, etc.
+//
+// For floating point types, a NaN is considered less than any non-NaN value,
+// and all NaN values are considered equal to each other. A TreeSet of floats
+// therefore holds at most one NaN, which is its minimum element.
 func Cmp[B BuiltIn](x, y B) int {
-	switch {
-	case x < y:
-		return -1
-	case x > y:
-		return 1
-	default:
-		return 0
-	}
+	return cmp.Compare(x, y)
 }
 
 // TreeSet provides a generic sortable set implementation for Go.
@@ -78,20 +80,52 @@ func TreeSetFrom[T any, C Compare[T]](items []T, compare C) *TreeSet[T, C] {
 	return s
 }
 
+// TreeSetCollect creates a new TreeSet containing each item produced by seq.
+//
+// T may be any type.
+//
+// C is an implementation of Compare[T]. For builtin types, Cmp provides a
+// convenient Compare implementation.
+func TreeSetCollect[T any, C Compare[T]](seq iter.Seq[T], compare C) *TreeSet[T, C] {
+	s := NewTreeSet[T](compare)
+	for item := range seq {
+		s.Insert(item)
+	}
+	return s
+}
+
+// TreeSetFromRange creates a new TreeSet containing the integers from lo up to
+// but not including hi, counting by step. A negative step counts down from lo
+// to hi. Elements are ordered by Cmp regardless of the sign of step.
+//
+// The set is empty if step does not move lo towards hi. TreeSetFromRange
+// panics if step is zero.
+func TreeSetFromRange(lo, hi, step int) *TreeSet[int, Compare[int]] {
+	return TreeSetCollect[int, Compare[int]](intRange(lo, hi, step), Cmp[int])
+}
+
 // Insert item into s.
 //
 // Returns true if s was modified (item was not already in s), false otherwise.
 func (s *TreeSet[T, C]) Insert(item T) bool {
-	return s.insert(&node[T]{
-		element: item,
-		color:   red,
-	})
+	return s.insert(item)
 }
 
+// treeBulkThreshold is the least number of items for which InsertSlice
+// rebuilds the tree rather than inserting each item.
+const treeBulkThreshold = 64
+
 // InsertSlice will insert each item in items into s.
 //
+// A batch of items at least as large as s is sorted and merged with the
+// elements of s into a newly built balanced tree, which is much faster than
+// inserting each item in turn.
+//
 // Return true if s was modified (at least one item was not already in s), false otherwise.
 func (s *TreeSet[T, C]) InsertSlice(items []T) bool {
+	if len(items) >= treeBulkThreshold && len(items) >= s.size {
+		return s.bulkInsert(items)
+	}
 	modified := false
 	for _, item := range items {
 		if s.Insert(item) {
@@ -101,6 +135,13 @@ func (s *TreeSet[T, C]) InsertSlice(items []T) bool {
 	return modified
 }
 
+// InsertMany will insert each item passed as an argument into s.
+//
+// Return true if s was modified (at least one item was not already in s), false otherwise.
+func (s *TreeSet[T, C]) InsertMany(items ...T) bool {
+	return s.InsertSlice(items)
+}
+
 // Remove item from s.
 //
 // Returns true if s was modified (item was in s), false otherwise.
@@ -121,6 +162,39 @@ func (s *TreeSet[T, C]) RemoveSlice(items []T) bool {
 	return modified
 }
 
+// RemoveMany will remove each item passed as an argument from s.
+//
+// Return true if s was modified (any item was in s), false otherwise.
+func (s *TreeSet[T, C]) RemoveMany(items ...T) bool {
+	return s.RemoveSlice(items)
+}
+
+// RemoveFunc will remove each element from s that satisfies condition f.
+//
+// Return true if s was modified, false otherwise.
+func (s *TreeSet[T, C]) RemoveFunc(f func(item T) bool) bool {
+	var remove []T
+	s.infix(func(n *node[T]) bool {
+		if f(n.element) {
+			remove = append(remove, n.element)
+		}
+		return true
+	}, s.root)
+	return s.RemoveSlice(remove)
+}
+
+// Filter returns a new set containing each element of s that satisfies
+// condition f. s is not modified.
+func (s *TreeSet[T, C]) Filter(f func(item T) bool) *TreeSet[T, C] {
+	tree := NewTreeSet[T](s.comparison)
+	s.prefix(func(n *node[T]) {
+		if f(n.element) {
+			tree.Insert(n.element)
+		}
+	}, s.root)
+	return tree
+}
+
 // Min returns the smallest item in the set.
 //
 // Must not be called on an empty set.
@@ -278,6 +352,27 @@ func (s *TreeSet[T, C]) Contains(item T) bool {
 	return s.locate(s.root, item) != nil
 }
 
+// ContainsAll returns whether s contains at least every item in items.
+func (s *TreeSet[T, C]) ContainsAll(items ...T) bool {
+	for _, item := range items {
+		if !s.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny returns whether s contains at least one of the items passed as
+// an argument.
+func (s *TreeSet[T, C]) ContainsAny(items ...T) bool {
+	for _, item := range items {
+		if s.Contains(item) {
+			return true
+		}
+	}
+	return false
+}
+
 // ContainsSlice returns whether s contains the same set of elements that are in
 // items. The items slice may contain duplicate elements.
 //
@@ -292,6 +387,17 @@ func (s *TreeSet[T, C]) ContainsSlice(items []T) bool {
 	return true
 }
 
+// ContainsFunc returns whether s contains at least one element that satisfies
+// condition f. Elements are tested in order.
+func (s *TreeSet[T, C]) ContainsFunc(f func(item T) bool) bool {
+	found := false
+	s.infix(func(n *node[T]) bool {
+		found = f(n.element)
+		return !found
+	}, s.root)
+	return found
+}
+
 // Size returns the number of elements in s.
 func (s *TreeSet[T, C]) Size() int {
 	return s.size
@@ -304,12 +410,30 @@ func (s *TreeSet[T, C]) Empty() bool {
 
 // Slice returns the elements of s as a slice, in order.
 func (s *TreeSet[T, C]) Slice() []T {
-	result := make([]T, 0, s.Size())
+	return s.AppendSorted(make([]T, 0, s.Size()))
+}
+
+// AppendSorted appends the elements of s to dst in order, and returns the
+// extended slice. If dst is already sorted and its elements are less than the
+// minimum of s, the result is sorted.
+//
+// Reusing dst avoids allocating a new slice each time the sorted elements of s
+// are needed, e.g. to be passed to sort.Search or slices.BinarySearchFunc.
+func (s *TreeSet[T, C]) AppendSorted(dst []T) []T {
+	dst = slices.Grow(dst, s.Size())
 	s.infix(func(n *node[T]) bool {
-		result = append(result, n.element)
+		dst = append(dst, n.element)
 		return true
 	}, s.root)
-	return result
+	return dst
+}
+
+// ForEach calls visit for each element of s, in order. Iteration stops early
+// if visit returns false.
+func (s *TreeSet[T, C]) ForEach(visit func(item T) bool) {
+	s.infix(func(n *node[T]) bool {
+		return visit(n.element)
+	}, s.root)
 }
 
 // Subset returns whether o is a subset of s.
@@ -340,13 +464,14 @@ next:
 		nextO := <-iterO
 		for ; idxS < s.Size(); idxS++ {
 			nextS := <-iterS
-			cmp := s.compare(nextS, nextO)
+			cmp := s.comparison(nextS.element, nextO.element)
 			switch {
 			case cmp > 0:
 				return false
 			case cmp < 0:
 				continue
 			default:
+				idxS++
 				continue next
 			}
 		}
@@ -355,6 +480,31 @@ next:
 	return true
 }
 
+// ProperSubset returns whether o is a proper subset of s, that is whether o is
+// a subset of s and s contains at least one element not in o.
+func (s *TreeSet[T, C]) ProperSubset(o *TreeSet[T, C]) bool {
+	if s.Size() <= o.Size() {
+		return false
+	}
+	return s.Subset(o)
+}
+
+// Intersects returns whether s and o have at least one element in common.
+//
+// Returns as soon as a common element is found.
+func (s *TreeSet[T, C]) Intersects(o *TreeSet[T, C]) bool {
+	small, big := s, o
+	if s.Size() > o.Size() {
+		small, big = o, s
+	}
+	return small.ContainsFunc(big.Contains)
+}
+
+// Disjoint returns whether s and o have no elements in common.
+func (s *TreeSet[T, C]) Disjoint(o *TreeSet[T, C]) bool {
+	return !s.Intersects(o)
+}
+
 // Union returns a set that contains all elements of s and o combined.
 func (s *TreeSet[T, C]) Union(o *TreeSet[T, C]) *TreeSet[T, C] {
 	tree := NewTreeSet[T](s.comparison)
@@ -388,6 +538,23 @@ func (s *TreeSet[T, C]) Intersect(o *TreeSet[T, C]) *TreeSet[T, C] {
 	return tree
 }
 
+// SymmetricDifference returns a set that contains elements that are present in
+// either s or o, but not in both.
+func (s *TreeSet[T, C]) SymmetricDifference(o *TreeSet[T, C]) *TreeSet[T, C] {
+	tree := NewTreeSet[T](s.comparison)
+	s.prefix(func(n *node[T]) {
+		if !o.Contains(n.element) {
+			tree.Insert(n.element)
+		}
+	}, s.root)
+	o.prefix(func(n *node[T]) {
+		if !s.Contains(n.element) {
+			tree.Insert(n.element)
+		}
+	}, o.root)
+	return tree
+}
+
 // Copy creates a copy of s.
 //
 // Individual elements are reference copies.
@@ -422,7 +589,7 @@ func (s *TreeSet[T, C]) Equal(o *TreeSet[T, C]) bool {
 	for i := 0; i < s.Size(); i++ {
 		nextS := <-iterS
 		nextO := <-iterO
-		if s.compare(nextS, nextO) != 0 {
+		if s.comparison(nextS.element, nextO.element) != 0 {
 			return false
 		}
 	}
@@ -503,7 +670,7 @@ func (s *TreeSet[T, C]) locate(start *node[T], target T) *node[T] {
 		if n == nil {
 			return nil
 		}
-		cmp := s.compare(n, &node[T]{element: target})
+		cmp := s.comparison(n.element, target)
 		switch {
 		case cmp < 0:
 			n = n.right
@@ -562,16 +729,19 @@ func (s *TreeSet[T, C]) replaceChild(parent, previous, next *node[T]) {
 	}
 }
 
-func (s *TreeSet[T, C]) insert(n *node[T]) bool {
+func (s *TreeSet[T, C]) insert(item T) bool {
 	var (
 		parent *node[T] = nil
 		tmp    *node[T] = s.root
+		cmp    int
 	)
 
+	// find the parent of the new node before creating it, so that inserting
+	// an element already in the tree allocates nothing
 	for tmp != nil {
 		parent = tmp
 
-		cmp := s.compare(n, tmp)
+		cmp = s.comparison(item, tmp.element)
 		switch {
 		case cmp < 0:
 			tmp = tmp.left
@@ -583,92 +753,172 @@ func (s *TreeSet[T, C]) insert(n *node[T]) bool {
 		}
 	}
 
-	n.color = red
+	n := &node[T]{
+		element: item,
+		color:   red,
+		parent:  parent,
+	}
 	switch {
 	case parent == nil:
 		s.root = n
-	case s.compare(n, parent) < 0:
+	case cmp < 0:
 		parent.left = n
 	default:
 		parent.right = n
 	}
-	n.parent = parent
 
 	s.rebalanceInsertion(n)
 	s.size++
+	if debug {
+		s.verify()
+	}
 	return true
 }
 
-func (s *TreeSet[T, C]) rebalanceInsertion(n *node[T]) {
-	parent := n.parent
+// bulkInsert inserts items into s by merging them with the elements of s in
+// sorted order, and replacing the tree of s with a balanced tree of the result.
+// Of equal elements, an element already in s is kept over an item, and an
+// earlier item over a later one, as if each item were inserted in turn.
+func (s *TreeSet[T, C]) bulkInsert(items []T) bool {
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, s.comparison)
 
-	// case 1: parent is nil
-	// - means we are the root
-	// - our color must be black
-	if parent == nil {
-		n.color = black
-		return
+	elements := make([]T, 0, s.size+len(sorted))
+	i := 0
+	s.infix(func(n *node[T]) bool {
+		for ; i < len(sorted); i++ {
+			cmp := s.comparison(sorted[i], n.element)
+			if cmp > 0 {
+				break
+			}
+			if cmp < 0 && (len(elements) == 0 || s.comparison(elements[len(elements)-1], sorted[i]) != 0) {
+				elements = append(elements, sorted[i])
+			}
+		}
+		elements = append(elements, n.element)
+		return true
+	}, s.root)
+	for ; i < len(sorted); i++ {
+		if len(elements) == 0 || s.comparison(elements[len(elements)-1], sorted[i]) != 0 {
+			elements = append(elements, sorted[i])
+		}
 	}
 
-	// if parent is black there is nothing to do
-	if parent.black() {
-		return
+	if len(elements) == s.size {
+		return false
+	}
+	s.root = s.build(elements, nil, 0, redDepth(len(elements)))
+	s.size = len(elements)
+	if debug {
+		s.verify()
 	}
+	return true
+}
 
-	// case 2: no grandparent
-	// - implies the parent is root
-	// - we must now be black
-	grandparent := parent.parent
-	if grandparent == nil {
-		parent.color = black
-		return
+// redDepth returns the depth of the incomplete bottom level of a balanced
+// tree of n nodes, whose nodes are colored red so that every path from the
+// root to a leaf passes through the same number of black nodes.
+func redDepth(n int) int {
+	return bits.Len(uint(n+1)) - 1
+}
+
+// build returns a balanced red-black tree of the sorted elements, whose root
+// is at depth below parent, coloring the nodes at the bottom depth red.
+func (s *TreeSet[T, C]) build(elements []T, parent *node[T], depth, bottom int) *node[T] {
+	if len(elements) == 0 {
+		return nil
+	}
+	m := len(elements) / 2
+	n := &node[T]{
+		element: elements[m],
+		color:   black,
+		parent:  parent,
+	}
+	if depth == bottom {
+		n.color = red
 	}
+	n.left = s.build(elements[:m], n, depth+1, bottom)
+	n.right = s.build(elements[m+1:], n, depth+1, bottom)
+	return n
+}
+
+// rebalanceInsertion restores the red-black properties after n is inserted.
+// Only case 3 moves up the tree, which it does by looping rather than
+// recursing, so that rebalancing uses constant stack space.
+func (s *TreeSet[T, C]) rebalanceInsertion(n *node[T]) {
+	for {
+		parent := n.parent
+
+		// case 1: parent is nil
+		// - means we are the root
+		// - our color must be black
+		if parent == nil {
+			n.color = black
+			return
+		}
 
-	uncle := s.uncleOf(parent)
+		// if parent is black there is nothing to do
+		if parent.black() {
+			return
+		}
 
-	switch {
-	// case 3: uncle is red
-	// - fix color of parent, grandparent, uncle
-	// - recurse upwards as necessary
-	case uncle != nil && uncle.red():
-		parent.color = black
-		grandparent.color = red
-		uncle.color = black
-		s.rebalanceInsertion(grandparent)
-
-	case parent == grandparent.left:
-		// case 4a: uncle is black
-		// + node is left->right child of its grandparent
-		if n == parent.right {
-			s.rotateLeft(parent)
-			parent = n // recolor in case 5a
-		}
-
-		// case 5a: uncle is black
-		// + node is left->left child of its grandparent
-		s.rotateRight(grandparent)
-
-		// fix color of original parent and grandparent
-		parent.color = black
-		grandparent.color = red
-
-		// parent is right child of grandparent
-	default:
-		// case 4b: uncle is black
-		// + node is right->left child of its grandparent
-		if n == parent.left {
-			s.rotateRight(parent)
-			// points to root of rotated sub tree
-			parent = n // recolor in case 5b
+		// case 2: no grandparent
+		// - implies the parent is root
+		// - we must now be black
+		grandparent := parent.parent
+		if grandparent == nil {
+			parent.color = black
+			return
 		}
 
-		// case 5b: uncle is black
-		// + node is right->right child of its grandparent
-		s.rotateLeft(grandparent)
+		uncle := s.uncleOf(parent)
+
+		switch {
+		// case 3: uncle is red
+		// - fix color of parent, grandparent, uncle
+		// - continue upwards from the grandparent
+		case uncle != nil && uncle.red():
+			parent.color = black
+			grandparent.color = red
+			uncle.color = black
+			n = grandparent
+			continue
+
+		case parent == grandparent.left:
+			// case 4a: uncle is black
+			// + node is left->right child of its grandparent
+			if n == parent.right {
+				s.rotateLeft(parent)
+				parent = n // recolor in case 5a
+			}
+
+			// case 5a: uncle is black
+			// + node is left->left child of its grandparent
+			s.rotateRight(grandparent)
+
+			// fix color of original parent and grandparent
+			parent.color = black
+			grandparent.color = red
+
+			// parent is right child of grandparent
+		default:
+			// case 4b: uncle is black
+			// + node is right->left child of its grandparent
+			if n == parent.left {
+				s.rotateRight(parent)
+				// points to root of rotated sub tree
+				parent = n // recolor in case 5b
+			}
+
+			// case 5b: uncle is black
+			// + node is right->right child of its grandparent
+			s.rotateLeft(grandparent)
 
-		// fix color of original parent and grandparent
-		parent.color = black
-		grandparent.color = red
+			// fix color of original parent and grandparent
+			parent.color = black
+			grandparent.color = red
+		}
+		return
 	}
 }
 
@@ -717,6 +967,9 @@ func (s *TreeSet[T, C]) delete(element T) bool {
 	s.marker.left = nil
 	s.marker.right = nil
 	s.marker.parent = nil
+	if debug {
+		s.verify()
+	}
 	return true
 }
 
@@ -745,35 +998,42 @@ func (s *TreeSet[T, C]) delete01(n *node[T]) *node[T] {
 	}
 }
 
+// rebalanceDeletion restores the red-black properties after a black node is
+// removed above n. Only a black sibling with two black children and a black
+// parent moves up the tree, which it does by looping rather than recursing.
 func (s *TreeSet[T, C]) rebalanceDeletion(n *node[T]) {
-	// base case: node is root
-	if n == s.root {
-		n.color = black
-		return
-	}
+	for {
+		// base case: node is root
+		if n == s.root {
+			n.color = black
+			return
+		}
 
-	sibling := s.siblingOf(n)
+		sibling := s.siblingOf(n)
 
-	// case: sibling is red
-	if sibling.red() {
-		s.fixRedSibling(n, sibling)
-		sibling = s.siblingOf(n)
-	}
+		// case: sibling is red
+		if sibling.red() {
+			s.fixRedSibling(n, sibling)
+			sibling = s.siblingOf(n)
+		}
+
+		// case: black sibling with at least one red child
+		if !sibling.left.black() || !sibling.right.black() {
+			s.fixBlackSibling(n, sibling)
+			return
+		}
 
-	// case: black sibling with two black children
-	if sibling.left.black() && sibling.right.black() {
+		// case: black sibling with two black children
 		sibling.color = red
 
 		// case: black sibling with to black children and a red parent
 		if n.parent.red() {
 			n.parent.color = black
-		} else {
-			// case: black sibling with two black children and black parent
-			s.rebalanceDeletion(n.parent)
+			return
 		}
-	} else {
-		// case: black sibling with at least one red child
-		s.fixBlackSibling(n, sibling)
+
+		// case: black sibling with two black children and black parent
+		n = n.parent
 	}
 }
 
@@ -853,19 +1113,17 @@ func (s *TreeSet[T, C]) max(n *node[T]) *node[T] {
 	return n
 }
 
-func (s *TreeSet[T, C]) compare(a, b *node[T]) int {
-	return s.comparison(a.element, b.element)
-}
-
-func (s *TreeSet[T, C]) infix(visit func(*node[T]) (next bool), n *node[T]) {
+func (s *TreeSet[T, C]) infix(visit func(*node[T]) (next bool), n *node[T]) bool {
 	if n == nil {
-		return
+		return true
+	}
+	if !s.infix(visit, n.left) {
+		return false
 	}
-	s.infix(visit, n.left)
 	if !visit(n) {
-		return
+		return false
 	}
-	s.infix(visit, n.right)
+	return s.infix(visit, n.right)
 }
 
 func (s *TreeSet[T, C]) fillLeft(n *node[T], k *[]T) {
@@ -943,6 +1201,17 @@ func (s *TreeSet[T, C]) UnmarshalJSON(data []byte) error {
 	return unmarshalJSON[T](s, data)
 }
 
+// MarshalBinary implements the encoding.BinaryMarshaler interface. Elements
+// are encoded in order.
+func (s *TreeSet[T, C]) MarshalBinary() ([]byte, error) {
+	return marshalBinary[T](s)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *TreeSet[T, C]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinary[T](s, data)
+}
+
 func (s *TreeSet[T, C]) filterLeft(n *node[T], accept func(element T) bool, result *TreeSet[T, C]) {
 	if n == nil {
 		return
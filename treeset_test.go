@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"github.com/shoenig/test/must"
 	"go.uber.org/goleak"
+	"math"
 	"math/rand"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -40,6 +42,38 @@ var (
 	tokenH = &token{id: "H"}
 )
 
+func TestCmp(t *testing.T) {
+	t.Run("ints", func(t *testing.T) {
+		must.Negative(t, Cmp(1, 2))
+		must.Positive(t, Cmp(2, 1))
+		must.Zero(t, Cmp(2, 2))
+	})
+
+	t.Run("floats", func(t *testing.T) {
+		must.Negative(t, Cmp(1.5, 2.5))
+		must.Positive(t, Cmp(float32(2.5), float32(1.5)))
+		must.Zero(t, Cmp(0.0, math.Copysign(0, -1)))
+		must.Negative(t, Cmp(math.Inf(-1), math.MaxFloat64))
+	})
+
+	t.Run("nan", func(t *testing.T) {
+		nan := math.NaN()
+		must.Zero(t, Cmp(nan, nan))
+		must.Negative(t, Cmp(nan, math.Inf(-1)))
+		must.Positive(t, Cmp(0, nan))
+	})
+
+	t.Run("treeset", func(t *testing.T) {
+		ts := TreeSetFrom[float64, Compare[float64]]([]float64{
+			2.5, math.NaN(), -1, math.Inf(1), math.NaN(), 0,
+		}, Cmp[float64])
+		must.Size(t, 5, ts)
+		must.True(t, math.IsNaN(ts.Min()))
+		must.Eq(t, math.Inf(1), ts.Max())
+		must.Eq(t, []float64{-1, 0, 2.5, math.Inf(1)}, ts.Slice()[1:])
+	})
+}
+
 func TestNewTreeSet(t *testing.T) {
 	ts := NewTreeSet[*token, Compare[*token]](compareTokens)
 	must.NotNil(t, ts)
@@ -52,6 +86,12 @@ func TestTreeSetFrom(t *testing.T) {
 	must.NotEmpty(t, ts)
 }
 
+func TestTreeSetCollect(t *testing.T) {
+	ts := TreeSetCollect[int, Compare[int]](slices.Values(shuffle(ints(10))), Cmp[int])
+	must.Eq(t, ints(10), ts.Slice())
+	invariants(t, ts, Cmp[int])
+}
+
 func TestTreeSet_Empty(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		ts := NewTreeSet[int, Compare[int]](Cmp[int])
@@ -150,6 +190,86 @@ func TestTreeSet_InsertSlice(t *testing.T) {
 	must.False(t, ts.InsertSlice(numbers))
 }
 
+func TestTreeSet_InsertSlice_bulk(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for _, existing := range []int{0, 1, 10, 100, 500} {
+		for _, batch := range []int{treeBulkThreshold, 100, 255, 256, 1000} {
+			ts := NewTreeSet[int, Compare[int]](Cmp[int])
+			exp := New[int](0)
+			for range existing {
+				x := rng.Intn(2000)
+				ts.Insert(x)
+				exp.Insert(x)
+			}
+			items := make([]int, batch)
+			for i := range items {
+				items[i] = rng.Intn(2000)
+			}
+			ts.verify()
+
+			must.Eq(t, exp.InsertSlice(items), ts.InsertSlice(items))
+			ts.verify()
+			must.Eq(t, exp.SortedSlice(Cmp[int]), ts.Slice())
+
+			// the rebuilt tree remains valid under modification
+			for _, item := range items[:batch/2] {
+				ts.Remove(item)
+				ts.Insert(item + 2000)
+			}
+			ts.verify()
+		}
+	}
+
+	t.Run("keeps existing", func(t *testing.T) {
+		type entry struct{ key, value int }
+		byKey := func(a, b entry) int { return Cmp(a.key, b.key) }
+		ts := TreeSetFrom[entry, Compare[entry]]([]entry{{1, 1}}, byKey)
+
+		batch := make([]entry, treeBulkThreshold)
+		for i := range batch {
+			batch[i] = entry{key: i % 4, value: i + 10}
+		}
+		must.True(t, ts.InsertSlice(batch))
+		must.Eq(t, []entry{{0, 10}, {1, 1}, {2, 12}, {3, 13}}, ts.Slice())
+		must.False(t, ts.InsertSlice(batch))
+	})
+}
+
+func TestTreeSetFromRange(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		ts := TreeSetFromRange(8000, 8010, 2)
+		must.Eq(t, []int{8000, 8002, 8004, 8006, 8008}, ts.Slice())
+		invariants(t, ts, Cmp[int])
+	})
+
+	t.Run("down", func(t *testing.T) {
+		ts := TreeSetFromRange(5, 0, -1)
+		must.Eq(t, []int{1, 2, 3, 4, 5}, ts.Slice())
+		invariants(t, ts, Cmp[int])
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		ts := TreeSetFromRange(0, 5, -1)
+		must.Empty(t, ts)
+	})
+
+	t.Run("zero step", func(t *testing.T) {
+		defer func() {
+			must.NotNil(t, recover())
+		}()
+		TreeSetFromRange(0, 10, 0)
+	})
+}
+
+func TestTreeSet_InsertMany(t *testing.T) {
+	ts := NewTreeSet[int, Compare[int]](Cmp[int])
+	must.False(t, ts.InsertMany())
+	must.True(t, ts.InsertMany(5, 3, 1, 4, 2))
+	must.Eq(t, []int{1, 2, 3, 4, 5}, ts.Slice())
+	must.False(t, ts.InsertMany(1, 2, 3))
+	invariants(t, ts, Cmp[int])
+}
+
 func TestTreeSet_Remove_int(t *testing.T) {
 	cmp := Cmp[int]
 	ts := NewTreeSet[int, Compare[int]](cmp)
@@ -192,6 +312,35 @@ func TestTreeSet_RemoveSlice(t *testing.T) {
 	must.Empty(t, ts)
 }
 
+func TestTreeSet_RemoveMany(t *testing.T) {
+	ts := TreeSetFrom[int, Compare[int]](ints(10), Cmp[int])
+	must.False(t, ts.RemoveMany())
+	must.True(t, ts.RemoveMany(2, 4, 6, 8, 10, 12))
+	must.Eq(t, []int{1, 3, 5, 7, 9}, ts.Slice())
+	must.False(t, ts.RemoveMany(2, 4))
+	invariants(t, ts, Cmp[int])
+}
+
+func TestTreeSet_RemoveFunc(t *testing.T) {
+	ts := TreeSetFrom[int, Compare[int]](shuffle(ints(100)), Cmp[int])
+	must.False(t, ts.RemoveFunc(func(i int) bool { return i > 100 }))
+	must.True(t, ts.RemoveFunc(func(i int) bool { return i%3 != 0 }))
+	must.Size(t, 33, ts)
+	must.Eq(t, 3, ts.Min())
+	must.Eq(t, 99, ts.Max())
+	invariants(t, ts, Cmp[int])
+}
+
+func TestTreeSet_Filter(t *testing.T) {
+	ts := TreeSetFrom[int, Compare[int]](ints(20), Cmp[int])
+	result := ts.Filter(func(i int) bool { return i%5 == 0 })
+	must.Eq(t, []int{5, 10, 15, 20}, result.Slice())
+	must.Size(t, 20, ts)
+	invariants(t, result, Cmp[int])
+
+	must.Empty(t, ts.Filter(func(int) bool { return false }))
+}
+
 func TestTreeSet_Contains(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		ts := NewTreeSet[int, Compare[int]](Cmp[int])
@@ -212,6 +361,34 @@ func TestTreeSet_Contains(t *testing.T) {
 		must.NotContains[int](t, 0, ts)
 		must.NotContains[int](t, 6, ts)
 	})
+
+	t.Run("allocs", func(t *testing.T) {
+		ts := TreeSetFrom[int, Compare[int]](ints(100), Cmp[int])
+		allocs := testing.AllocsPerRun(100, func() {
+			ts.Contains(50)
+			ts.Contains(500)
+			ts.Insert(50)
+		})
+		must.Eq(t, 0.0, allocs)
+	})
+}
+
+func TestTreeSet_ContainsAll(t *testing.T) {
+	ts := TreeSetFrom[int, Compare[int]](ints(10), Cmp[int])
+	must.True(t, ts.ContainsAll())
+	must.True(t, ts.ContainsAll(1, 5, 10))
+	must.False(t, ts.ContainsAll(1, 5, 11))
+	must.False(t, ts.ContainsAll(ints(11)...))
+
+	one := TreeSetFrom[int, Compare[int]]([]int{1}, Cmp[int])
+	must.True(t, one.ContainsAll(1, 1))
+}
+
+func TestTreeSet_ContainsAny(t *testing.T) {
+	ts := TreeSetFrom[int, Compare[int]](ints(10), Cmp[int])
+	must.False(t, ts.ContainsAny())
+	must.True(t, ts.ContainsAny(20, 15, 10))
+	must.False(t, ts.ContainsAny(0, 11, 12))
 }
 
 func TestTreeSet_ContainsSlice(t *testing.T) {
@@ -233,6 +410,28 @@ func TestTreeSet_ContainsSlice(t *testing.T) {
 	})
 }
 
+func TestTreeSet_ContainsFunc(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		ts := NewTreeSet[int, Compare[int]](Cmp[int])
+		must.False(t, ts.ContainsFunc(func(int) bool { return true }))
+	})
+
+	t.Run("match stops early", func(t *testing.T) {
+		ts := TreeSetFrom[int, Compare[int]](shuffle(ints(100)), Cmp[int])
+		visited := 0
+		must.True(t, ts.ContainsFunc(func(i int) bool {
+			visited++
+			return i%7 == 0
+		}))
+		must.Eq(t, 7, visited)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		ts := TreeSetFrom[int, Compare[int]](ints(10), Cmp[int])
+		must.False(t, ts.ContainsFunc(func(i int) bool { return i > 10 }))
+	})
+}
+
 func TestTreeSet_Subset(t *testing.T) {
 	t.Run("empty empty", func(t *testing.T) {
 		t1 := NewTreeSet[int, Compare[int]](Cmp[int])
@@ -265,6 +464,12 @@ func TestTreeSet_Subset(t *testing.T) {
 		must.False(t, t1.Subset(t2))
 	})
 
+	t.Run("exhaust s", func(t *testing.T) {
+		t1 := TreeSetFrom[int, Compare[int]]([]int{1, 2, 3}, Cmp[int])
+		t2 := TreeSetFrom[int, Compare[int]]([]int{3, 4}, Cmp[int])
+		must.False(t, t1.Subset(t2))
+	})
+
 	t.Run("superset", func(t *testing.T) {
 		t1 := TreeSetFrom[int, Compare[int]]([]int{9, 7, 8, 5, 4, 2, 1, 3}, Cmp[int])
 		t2 := TreeSetFrom[int, Compare[int]]([]int{5, 1, 2, 8, 3}, Cmp[int])
@@ -277,6 +482,40 @@ func TestTreeSet_Subset(t *testing.T) {
 	})
 }
 
+func TestTreeSet_ProperSubset(t *testing.T) {
+	from := func(items ...int) *TreeSet[int, Compare[int]] {
+		return TreeSetFrom[int, Compare[int]](items, Cmp[int])
+	}
+	must.False(t, from().ProperSubset(from()))
+	must.True(t, from(1).ProperSubset(from()))
+	must.False(t, from(1, 2, 3).ProperSubset(from(3, 2, 1)))
+	must.True(t, from(1, 2, 3).ProperSubset(from(3, 1)))
+	must.False(t, from(1, 2, 3).ProperSubset(from(3, 4)))
+}
+
+func TestTreeSet_Intersects(t *testing.T) {
+	t.Run("empty some", func(t *testing.T) {
+		a := NewTreeSet[int, Compare[int]](Cmp[int])
+		b := TreeSetFrom[int, Compare[int]](ints(3), Cmp[int])
+		must.False(t, a.Intersects(b))
+		must.True(t, b.Disjoint(a))
+	})
+
+	t.Run("common", func(t *testing.T) {
+		a := TreeSetFrom[int, Compare[int]](ints(100), Cmp[int])
+		b := TreeSetFrom[int, Compare[int]]([]int{300, 200, 100}, Cmp[int])
+		must.True(t, a.Intersects(b))
+		must.False(t, b.Disjoint(a))
+	})
+
+	t.Run("nothing common", func(t *testing.T) {
+		a := TreeSetFrom[int, Compare[int]](ints(100), Cmp[int])
+		b := TreeSetFrom[int, Compare[int]]([]int{0, -1, 101}, Cmp[int])
+		must.False(t, a.Intersects(b))
+		must.True(t, b.Disjoint(a))
+	})
+}
+
 func TestTreeSet_Union(t *testing.T) {
 	t.Run("empty empty", func(t *testing.T) {
 		t1 := TreeSetFrom[int, Compare[int]](nil, Cmp[int])
@@ -388,6 +627,29 @@ func TestTreeSet_Intersect(t *testing.T) {
 	})
 }
 
+func TestTreeSet_SymmetricDifference(t *testing.T) {
+	t.Run("empty empty", func(t *testing.T) {
+		t1 := TreeSetFrom[int, Compare[int]](nil, Cmp[int])
+		t2 := TreeSetFrom[int, Compare[int]](nil, Cmp[int])
+		must.Empty(t, t1.SymmetricDifference(t2))
+	})
+
+	t.Run("full empty", func(t *testing.T) {
+		t1 := TreeSetFrom[int, Compare[int]]([]int{3, 1, 2}, Cmp[int])
+		t2 := TreeSetFrom[int, Compare[int]](nil, Cmp[int])
+		must.Eq(t, []int{1, 2, 3}, t1.SymmetricDifference(t2).Slice())
+		must.Eq(t, []int{1, 2, 3}, t2.SymmetricDifference(t1).Slice())
+	})
+
+	t.Run("overlap", func(t *testing.T) {
+		t1 := TreeSetFrom[int, Compare[int]]([]int{1, 2, 3, 4, 5, 6}, Cmp[int])
+		t2 := TreeSetFrom[int, Compare[int]]([]int{0, 4, 5, 7}, Cmp[int])
+		result := t1.SymmetricDifference(t2)
+		must.Eq(t, []int{0, 1, 2, 3, 6, 7}, result.Slice())
+		invariants(t, result, Cmp[int])
+	})
+}
+
 func TestTreeSet_Copy(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		t1 := NewTreeSet[int, Compare[int]](Cmp[int])
@@ -738,6 +1000,55 @@ func TestTreeSet_Slice(t *testing.T) {
 	})
 }
 
+func TestTreeSet_AppendSorted(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		ts := NewTreeSet[int, Compare[int]](Cmp[int])
+		must.SliceEmpty(t, ts.AppendSorted(nil))
+		must.Eq(t, []int{1}, ts.AppendSorted([]int{1}))
+	})
+
+	t.Run("append", func(t *testing.T) {
+		ts := TreeSetFrom[int, Compare[int]]([]int{9, 7, 8}, Cmp[int])
+		result := ts.AppendSorted([]int{1, 2})
+		must.Eq(t, []int{1, 2, 7, 8, 9}, result)
+		must.True(t, slices.IsSorted(result))
+	})
+
+	t.Run("reuse", func(t *testing.T) {
+		ts := TreeSetFrom[int, Compare[int]](shuffle(ints(100)), Cmp[int])
+		buf := make([]int, 0, 100)
+		result := ts.AppendSorted(buf[:0])
+		must.Eq(t, ints(100), result)
+		must.Eq(t, &buf[:1][0], &result[0])
+
+		i, found := slices.BinarySearch(result, 42)
+		must.True(t, found)
+		must.Eq(t, 41, i)
+	})
+}
+
+func TestTreeSet_ForEach(t *testing.T) {
+	t.Run("in order", func(t *testing.T) {
+		ts := TreeSetFrom[int, Compare[int]]([]int{5, 3, 4, 1}, Cmp[int])
+		result := make([]int, 0, 4)
+		ts.ForEach(func(i int) bool {
+			result = append(result, i)
+			return true
+		})
+		must.Eq(t, []int{1, 3, 4, 5}, result)
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		ts := TreeSetFrom[int, Compare[int]]([]int{5, 3, 4, 1}, Cmp[int])
+		result := make([]int, 0, 4)
+		ts.ForEach(func(i int) bool {
+			result = append(result, i)
+			return i != 3
+		})
+		must.Eq(t, []int{1, 3}, result)
+	})
+}
+
 func TestTreeSet_String(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		ts := NewTreeSet[int, Compare[int]](Cmp[int])
@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"sync"
+)
+
+// VersionedSet is a thread-safe set from which cheap, immutable, point-in-time
+// snapshots can be taken while modification continues. Snapshot takes O(1)
+// time and copies nothing; a snapshot is unaffected by later modifications,
+// so it may be read at leisure, such as during a long export, without holding
+// up writers.
+//
+// The elements are stored in a PersistentSet, so each modification creates a
+// new version of the set sharing all but O(log n) of its storage with the
+// previous version, and with any snapshots of it.
+//
+// A VersionedSet must not be copied after first use.
+type VersionedSet[T comparable] struct {
+	lock    sync.RWMutex
+	set     *PersistentSet[T]
+	version uint64
+}
+
+// Snapshot is an immutable point-in-time view of a VersionedSet, which may be
+// shared between goroutines without locking.
+type Snapshot[T comparable] struct {
+	*PersistentSet[T]
+	version uint64
+}
+
+// Version returns the version of the VersionedSet that s was taken from.
+func (s *Snapshot[T]) Version() uint64 {
+	return s.version
+}
+
+// NewVersionedSet creates a new empty VersionedSet, at version zero.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func NewVersionedSet[T comparable]() *VersionedSet[T] {
+	return &VersionedSet[T]{
+		set: NewPersistentSet[T](),
+	}
+}
+
+// VersionedSetFrom creates a new VersionedSet containing each item in items,
+// at version zero.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func VersionedSetFrom[T comparable](items []T) *VersionedSet[T] {
+	return &VersionedSet[T]{
+		set: PersistentSetFrom(items),
+	}
+}
+
+// update replaces the current version of s with the result of f, and advances
+// the version of s if the result differs.
+func (s *VersionedSet[T]) update(f func(*PersistentSet[T]) *PersistentSet[T]) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	next := f(s.set)
+	if next == s.set {
+		return false
+	}
+	s.set = next
+	s.version++
+	return true
+}
+
+// current returns the current version of the elements of s.
+func (s *VersionedSet[T]) current() *PersistentSet[T] {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.set
+}
+
+// Insert item into s.
+//
+// Return true if s was modified (item was not already in s), false otherwise.
+func (s *VersionedSet[T]) Insert(item T) bool {
+	return s.update(func(p *PersistentSet[T]) *PersistentSet[T] {
+		return p.Insert(item)
+	})
+}
+
+// InsertSlice will insert each item in items into s, as a single new version.
+//
+// Return true if s was modified (at least one item was not already in s), false otherwise.
+func (s *VersionedSet[T]) InsertSlice(items []T) bool {
+	return s.update(func(p *PersistentSet[T]) *PersistentSet[T] {
+		return p.InsertSlice(items)
+	})
+}
+
+// Remove item from s.
+//
+// Return true if s was modified (item was present), false otherwise.
+func (s *VersionedSet[T]) Remove(item T) bool {
+	return s.update(func(p *PersistentSet[T]) *PersistentSet[T] {
+		return p.Remove(item)
+	})
+}
+
+// RemoveSlice will remove each item in items from s, as a single new version.
+//
+// Return true if s was modified (any item was present), false otherwise.
+func (s *VersionedSet[T]) RemoveSlice(items []T) bool {
+	return s.update(func(p *PersistentSet[T]) *PersistentSet[T] {
+		return p.RemoveSlice(items)
+	})
+}
+
+// Snapshot returns an immutable view of the elements of s as of now. Later
+// modifications of s are not visible through the snapshot.
+func (s *VersionedSet[T]) Snapshot() *Snapshot[T] {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return &Snapshot[T]{PersistentSet: s.set, version: s.version}
+}
+
+// Version returns the current version of s, which is advanced by every
+// modification of s.
+func (s *VersionedSet[T]) Version() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.version
+}
+
+// Contains returns whether item is present in s.
+func (s *VersionedSet[T]) Contains(item T) bool {
+	return s.current().Contains(item)
+}
+
+// Size returns the cardinality of s.
+func (s *VersionedSet[T]) Size() int {
+	return s.current().Size()
+}
+
+// Empty returns true if s contains no elements, false otherwise.
+func (s *VersionedSet[T]) Empty() bool {
+	return s.current().Empty()
+}
+
+// Slice creates a copy of s as a slice. Elements are in no particular order.
+func (s *VersionedSet[T]) Slice() []T {
+	return s.current().Slice()
+}
+
+// ForEach calls visit for each element of a snapshot of s, in no particular
+// order. Modifications of s made during iteration, including by visit, are
+// not visited. Iteration stops early if visit returns false.
+func (s *VersionedSet[T]) ForEach(visit func(item T) bool) {
+	s.current().ForEach(visit)
+}
+
+// String creates a string representation of s, using "%v" printf formatting to transform
+// each element into a string. The result contains elements sorted by their lexical
+// string order.
+func (s *VersionedSet[T]) String() string {
+	return s.current().String()
+}
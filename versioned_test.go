@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestVersionedSet_Insert(t *testing.T) {
+	s := NewVersionedSet[int]()
+	must.True(t, s.Empty())
+	must.Eq(t, 0, s.Version())
+
+	must.True(t, s.Insert(1))
+	must.False(t, s.Insert(1))
+	must.Eq(t, 1, s.Version())
+
+	// a slice is a single version
+	must.True(t, s.InsertSlice([]int{2, 3, 4}))
+	must.False(t, s.InsertSlice([]int{2, 3}))
+	must.Eq(t, 2, s.Version())
+
+	must.True(t, s.Remove(4))
+	must.False(t, s.Remove(4))
+	must.True(t, s.RemoveSlice([]int{3, 5}))
+	must.False(t, s.RemoveSlice([]int{3, 5}))
+	must.Eq(t, 4, s.Version())
+
+	must.Eq(t, 2, s.Size())
+	must.True(t, s.Contains(2))
+	must.False(t, s.Contains(3))
+	must.Eq(t, "[1 2]", s.String())
+}
+
+func TestVersionedSet_Snapshot(t *testing.T) {
+	s := VersionedSetFrom(ints(3))
+	before := s.Snapshot()
+	must.Eq(t, 0, before.Version())
+
+	s.Insert(4)
+	s.Remove(1)
+	after := s.Snapshot()
+
+	must.Eq(t, "[1 2 3]", before.String())
+	must.Eq(t, 3, before.Size())
+	must.Eq(t, "[2 3 4]", after.String())
+	must.Eq(t, 2, after.Version())
+	must.True(t, before.Difference(after.PersistentSet).Equal(PersistentSetOf(1)))
+}
+
+func TestVersionedSet_ForEach(t *testing.T) {
+	s := VersionedSetFrom(ints(10))
+
+	// modifications made while iterating are not visited
+	visited := 0
+	s.ForEach(func(i int) bool {
+		s.Insert(i + 100)
+		visited++
+		return true
+	})
+	must.Eq(t, 10, visited)
+	must.Eq(t, 20, s.Size())
+	must.SliceLen(t, 20, s.Slice())
+}
+
+func TestVersionedSet_concurrent(t *testing.T) {
+	s := NewVersionedSet[int]()
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 250 {
+				s.Insert(w*1000 + i)
+			}
+		}()
+	}
+
+	// snapshots taken while writers run are internally consistent
+	for range 50 {
+		snap := s.Snapshot()
+		must.Eq(t, snap.Size(), len(snap.Slice()))
+	}
+	wg.Wait()
+
+	must.Eq(t, 1000, s.Size())
+	must.Eq(t, 1000, s.Version())
+}
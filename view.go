@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+// viewOp is the set operation combining the operands of a View.
+type viewOp int
+
+const (
+	viewUnion viewOp = iota
+	viewIntersect
+	viewDifference
+)
+
+// View is a lazily evaluated combination of two sets, such as their union.
+// A View does not copy or materialize its operands; each operation on the
+// View is answered by delegating to them, so modifications to the operands
+// are visible through the View.
+//
+// Contains is as fast as Contains on the operands. Size, Slice and ForEach
+// visit elements of the operands, checking each against the other operand,
+// so an operation on a View takes time proportional to the size of its
+// operands rather than the size of the result. Materialize a View into a Set
+// if it is read many times.
+//
+// Views are ReadOnly, and so may themselves be operands of other views.
+type View[T any] struct {
+	op   viewOp
+	a, b ReadOnly[T]
+}
+
+// UnionView creates a View of the elements present in a or b.
+func UnionView[T any](a, b ReadOnly[T]) *View[T] {
+	return &View[T]{op: viewUnion, a: a, b: b}
+}
+
+// IntersectView creates a View of the elements present in both a and b.
+func IntersectView[T any](a, b ReadOnly[T]) *View[T] {
+	return &View[T]{op: viewIntersect, a: a, b: b}
+}
+
+// DifferenceView creates a View of the elements present in a but not in b.
+func DifferenceView[T any](a, b ReadOnly[T]) *View[T] {
+	return &View[T]{op: viewDifference, a: a, b: b}
+}
+
+// Contains returns whether item is present in v.
+func (v *View[T]) Contains(item T) bool {
+	switch v.op {
+	case viewUnion:
+		return v.a.Contains(item) || v.b.Contains(item)
+	case viewIntersect:
+		return v.a.Contains(item) && v.b.Contains(item)
+	default:
+		return v.a.Contains(item) && !v.b.Contains(item)
+	}
+}
+
+// Size returns the cardinality of v, which is computed by visiting the
+// elements of the operands.
+func (v *View[T]) Size() int {
+	if v.op == viewUnion {
+		// the elements of a, and those of b not already counted
+		return v.a.Size() + DifferenceView(v.b, v.a).Size()
+	}
+	size := 0
+	v.ForEach(func(T) bool {
+		size++
+		return true
+	})
+	return size
+}
+
+// Empty returns true if v contains no elements, false otherwise. Iteration
+// stops at the first element of v.
+func (v *View[T]) Empty() bool {
+	if v.op == viewUnion {
+		return v.a.Empty() && v.b.Empty()
+	}
+	empty := true
+	v.ForEach(func(T) bool {
+		empty = false
+		return false
+	})
+	return empty
+}
+
+// Slice creates a copy of v as a slice, in the order in which ForEach visits
+// elements.
+func (v *View[T]) Slice() []T {
+	result := make([]T, 0)
+	v.ForEach(func(item T) bool {
+		result = append(result, item)
+		return true
+	})
+	return result
+}
+
+// ForEach calls visit for each element of v. Iteration stops early if visit
+// returns false.
+//
+// The elements of a union are visited in the order of a, followed by the
+// elements of b not in a in the order of b. The elements of an intersection
+// are visited in the order of the smaller operand, and the elements of a
+// difference in the order of a.
+//
+// Wrapping visit for the operands allocates a small constant amount per call,
+// but visiting an element does not allocate.
+func (v *View[T]) ForEach(visit func(item T) bool) {
+	switch v.op {
+	case viewUnion:
+		more := true
+		v.a.ForEach(func(item T) bool {
+			more = visit(item)
+			return more
+		})
+		if more {
+			v.b.ForEach(func(item T) bool {
+				return v.a.Contains(item) || visit(item)
+			})
+		}
+	case viewIntersect:
+		small, big := v.a, v.b
+		if small.Size() > big.Size() {
+			small, big = big, small
+		}
+		small.ForEach(func(item T) bool {
+			return !big.Contains(item) || visit(item)
+		})
+	default:
+		v.a.ForEach(func(item T) bool {
+			return v.b.Contains(item) || visit(item)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestView_Union(t *testing.T) {
+	a := From([]int{1, 2, 3})
+	b := TreeSetFrom[int, Compare[int]]([]int{3, 4, 5}, Cmp[int])
+	v := UnionView[int](a, b)
+
+	must.Eq(t, 5, v.Size())
+	must.False(t, v.Empty())
+	for _, i := range ints(5) {
+		must.True(t, v.Contains(i))
+	}
+	must.False(t, v.Contains(6))
+	must.True(t, a.EqualSlice(v.Slice()[:3]))
+	must.Eq(t, []int{4, 5}, v.Slice()[3:])
+
+	// modifications of the operands are visible
+	b.Insert(6)
+	must.True(t, v.Contains(6))
+	must.Eq(t, 6, v.Size())
+
+	must.True(t, UnionView[int](New[int](0), New[int](0)).Empty())
+}
+
+func TestView_Intersect(t *testing.T) {
+	a := From(ints(10))
+	b := From([]int{2, 4, 6, 8, 10, 12})
+	v := IntersectView[int](a, b)
+
+	must.Eq(t, 5, v.Size())
+	must.False(t, v.Empty())
+	must.True(t, v.Contains(4))
+	must.False(t, v.Contains(5))
+	must.False(t, v.Contains(12))
+	must.True(t, From([]int{2, 4, 6, 8, 10}).EqualSlice(v.Slice()))
+
+	must.True(t, IntersectView[int](From([]int{1}), From([]int{2})).Empty())
+	must.SliceEmpty(t, IntersectView[int](From([]int{1}), From([]int{2})).Slice())
+}
+
+func TestView_Difference(t *testing.T) {
+	a := From(ints(5))
+	b := From([]int{2, 4, 6})
+	v := DifferenceView[int](a, b)
+
+	must.Eq(t, 3, v.Size())
+	must.True(t, v.Contains(1))
+	must.False(t, v.Contains(2))
+	must.False(t, v.Contains(6))
+	must.True(t, From([]int{1, 3, 5}).EqualSlice(v.Slice()))
+
+	must.True(t, DifferenceView[int](b, From(ints(6))).Empty())
+}
+
+func TestView_nested(t *testing.T) {
+	a := From([]int{1, 2, 3, 4})
+	b := From([]int{3, 4, 5, 6})
+	c := From([]int{4, 6, 8})
+
+	// (a ∪ b) − c, and (a ∩ b) ∪ c
+	v := DifferenceView[int](UnionView[int](a, b), c)
+	must.True(t, a.Union(b).Difference(c).EqualSlice(v.Slice()))
+	must.Eq(t, 4, v.Size())
+
+	w := UnionView[int](IntersectView[int](a, b), c)
+	must.True(t, a.Intersect(b).Union(c).EqualSlice(w.Slice()))
+	must.Eq(t, 4, w.Size())
+}
+
+func TestView_ForEach(t *testing.T) {
+	v := UnionView[int](From(ints(3)), From([]int{4, 5, 6}))
+	for stop := 1; stop <= 6; stop++ {
+		visited := 0
+		v.ForEach(func(int) bool {
+			visited++
+			return visited < stop
+		})
+		must.Eq(t, stop, visited)
+	}
+}
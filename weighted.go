@@ -0,0 +1,253 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+	"sort"
+)
+
+// WeightedSet is a set of comparable elements, each with a non-negative
+// weight, from which elements can be picked at random with probability
+// proportional to their weight. A WeightedSet is useful for spreading work
+// across a set of targets in proportion to their capacity.
+//
+// The underlying data structure is a Fenwick tree of the weights of the
+// elements, so Insert, Remove and PickWeighted take O(log n) time.
+//
+// https://en.wikipedia.org/wiki/Fenwick_tree
+//
+// Not thread safe, and not safe for concurrent modification.
+type WeightedSet[T comparable] struct {
+	index   map[T]int
+	items   []T
+	weights []float64
+	tree    []float64 // 1-based; tree[i] sums the weights of (i-lowbit(i), i]
+	nonzero int       // number of elements with a positive weight
+}
+
+// NewWeightedSet creates a new empty WeightedSet with initial underlying
+// capacity of size.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func NewWeightedSet[T comparable](size int) *WeightedSet[T] {
+	size = max(0, size)
+	return &WeightedSet[T]{
+		index:   make(map[T]int, size),
+		items:   make([]T, 0, size),
+		weights: make([]float64, 0, size),
+		tree:    make([]float64, 1, size+1),
+	}
+}
+
+// WeightedSetFrom creates a new WeightedSet containing each item of weights,
+// with its weight.
+//
+// T must *not* be of pointer type, nor contain pointer fields, which are comparable
+// but not in the way you expect.
+func WeightedSetFrom[T comparable](weights map[T]float64) *WeightedSet[T] {
+	w := NewWeightedSet[T](len(weights))
+	for item, weight := range weights {
+		w.Insert(item, weight)
+	}
+	return w
+}
+
+// Insert item into w with the given weight, replacing the weight of item if
+// it is already in w.
+//
+// Return true if w was modified (item was not already in w, or had a different
+// weight), false otherwise.
+//
+// Insert panics if weight is negative, infinite, or NaN.
+func (w *WeightedSet[T]) Insert(item T, weight float64) bool {
+	if weight < 0 || math.IsInf(weight, 0) || math.IsNaN(weight) {
+		panic(fmt.Sprintf("weighted: invalid weight %v", weight))
+	}
+	if i, exists := w.index[item]; exists {
+		if w.weights[i] == weight {
+			return false
+		}
+		w.count(w.weights[i], weight)
+		w.add(i, weight-w.weights[i])
+		w.weights[i] = weight
+		return true
+	}
+	w.count(0, weight)
+
+	// the new node of the tree covers itself and the nodes preceding it
+	// down to its lowest set bit
+	n := len(w.items) + 1
+	w.index[item] = n - 1
+	w.items = append(w.items, item)
+	w.weights = append(w.weights, weight)
+	w.tree = append(w.tree, weight+w.prefix(n-1)-w.prefix(n-(n&-n)))
+	return true
+}
+
+// Remove item from w.
+//
+// Return true if w was modified (item was in w), false otherwise.
+func (w *WeightedSet[T]) Remove(item T) bool {
+	i, exists := w.index[item]
+	if !exists {
+		return false
+	}
+
+	// move the last element into the place of item, then drop the last node
+	// of the tree, which no other node depends on
+	w.count(w.weights[i], 0)
+	last := len(w.items) - 1
+	w.add(last, -w.weights[last])
+	if i != last {
+		moved := w.items[last]
+		w.add(i, w.weights[last]-w.weights[i])
+		w.items[i] = moved
+		w.weights[i] = w.weights[last]
+		w.index[moved] = i
+	}
+	var zero T
+	w.items[last] = zero
+	w.items = w.items[:last]
+	w.weights = w.weights[:last]
+	w.tree = w.tree[:last+1]
+	delete(w.index, item)
+	return true
+}
+
+// Weight returns the weight of item and true. If item is not in w, zero and
+// false are returned.
+func (w *WeightedSet[T]) Weight(item T) (float64, bool) {
+	i, exists := w.index[item]
+	if !exists {
+		return 0, false
+	}
+	return w.weights[i], true
+}
+
+// TotalWeight returns the sum of the weights of every element of w.
+func (w *WeightedSet[T]) TotalWeight() float64 {
+	return w.prefix(len(w.items))
+}
+
+// PickWeighted returns an element of w chosen at random using rng, with
+// probability proportional to its weight, and true. An element with a weight
+// of zero is never chosen. If w is empty or every weight is zero, the zero
+// value and false are returned. If rng is nil, the default source of package
+// math/rand is used.
+func (w *WeightedSet[T]) PickWeighted(rng *rand.Rand) (T, bool) {
+	total := w.TotalWeight()
+	if w.nonzero == 0 || total <= 0 {
+		var zero T
+		return zero, false
+	}
+	float := rand.Float64
+	if rng != nil {
+		float = rng.Float64
+	}
+	target := float() * total
+
+	// descend the tree to the last position whose prefix sum is <= target;
+	// the element following it is the one picked
+	n := len(w.items)
+	pos := 0
+	for step := 1 << (bits.Len(uint(n)) - 1); step > 0; step >>= 1 {
+		if next := pos + step; next <= n && w.tree[next] <= target {
+			pos = next
+			target -= w.tree[next]
+		}
+	}
+
+	// rounding may walk off the end; fall back to the last weighted element
+	for pos >= n || w.weights[pos] == 0 {
+		pos = (pos + n - 1) % n
+	}
+	return w.items[pos], true
+}
+
+// Contains returns whether item is present in w.
+func (w *WeightedSet[T]) Contains(item T) bool {
+	_, exists := w.index[item]
+	return exists
+}
+
+// Size returns the number of elements in w.
+func (w *WeightedSet[T]) Size() int {
+	return len(w.items)
+}
+
+// Empty returns true if w contains no elements, false otherwise.
+func (w *WeightedSet[T]) Empty() bool {
+	return len(w.items) == 0
+}
+
+// Slice creates a copy of w as a slice. Elements are in no particular order.
+func (w *WeightedSet[T]) Slice() []T {
+	result := make([]T, len(w.items))
+	copy(result, w.items)
+	return result
+}
+
+// ForEach calls visit for each element of w, in no particular order.
+// Iteration stops early if visit returns false.
+func (w *WeightedSet[T]) ForEach(visit func(item T) bool) {
+	for _, item := range w.items {
+		if !visit(item) {
+			return
+		}
+	}
+}
+
+// ForEachWeight calls visit for each element of w and its weight, in no
+// particular order. Iteration stops early if visit returns false.
+func (w *WeightedSet[T]) ForEachWeight(visit func(item T, weight float64) bool) {
+	for i, item := range w.items {
+		if !visit(item, w.weights[i]) {
+			return
+		}
+	}
+}
+
+// String creates a string representation of w, using "%v" printf formatting
+// to transform each element into a string, followed by its weight. The result
+// contains elements sorted by their lexical string order.
+func (w *WeightedSet[T]) String() string {
+	l := make([]string, 0, len(w.items))
+	for i, item := range w.items {
+		l = append(l, fmt.Sprintf("%v:%v", item, w.weights[i]))
+	}
+	sort.Strings(l)
+	return fmt.Sprintf("%s", l)
+}
+
+// count tracks the number of elements with a positive weight, as the weight
+// of an element changes from before to after.
+func (w *WeightedSet[T]) count(before, after float64) {
+	switch {
+	case before == 0 && after > 0:
+		w.nonzero++
+	case before > 0 && after == 0:
+		w.nonzero--
+	}
+}
+
+// add adds delta to the weight of the element at index i of the tree.
+func (w *WeightedSet[T]) add(i int, delta float64) {
+	for j := i + 1; j < len(w.tree); j += j & -j {
+		w.tree[j] += delta
+	}
+}
+
+// prefix returns the sum of the weights of the first n elements.
+func (w *WeightedSet[T]) prefix(n int) float64 {
+	sum := 0.0
+	for j := n; j > 0; j -= j & -j {
+		sum += w.tree[j]
+	}
+	return sum
+}
@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package set
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestWeightedSet_Insert(t *testing.T) {
+	w := NewWeightedSet[string](0)
+	must.True(t, w.Empty())
+
+	must.True(t, w.Insert("a", 1))
+	must.True(t, w.Insert("b", 2.5))
+	must.False(t, w.Insert("a", 1))
+	must.True(t, w.Insert("a", 3))
+	must.Eq(t, 2, w.Size())
+	must.Eq(t, 5.5, w.TotalWeight())
+
+	weight, ok := w.Weight("a")
+	must.True(t, ok)
+	must.Eq(t, 3, weight)
+	_, ok = w.Weight("c")
+	must.False(t, ok)
+
+	must.True(t, w.Contains("b"))
+	must.False(t, w.Contains("c"))
+	must.Eq(t, "[a:3 b:2.5]", w.String())
+}
+
+func TestWeightedSet_Insert_invalid(t *testing.T) {
+	for _, weight := range []float64{-1, math.Inf(1), math.NaN()} {
+		w := NewWeightedSet[int](0)
+		mustPanic(t, "invalid weight", func() { w.Insert(1, weight) })
+	}
+}
+
+func TestWeightedSet_Remove(t *testing.T) {
+	w := WeightedSetFrom(map[int]float64{1: 1, 2: 2, 3: 3, 4: 4, 5: 5})
+	must.False(t, w.Remove(6))
+	must.True(t, w.Remove(2))
+	must.False(t, w.Remove(2))
+	must.True(t, w.Remove(5))
+	must.Eq(t, 3, w.Size())
+	must.Eq(t, 8, w.TotalWeight())
+	must.SliceContainsAll(t, []int{1, 3, 4}, w.Slice())
+
+	for _, item := range []int{1, 3, 4} {
+		must.True(t, w.Remove(item))
+	}
+	must.True(t, w.Empty())
+	must.Eq(t, 0, w.TotalWeight())
+
+	// the tree is rebuilt correctly after shrinking
+	must.True(t, w.Insert(7, 7))
+	must.Eq(t, 7, w.TotalWeight())
+}
+
+func TestWeightedSet_PickWeighted(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+
+	t.Run("empty", func(t *testing.T) {
+		w := NewWeightedSet[string](0)
+		_, ok := w.PickWeighted(rng)
+		must.False(t, ok)
+
+		w.Insert("a", 0)
+		_, ok = w.PickWeighted(rng)
+		must.False(t, ok)
+	})
+
+	t.Run("zero weights", func(t *testing.T) {
+		w := WeightedSetFrom(map[string]float64{"a": 0, "b": 1, "c": 0})
+		for range 100 {
+			item, ok := w.PickWeighted(rng)
+			must.True(t, ok)
+			must.Eq(t, "b", item)
+		}
+	})
+
+	t.Run("nil rng", func(t *testing.T) {
+		w := WeightedSetFrom(map[string]float64{"a": 1})
+		item, ok := w.PickWeighted(nil)
+		must.True(t, ok)
+		must.Eq(t, "a", item)
+	})
+
+	t.Run("proportional", func(t *testing.T) {
+		w := NewWeightedSet[int](10)
+		for i := range 10 {
+			w.Insert(i, float64(i+1))
+		}
+		// churn the tree before sampling
+		w.Remove(0)
+		w.Insert(3, 10)
+		w.Insert(0, 1)
+
+		const n = 200_000
+		counts := make(map[int]int)
+		for range n {
+			item, _ := w.PickWeighted(rng)
+			counts[item]++
+		}
+		total := w.TotalWeight()
+		w.ForEachWeight(func(item int, weight float64) bool {
+			expect := weight / total
+			actual := float64(counts[item]) / n
+			must.Less(t, 0.005, math.Abs(expect-actual))
+			return true
+		})
+	})
+}
+
+func TestWeightedSet_ForEach(t *testing.T) {
+	w := WeightedSetFrom(map[int]float64{1: 1, 2: 2, 3: 3})
+	visited := 0
+	w.ForEach(func(int) bool {
+		visited++
+		return visited < 2
+	})
+	must.Eq(t, 2, visited)
+
+	sum := 0.0
+	w.ForEachWeight(func(_ int, weight float64) bool {
+		sum += weight
+		return true
+	})
+	must.Eq(t, 6, sum)
+}